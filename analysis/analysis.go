@@ -129,6 +129,7 @@ func CombineBest(baseFolder string) error {
 							RandTreeDepth: params.Strategies.DepthOfRandomNewTrees,
 							DivByZero:     params.SpecParam.DivideByZeroStrategy,
 							DivByZeroPen:  params.SpecParam.DivideByZeroPenalty,
+							ErrorMetric:   params.FitnessStrategy.ResolvedErrorMetric(),
 						}
 						(accCSV) = append(accCSV, csvBest)
 						return err
@@ -237,6 +238,7 @@ func CombineGenerations(baseFolder string) error {
 							RandTreeDepth: params.Strategies.DepthOfRandomNewTrees,
 							DivByZero:     params.SpecParam.DivideByZeroStrategy,
 							DivByZeroPen:  params.SpecParam.DivideByZeroPenalty,
+							ErrorMetric:   params.FitnessStrategy.ResolvedErrorMetric(),
 							TopologyScale:  ordinal,
 							CrossoverScale: toOrdinal,
 							CrossoverType:  params.Reproduction.CrossoverStrategy,
@@ -426,6 +428,7 @@ func CombineBestCombinedAll(baseFolder string) error {
 								RandTreeDepth: params.Strategies.DepthOfRandomNewTrees,
 								DivByZero:     params.SpecParam.DivideByZeroStrategy,
 								DivByZeroPen:  params.SpecParam.DivideByZeroPenalty,
+								ErrorMetric:   params.FitnessStrategy.ResolvedErrorMetric(),
 
 								TournamentSize: params.Selection.Parent.TournamentSize,
 								SurvivorPercent: params.Selection.Survivor.SurvivorPercentage,
@@ -589,6 +592,7 @@ func CombineBestCombinedAll2(baseFolder string) error {
 								RandTreeDepth:  params.Strategies.DepthOfRandomNewTrees,
 								DivByZero:      params.SpecParam.DivideByZeroStrategy,
 								DivByZeroPen:   params.SpecParam.DivideByZeroPenalty,
+								ErrorMetric:    params.FitnessStrategy.ResolvedErrorMetric(),
 								TopologyScale:  ordinal,
 								CrossoverScale: toOrdinal,
 								CrossoverType:  params.Reproduction.CrossoverStrategy,
@@ -112,6 +112,7 @@ type CSVBestAll struct {
 	SurvivorPercent    float64 `csv:"survivorPercent"`
 	DivByZero          string  `csv:"d0"`
 	DivByZeroPen       float64 `csv:"d0Pen"`
+	ErrorMetric        string  `csv:"errorMetric"`
 
 	CrossoverType   string `csv:"crossoverType"`
 	CrossoverScale int `csv:"crossoverScale"`
@@ -185,6 +186,7 @@ type CSVCombinedGenerations struct {
 	SurvivorPercent    float64 `csv:"survivorPercent"`
 	DivByZero          string  `csv:"d0"`
 	DivByZeroPen       float64 `csv:"d0Pen"`
+	ErrorMetric        string  `csv:"errorMetric"`
 	TopologyScale      int `csv:"topologyScale"`
 	CrossoverScale int `csv:"crossoverScale"`
 	CrossoverType string `csv:"crossoverType"`
@@ -76,6 +76,46 @@ func (n *Leaf) Eval() (Number, bool) {
 //	return fmt.Sprintf("%v", n.value)  // %v = default format
 //}
 
+// Variable AST node. A variable has no value of its own, it can only be resolved once the tree has been compiled
+// (see Compile) and evaluated against a set of variables.
+type Variable struct {
+	name string
+}
+
+func (n *Variable) Init(name string) Node {
+	n.name = name
+	return n
+}
+
+func (n *Variable) Eval() (Number, bool) {
+	return 0, false
+}
+
+// FunctionCall AST node e.g. sin(x) or pow(x,2). See FunctionArity for the available functions.
+type FunctionCall struct {
+	name string
+	args []Node
+}
+
+func (n *FunctionCall) Init(name string, args []Node) Node {
+	n.name = name
+	n.args = args
+	return n
+}
+
+func (n *FunctionCall) Eval() (Number, bool) {
+	values := make([]float64, len(n.args))
+	for i := range n.args {
+		value, ok := n.args[i].Eval()
+		if !ok {
+			return Number(math.NaN()), false
+		}
+		values[i] = float64(value)
+	}
+	result, ok := applyFunction(n.name, values)
+	return Number(result), ok
+}
+
 /* ==== Lexer ==== */
 
 type Lexer struct {
@@ -84,6 +124,7 @@ type Lexer struct {
 	Kind int
 	Num  Number
 	Oper byte
+	Name string
 }
 
 func (lexer *Lexer) Init(data string) *Lexer {
@@ -117,6 +158,15 @@ func (l *Lexer) Next() int {
 			}
 			l.Kind = NUM
 			l.Num = value / divisor
+		case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't',
+			'u', 'v', 'w', 'x', 'y', 'z', 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P',
+			'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z', '_':
+			start := l.pos
+			for ; l.pos < n && isIdentifierChar(l.data[l.pos]); l.pos++ {
+			}
+			l.Kind = VAR
+			l.Num = 0
+			l.Name = l.data[start:l.pos]
 		case ')':
 			l.pos++
 			l.Kind = RPAR
@@ -125,10 +175,14 @@ func (l *Lexer) Next() int {
 			l.pos++
 			l.Kind = LPAR
 			l.Oper = char
+		case ',':
+			l.pos++
+			l.Kind = COMMA
+			l.Oper = char
 		case '+', '-', '*', '/':
 			if char == '-' {
 				switch prevChar {
-				case '+', '-', '*', '/', '(':
+				case '+', '-', '*', '/', '(', ',':
 					l.pos++
 					l.Kind = NEG
 					l.Oper = char
@@ -147,11 +201,17 @@ func (l *Lexer) Next() int {
 	return l.Kind
 }
 
+func isIdentifierChar(char byte) bool {
+	return ('a' <= char && char <= 'z') || ('A' <= char && char <= 'Z') || ('0' <= char && char <= '9') || char == '_'
+}
+
 /* ==== Parser ==== */
 
 type Parser struct {
 	lexer      *Lexer
 	precedence map[byte]int
+	// negateVar is set when a negative sign directly precedes a variable e.g. (-x)
+	negateVar bool
 }
 
 func (p *Parser) Init(data string) *Parser {
@@ -185,11 +245,34 @@ func (p *Parser) parsePrimary() (Node, bool) {
 		node := new(Leaf).Init(p.lexer.Num)
 		p.lexer.Next()
 		return node, true
+	case VAR:
+		name := p.lexer.Name
+		negate := p.negateVar
+		p.negateVar = false
+		p.lexer.Next()
+		var node Node
+		if p.lexer.Kind == LPAR {
+			args, ok := p.parseArguments()
+			if !ok {
+				return nil, false
+			}
+			node = new(FunctionCall).Init(name, args)
+		} else {
+			node = new(Variable).Init(name)
+		}
+		if negate {
+			node = new(Binary).Init('*', new(Leaf).Init(-1), node)
+		}
+		return node, true
 	case LPAR:
 		p.lexer.Next()
 		if p.lexer.Kind == NEG {
 			p.lexer.Next()
-			p.lexer.Num = p.lexer.Num * -1
+			if p.lexer.Kind == VAR {
+				p.negateVar = true
+			} else {
+				p.lexer.Num = p.lexer.Num * -1
+			}
 		}
 		node, ok := p.Parse()
 		if !ok {
@@ -201,6 +284,10 @@ func (p *Parser) parsePrimary() (Node, bool) {
 		return node, true
 	case NEG:
 		p.lexer.Next()
+		if p.lexer.Kind == VAR {
+			p.negateVar = true
+			return p.parsePrimary()
+		}
 		p.lexer.Num = p.lexer.Num * -1
 		node := new(Leaf).Init(p.lexer.Num)
 		return node, true
@@ -208,6 +295,33 @@ func (p *Parser) parsePrimary() (Node, bool) {
 	return nil, false
 }
 
+// parseArguments parses the comma separated arguments of a function call e.g. (x,2).
+// The lexer is expected to be on the opening parenthesis.
+func (p *Parser) parseArguments() ([]Node, bool) {
+	args := make([]Node, 0)
+	p.lexer.Next()
+	for {
+		arg, ok := p.Parse()
+		if !ok {
+			return nil, false
+		}
+		// A negative number is left as the current token by the NEG case of parsePrimary, skip past it.
+		if p.lexer.Kind == NUM && p.lexer.Num < 0 {
+			p.lexer.Next()
+		}
+		args = append(args, arg)
+		if p.lexer.Kind != COMMA {
+			break
+		}
+		p.lexer.Next()
+	}
+	if p.lexer.Kind != RPAR {
+		return nil, false
+	}
+	p.lexer.Next()
+	return args, true
+}
+
 const (
 	ERR  = iota // error
 	NUM         // number
@@ -215,6 +329,8 @@ const (
 	RPAR        // right parenthesis
 	OP          // operator
 	NEG         // a negative sign
+	VAR         // a variable or function name e.g. x or sin
+	COMMA       // separates function arguments
 )
 
 func (p *Parser) parseOperators(lhs Node, min_precedence int) (Node, bool) {
@@ -283,11 +399,55 @@ func CalculateWithVar(substitutedExpression string, variables map[string]float64
 	if variables == nil {
 		return Calculate(substitutedExpression)
 	}
-	for key := range variables {
-		floatStr := strconv.FormatFloat(variables[key], 'g', 10, 64)
-		substitutedExpression = strings.ReplaceAll(substitutedExpression, key, floatStr)
+	return Calculate(SubstituteVariables(substitutedExpression, variables))
+}
+
+// SubstituteVariables replaces every variable in the expression with its value from the variables map.
+// Substitution is token aware, only whole identifiers are replaced so x will not clash with x1 or with the x in exp.
+// Identifiers that are not present in the map are left untouched.
+func SubstituteVariables(expression string, variables map[string]float64) string {
+	if len(variables) == 0 {
+		return expression
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(expression))
+	n := len(expression)
+	for pos := 0; pos < n; {
+		char := expression[pos]
+		switch {
+		case '0' <= char && char <= '9' || char == '.':
+			// Skip numbers as a whole so an exponent such as 1e5 is not read as an identifier
+			start := pos
+			for ; pos < n && ('0' <= expression[pos] && expression[pos] <= '9' || expression[pos] == '.'); pos++ {
+			}
+			if pos < n && (expression[pos] == 'e' || expression[pos] == 'E') {
+				exponent := pos + 1
+				if exponent < n && (expression[exponent] == '+' || expression[exponent] == '-') {
+					exponent++
+				}
+				if exponent < n && '0' <= expression[exponent] && expression[exponent] <= '9' {
+					for pos = exponent; pos < n && '0' <= expression[pos] && expression[pos] <= '9'; pos++ {
+					}
+				}
+			}
+			sb.WriteString(expression[start:pos])
+		case isIdentifierChar(char):
+			start := pos
+			for ; pos < n && isIdentifierChar(expression[pos]); pos++ {
+			}
+			name := expression[start:pos]
+			if value, ok := variables[name]; ok {
+				sb.WriteString(strconv.FormatFloat(value, 'g', 10, 64))
+			} else {
+				sb.WriteString(name)
+			}
+		default:
+			sb.WriteByte(char)
+			pos++
+		}
 	}
-	return Calculate(substitutedExpression)
+	return sb.String()
 }
 
 // NegativeNumberParser adds a 0 if an odd number is at the start of a string
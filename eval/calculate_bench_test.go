@@ -81,6 +81,44 @@ func BenchmarkCalculateWithVarX(b *testing.B) {
 	b.Log(x1)
 }
 
+// BenchmarkCompiledWithVarX compiles the expression once and evaluates it for every iteration,
+// this is how fitness evaluation uses the evaluator across a spec.
+func BenchmarkCompiledWithVarX(b *testing.B) {
+	b.ReportAllocs()
+	var x1 float64
+	evaluator, err := Compile(exprLong)
+	if err != nil {
+		b.Fatal(err)
+	}
+	variables := map[string]float64{"x": 12}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ans, err := evaluator.EvaluateWithVar(variables)
+		if err != nil {
+			b.Error(err)
+		}
+		x1 = ans
+	}
+	b.Log(x1)
+}
+
+func BenchmarkCompileWithVarX(b *testing.B) {
+	b.ReportAllocs()
+	var x1 float64
+	for i := 0; i < b.N; i++ {
+		evaluator, err := Compile(exprLong)
+		if err != nil {
+			b.Error(err)
+		}
+		ans, err := evaluator.EvaluateWithVar(map[string]float64{"x": 12})
+		if err != nil {
+			b.Error(err)
+		}
+		x1 = ans
+	}
+	b.Log(x1)
+}
+
 func BenchmarkGValWithVarX(b *testing.B) {
 	b.ReportAllocs()
 	var x1 float64
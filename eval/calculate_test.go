@@ -64,6 +64,29 @@ func TestCalculate(t *testing.T) {
 	}
 }
 
+func TestSubstituteVariables(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		variables  map[string]float64
+		want       string
+	}{
+		{"nil map", "x+1", nil, "x+1"},
+		{"x", "(x)*(x)", map[string]float64{"x": -3}, "(-3)*(-3)"},
+		{"x and x1", "x1+x", map[string]float64{"x": 2, "x1": 3}, "3+2"},
+		{"function name", "exp(x)+max", map[string]float64{"x": 2}, "exp(2)+max"},
+		{"unbound", "x+y", map[string]float64{"x": 2}, "2+y"},
+		{"number exponent", "1e5+e", map[string]float64{"e": 2}, "1e5+2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SubstituteVariables(tt.expression, tt.variables); got != tt.want {
+				t.Errorf("SubstituteVariables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCalculateWithVar(t *testing.T) {
 	type args struct {
 		substitutedExpression string
@@ -83,6 +106,9 @@ func TestCalculateWithVar(t *testing.T) {
 		{"x + y", args{"x+y", map[string]float64{"x": 1, "y": 2}}, 3, false},
 		{"x * y", args{"x*y", map[string]float64{"x": 1, "y": 2}}, 2, false},
 		{"x * y * a* b", args{"x*y*a*b", map[string]float64{"x": 1, "y": 2, "a": 3, "b": 4}}, 24, false},
+		{"x1 * x", args{"x1*x", map[string]float64{"x": 2, "x1": 3}}, 6, false},
+		{"exp(x)", args{"exp(x)", map[string]float64{"x": 0}}, 1, false},
+		{"xy + y", args{"xy+y", map[string]float64{"xy": 10, "y": 2}}, 12, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,150 @@
+package eval
+
+import (
+	"fmt"
+	"math"
+)
+
+// Evaluator is an expression that has been compiled once into a tree of closures. It can be evaluated against many
+// sets of variables without any of the string substitution or re-parsing that CalculateWithVar performs.
+// An Evaluator returns false if the expression could not be evaluated e.g. a divide by zero or an unbound variable.
+type Evaluator func(variables map[string]float64) (float64, bool)
+
+// Constant returns an Evaluator that always returns the given value
+func Constant(value float64) Evaluator {
+	return func(variables map[string]float64) (float64, bool) {
+		return value, true
+	}
+}
+
+// Var returns an Evaluator that resolves the given variable name at evaluation time.
+// Variables that are not present in the map are treated as invalid.
+func Var(name string) Evaluator {
+	return func(variables map[string]float64) (float64, bool) {
+		value, ok := variables[name]
+		return value, ok
+	}
+}
+
+// BinaryOperator returns an Evaluator that applies one of +, -, *, / to the left and right evaluators.
+// Divide by 0 is treated as invalid in the same way as Calculate.
+func BinaryOperator(op byte, left, right Evaluator) (Evaluator, error) {
+	if left == nil || right == nil {
+		return nil, fmt.Errorf("BinaryOperator | operator %c cannot have a nil operand", op)
+	}
+	switch op {
+	case '+':
+		return func(variables map[string]float64) (float64, bool) {
+			l, ok := left(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			r, ok := right(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			return l + r, true
+		}, nil
+	case '-':
+		return func(variables map[string]float64) (float64, bool) {
+			l, ok := left(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			r, ok := right(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			return l - r, true
+		}, nil
+	case '*':
+		return func(variables map[string]float64) (float64, bool) {
+			l, ok := left(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			r, ok := right(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			return l * r, true
+		}, nil
+	case '/':
+		return func(variables map[string]float64) (float64, bool) {
+			l, ok := left(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			r, ok := right(variables)
+			if !ok || r == 0 {
+				return math.NaN(), false
+			}
+			return l / r, true
+		}, nil
+	}
+	return nil, fmt.Errorf("BinaryOperator | unknown operator %c", op)
+}
+
+// Compile parses the given expression once and returns an Evaluator for it.
+// Variables are left unbound and are resolved each time the Evaluator is called.
+func Compile(expression string) (Evaluator, error) {
+	if expression == "" {
+		return nil, fmt.Errorf("Compile | expression cannot be empty")
+	}
+
+	expression = NegativeNumberParser(expression)
+	p := new(Parser).Init(expression)
+	p.AddOperator('+', 1)
+	p.AddOperator('-', 1)
+	p.AddOperator('*', 2)
+	p.AddOperator('/', 2)
+	node, ok := p.Parse()
+	if !ok {
+		return nil, fmt.Errorf("%s = Invalid Syntax error\n", expression)
+	}
+	return compileNode(node)
+}
+
+// compileNode converts a parsed AST into an Evaluator
+func compileNode(node Node) (Evaluator, error) {
+	switch n := node.(type) {
+	case *Leaf:
+		return Constant(float64(n.value)), nil
+	case *Variable:
+		return Var(n.name), nil
+	case *Binary:
+		left, err := compileNode(n.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compileNode(n.right)
+		if err != nil {
+			return nil, err
+		}
+		return BinaryOperator(n.op, left, right)
+	case *FunctionCall:
+		args := make([]Evaluator, len(n.args))
+		for i := range n.args {
+			arg, err := compileNode(n.args[i])
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+		}
+		return Function(n.name, args...)
+	}
+	return nil, fmt.Errorf("compileNode | unknown node type %T", node)
+}
+
+// EvaluateWithVar evaluates the compiled expression against the given variables.
+// It mirrors CalculateWithVar and returns an error if the expression could not be evaluated.
+func (e Evaluator) EvaluateWithVar(variables map[string]float64) (float64, error) {
+	if e == nil {
+		return math.NaN(), fmt.Errorf("EvaluateWithVar | evaluator is nil")
+	}
+	result, ok := e(variables)
+	if !ok {
+		return math.NaN(), fmt.Errorf("Invalid Evaluation error")
+	}
+	return result, nil
+}
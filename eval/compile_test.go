@@ -0,0 +1,74 @@
+package eval
+
+import (
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	type args struct {
+		expression string
+		variables  map[string]float64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       float64
+		wantErr    bool
+		wantEvaErr bool
+	}{
+		{"empty", args{"", nil}, 0.0, true, false},
+		{"1", args{"1", nil}, 1, false, false},
+		{"x without map", args{"x", nil}, 0.0, false, true},
+		{"x with map", args{"x", map[string]float64{"x": 1}}, 1, false, false},
+		{"x+y (y no map)", args{"x+y", map[string]float64{"x": 1}}, 0, false, true},
+		{"x+y", args{"x+y", map[string]float64{"x": 1, "y": 2}}, 3, false, false},
+		{"x*y*a*b", args{"x*y*a*b", map[string]float64{"x": 1, "y": 2, "a": 3, "b": 4}}, 24, false, false},
+		{"x1*x2", args{"x1*x2", map[string]float64{"x1": 3, "x2": 4}}, 12, false, false},
+		{"x-negative", args{"(x)*(x)", map[string]float64{"x": -3}}, 9, false, false},
+		{"(-x)", args{"(-x)", map[string]float64{"x": 3}}, -3, false, false},
+		{"-x+1", args{"-x+1", map[string]float64{"x": 3}}, -2, false, false},
+		{"(x)/(x)", args{"(x)/(x)", map[string]float64{"x": 0}}, 0, false, true},
+		{"precedence", args{"x+x*2", map[string]float64{"x": 3}}, 9, false, false},
+		{"exprLong", args{exprLong, map[string]float64{"x": 12}}, -126854411607, false, false},
+		{"sin(0)", args{"sin(0)", nil}, 0, false, false},
+		{"cos(x)", args{"cos(x)", map[string]float64{"x": 0}}, 1, false, false},
+		{"exp(x)", args{"exp(x)", map[string]float64{"x": 0}}, 1, false, false},
+		{"log(x)", args{"log(x)", map[string]float64{"x": 1}}, 0, false, false},
+		{"log(0)", args{"log(x)", map[string]float64{"x": 0}}, 0, false, true},
+		{"log(-1)", args{"log(x)", map[string]float64{"x": -1}}, 0, false, true},
+		{"sqrt(x)", args{"sqrt(x)", map[string]float64{"x": 16}}, 4, false, false},
+		{"sqrt(-1)", args{"sqrt(x)", map[string]float64{"x": -1}}, 0, false, true},
+		{"abs(-x)", args{"abs(-x)", map[string]float64{"x": 3}}, 3, false, false},
+		{"neg(x)", args{"neg(x)", map[string]float64{"x": 3}}, -3, false, false},
+		{"pow(x,2)", args{"pow(x,2)", map[string]float64{"x": -3}}, 9, false, false},
+		{"pow(-x,3)", args{"pow(-x,3)", map[string]float64{"x": 2}}, -8, false, false},
+		{"pow overflow", args{"pow(x,1000)", map[string]float64{"x": 10}}, 0, false, true},
+		{"nested", args{"pow(sqrt(x),2)*2+abs(x-20)", map[string]float64{"x": 4}}, 24, false, false},
+		{"unknown function", args{"foo(x)", nil}, 0, true, false},
+		{"wrong arity", args{"pow(x)", nil}, 0, true, false},
+		{"unclosed", args{"sin(x", nil}, 0, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluator, err := Compile(tt.args.expression)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			got, err := evaluator.EvaluateWithVar(tt.args.variables)
+			if (err != nil) != tt.wantEvaErr {
+				t.Errorf("EvaluateWithVar() error = %v, wantEvaErr %v", err, tt.wantEvaErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateWithVar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package eval
+
+import (
+	"fmt"
+	"math"
+)
+
+// Named functions that can be used as non-terminals. Unary functions take a single argument e.g. sin(x),
+// binary functions take two e.g. pow(x,2)
+const (
+	FunctionSin  = "sin"
+	FunctionCos  = "cos"
+	FunctionExp  = "exp"
+	FunctionLog  = "log"
+	FunctionSqrt = "sqrt"
+	FunctionAbs  = "abs"
+	FunctionNeg  = "neg"
+	FunctionPow  = "pow"
+)
+
+// unaryFunctions return false if the argument falls outside the domain of the function e.g. log(-1),
+// or if the result is not a finite number. This allows callers to treat domain errors the same way as a divide by zero.
+var unaryFunctions = map[string]func(float64) (float64, bool){
+	FunctionSin: func(x float64) (float64, bool) { return finite(math.Sin(x)) },
+	FunctionCos: func(x float64) (float64, bool) { return finite(math.Cos(x)) },
+	FunctionExp: func(x float64) (float64, bool) { return finite(math.Exp(x)) },
+	FunctionLog: func(x float64) (float64, bool) {
+		if x <= 0 {
+			return math.NaN(), false
+		}
+		return finite(math.Log(x))
+	},
+	FunctionSqrt: func(x float64) (float64, bool) {
+		if x < 0 {
+			return math.NaN(), false
+		}
+		return finite(math.Sqrt(x))
+	},
+	FunctionAbs: func(x float64) (float64, bool) { return math.Abs(x), true },
+	FunctionNeg: func(x float64) (float64, bool) { return -x, true },
+}
+
+var binaryFunctions = map[string]func(float64, float64) (float64, bool){
+	FunctionPow: func(x, y float64) (float64, bool) { return finite(math.Pow(x, y)) },
+}
+
+func finite(value float64) (float64, bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return math.NaN(), false
+	}
+	return value, true
+}
+
+// FunctionArity returns the number of arguments a named function takes. ok is false if the function is unknown.
+func FunctionArity(name string) (arity int, ok bool) {
+	if _, ok := unaryFunctions[name]; ok {
+		return 1, true
+	}
+	if _, ok := binaryFunctions[name]; ok {
+		return 2, true
+	}
+	return 0, false
+}
+
+// IsFunction returns true if the name refers to a known function such as sin or pow
+func IsFunction(name string) bool {
+	_, ok := FunctionArity(name)
+	return ok
+}
+
+// Function returns an Evaluator that applies the named function to the given arguments.
+// The number of arguments must match the arity of the function.
+func Function(name string, args ...Evaluator) (Evaluator, error) {
+	for i := range args {
+		if args[i] == nil {
+			return nil, fmt.Errorf("Function | %s cannot have a nil argument", name)
+		}
+	}
+
+	if f, ok := unaryFunctions[name]; ok {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("Function | %s takes 1 argument, got %d", name, len(args))
+		}
+		arg := args[0]
+		return func(variables map[string]float64) (float64, bool) {
+			x, ok := arg(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			return f(x)
+		}, nil
+	}
+
+	if f, ok := binaryFunctions[name]; ok {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("Function | %s takes 2 arguments, got %d", name, len(args))
+		}
+		left, right := args[0], args[1]
+		return func(variables map[string]float64) (float64, bool) {
+			x, ok := left(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			y, ok := right(variables)
+			if !ok {
+				return math.NaN(), false
+			}
+			return f(x, y)
+		}, nil
+	}
+
+	return nil, fmt.Errorf("Function | unknown function %s", name)
+}
+
+// applyFunction evaluates a named function against already evaluated arguments
+func applyFunction(name string, args []float64) (float64, bool) {
+	if f, ok := unaryFunctions[name]; ok && len(args) == 1 {
+		return f(args[0])
+	}
+	if f, ok := binaryFunctions[name]; ok && len(args) == 2 {
+		return f(args[0], args[1])
+	}
+	return math.NaN(), false
+}
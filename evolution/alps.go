@@ -0,0 +1,146 @@
+package evolution
+
+import (
+	"fmt"
+	"sort"
+)
+
+// IsEnabled returns true if the populations are split into age layers.
+func (a ALPS) IsEnabled() bool {
+	return a.Layers > 1
+}
+
+// layerSize returns the number of individuals in each age layer.
+func (a ALPS) layerSize(eachPopulationSize int) int {
+	return eachPopulationSize / a.Layers
+}
+
+// shouldInject returns true if the bottom layer is replaced by random individuals when selecting the survivors of the
+// given generation.
+func (a ALPS) shouldInject(generation int) bool {
+	return (generation+1)%a.AgeGap == 0
+}
+
+// validate checks that the population can be split into age layers that each satisfy the selection parameters.
+func (a ALPS) validate(params EvolutionParams) error {
+	if !a.IsEnabled() {
+		return nil
+	}
+	if a.AgeGap < 1 {
+		return fmt.Errorf("AgeGap must be at least 1 when using ALPS")
+	}
+	if params.EachPopulationSize%a.Layers != 0 {
+		return fmt.Errorf("EachPopulationSize must be divisible by the number of ALPS layers")
+	}
+	layerSize := a.layerSize(params.EachPopulationSize)
+	if layerSize < 2 || layerSize%2 != 0 {
+		return fmt.Errorf("each ALPS layer must hold an even number of at least 2 individuals")
+	}
+	if params.Selection.Parent.Type == ParentSelectionTournament && params.Selection.Parent.TournamentSize >= layerSize {
+		return fmt.Errorf("Tournament Size should not be greater than the size of an ALPS layer.")
+	}
+	if err := params.Selection.Parent.validate(layerSize); err != nil {
+		return err
+	}
+	return params.Selection.Survivor.validate(layerSize)
+}
+
+// AgeLayers splits the population into the given number of equally sized layers ordered from the youngest to the
+// oldest individuals. Individuals of the same Age keep their order in the population.
+func AgeLayers(population []*Individual, layers int) ([][]*Individual, error) {
+	if layers < 1 {
+		return nil, fmt.Errorf("AgeLayers | layers must be at least 1")
+	}
+	if len(population) < layers || len(population)%layers != 0 {
+		return nil, fmt.Errorf("AgeLayers | a population of %d cannot be split into %d layers", len(population),
+			layers)
+	}
+
+	sorted := make([]*Individual, len(population))
+	copy(sorted, population)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Age < sorted[j].Age
+	})
+
+	layerSize := len(population) / layers
+	ageLayers := make([][]*Individual, layers)
+	for i := range ageLayers {
+		ageLayers[i] = sorted[i*layerSize : (i+1)*layerSize : (i+1)*layerSize]
+	}
+	return ageLayers, nil
+}
+
+// selectLayeredSurvivors applies parent selection, reproduction and survivor selection within each age layer of the
+// population, so individuals only compete for and breed with individuals of a similar age. Children inherit the Age
+// of their oldest parent plus one so they stay in the age band of their parents. Every ALPS.AgeGap generations
+// the survivors of the bottom layer are replaced by new random individuals.
+func (g *Generation) selectLayeredSurvivors(population []*Individual, kind int) ([]*Individual, error) {
+	alps := g.engine.Parameters.ALPS
+	layers, err := AgeLayers(population, alps.Layers)
+	if err != nil {
+		return nil, err
+	}
+
+	layerEngine := &EvolutionEngine{Parameters: g.engine.Parameters}
+	layerEngine.Parameters.EachPopulationSize = len(layers[0])
+	layerEngine.Parameters.Reproduction.ProbabilityOfMutation = g.mutationRate(kind)
+	layerGeneration := &Generation{engine: layerEngine, count: g.count}
+
+	survivors := make([]*Individual, 0, len(population))
+	for _, layer := range layers {
+		winnerParents, err := layerGeneration.ApplyParentSelection(layer)
+		if err != nil {
+			return nil, err
+		}
+		selectedParents, selectedChildren, err := layerGeneration.ApplyReproduction(winnerParents, kind)
+		if err != nil {
+			return nil, err
+		}
+		inheritAge(selectedParents, selectedChildren)
+		layerSurvivors, err := layerGeneration.ApplySurvivorSelection(selectedParents, selectedChildren)
+		if err != nil {
+			return nil, err
+		}
+		survivors = append(survivors, layerSurvivors...)
+	}
+
+	if alps.shouldInject(g.count) {
+		injected, err := g.GenerateRandomIndividuals(kind, layerEngine.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		for i := range injected {
+			injected[i].Id = GenerateIndividualID("", kind)
+			injected[i].BirthGen = g.count + 1
+			injected[i].Age = 0
+			survivors[i] = injected[i]
+		}
+	}
+	return survivors, nil
+}
+
+// inheritAge sets the Age of each child to the Age of the older of the two parents it was bred from plus one.
+// Children are bred in pairs from consecutive parents, see ApplyReproduction.
+func inheritAge(parents, children []*Individual) {
+	if len(parents) < 1 {
+		return
+	}
+	for i := range children {
+		if children[i] == nil {
+			continue
+		}
+		first := i - i%2
+		if first >= len(parents) {
+			continue
+		}
+		second := first + 1
+		if second >= len(parents) {
+			second = first
+		}
+		age := parents[first].Age
+		if parents[second].Age > age {
+			age = parents[second].Age
+		}
+		children[i].Age = age + 1
+	}
+}
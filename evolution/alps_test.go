@@ -0,0 +1,152 @@
+package evolution
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// alpsTestPopulation returns protagonists with the given ages.
+func alpsTestPopulation(ages ...int) []*Individual {
+	population := make([]*Individual, len(ages))
+	for i := range ages {
+		population[i] = &Individual{
+			Id:             string(rune('a' + i)),
+			Kind:           IndividualProtagonist,
+			Age:            ages[i],
+			AverageFitness: float64(i) / 10,
+			Strategy:       []Strategy{StrategyAddToLeafX, StrategyAddXD, StrategyAddToLeaf, StrategyAddXD},
+		}
+	}
+	return population
+}
+
+func TestAgeLayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		ages    []int
+		layers  int
+		want    [][]string
+		wantErr bool
+	}{
+		{"no-layers", []int{0, 1}, 0, nil, true},
+		{"uneven", []int{0, 1, 2}, 2, nil, true},
+		{"too-many-layers", []int{0, 1}, 4, nil, true},
+		{"single-layer", []int{3, 1, 2, 0}, 1, [][]string{{"d", "b", "c", "a"}}, false},
+		{"two-layers", []int{3, 1, 2, 0}, 2, [][]string{{"d", "b"}, {"c", "a"}}, false},
+		{"equal-ages-keep-order", []int{1, 0, 1, 0, 1, 0}, 3, [][]string{{"b", "d"}, {"f", "a"}, {"c", "e"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			population := alpsTestPopulation(tt.ages...)
+			got, err := AgeLayers(population, tt.layers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AgeLayers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			ids := make([][]string, len(got))
+			for i := range got {
+				ids[i] = survivorIds(got[i])
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("AgeLayers() = %v, want %v", ids, tt.want)
+			}
+			if !reflect.DeepEqual(survivorIds(population), survivorIds(alpsTestPopulation(tt.ages...))) {
+				t.Errorf("AgeLayers() reordered the population")
+			}
+		})
+	}
+}
+
+func Test_inheritAge(t *testing.T) {
+	parents := alpsTestPopulation(2, 5, 1, 0, 3)
+	children := alpsTestPopulation(0, 0, 0, 0, 0)
+	inheritAge(parents, children)
+
+	got := make([]int, len(children))
+	for i := range children {
+		got[i] = children[i].Age
+	}
+	if want := []int{6, 6, 2, 2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("inheritAge() gave ages %v, want %v", got, want)
+	}
+}
+
+func TestALPS_validate(t *testing.T) {
+	tests := []struct {
+		name               string
+		alps               ALPS
+		eachPopulationSize int
+		parentSelection    ParentSelection
+		wantErr            bool
+	}{
+		{"disabled", ALPS{}, 10, ParentSelection{Type: ParentSelectionTournament, TournamentSize: 3}, false},
+		{"single-layer", ALPS{Layers: 1}, 10, ParentSelection{Type: ParentSelectionTournament, TournamentSize: 3},
+			false},
+		{"valid", ALPS{Layers: 2, AgeGap: 5}, 8, ParentSelection{Type: ParentSelectionTournament, TournamentSize: 3},
+			false},
+		{"no-age-gap", ALPS{Layers: 2}, 8, ParentSelection{Type: ParentSelectionTournament, TournamentSize: 3}, true},
+		{"indivisible", ALPS{Layers: 3, AgeGap: 5}, 8, ParentSelection{Type: ParentSelectionTournament,
+			TournamentSize: 1}, true},
+		{"odd-layer", ALPS{Layers: 2, AgeGap: 5}, 10, ParentSelection{Type: ParentSelectionTournament,
+			TournamentSize: 3}, true},
+		{"tournament-too-large", ALPS{Layers: 4, AgeGap: 5}, 8, ParentSelection{Type: ParentSelectionTournament,
+			TournamentSize: 3}, true},
+		{"elites-too-many", ALPS{Layers: 2, AgeGap: 5}, 8, ParentSelection{Type: ParentSelectionElitism,
+			EliteCount: 6}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := EvolutionParams{EachPopulationSize: tt.eachPopulationSize, ALPS: tt.alps}
+			params.Selection.Parent = tt.parentSelection
+			if err := tt.alps.validate(params); (err != nil) != tt.wantErr {
+				t.Errorf("ALPS.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeneration_selectLayeredSurvivors(t *testing.T) {
+	rand.Seed(1)
+	params := EvolutionParams{EachPopulationSize: 8, ALPS: ALPS{Layers: 2, AgeGap: 2}}
+	params.Selection.Parent = ParentSelection{Type: ParentSelectionTournament, TournamentSize: 2}
+	params.Selection.Survivor = SurvivorSelection{Type: SurvivorSelectionGenerational}
+	params.Reproduction = Reproduction{CrossoverStrategy: CrossoverSinglePoint, CrossoverPercentage: 0.5}
+	params.Strategies.ProtagonistStrategyCount = 1
+	params.Strategies.ProtagonistAvailableStrategies = []Strategy{StrategyAddToLeafX}
+
+	tests := []struct {
+		name     string
+		count    int
+		wantAges []int
+	}{
+		{"breed-within-layers", 0, []int{1, 1, 1, 1, 6, 6, 6, 6}},
+		{"inject-bottom-layer", 1, []int{0, 0, 0, 0, 6, 6, 6, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generation := &Generation{engine: &EvolutionEngine{Parameters: params}, count: tt.count}
+			population := alpsTestPopulation(5, 0, 5, 0, 5, 0, 5, 0)
+
+			survivors, err := generation.selectLayeredSurvivors(population, IndividualProtagonist)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(survivors) != len(population) {
+				t.Fatalf("selectLayeredSurvivors() returned %d survivors, want %d", len(survivors), len(population))
+			}
+			gotAges := make([]int, len(survivors))
+			for i := range survivors {
+				gotAges[i] = survivors[i].Age
+			}
+			if !reflect.DeepEqual(gotAges, tt.wantAges) {
+				t.Errorf("selectLayeredSurvivors() survivor ages = %v, want %v", gotAges, tt.wantAges)
+			}
+			if tt.count == 1 && survivors[0].BirthGen != 2 {
+				t.Errorf("injected individual has BirthGen %d, want 2", survivors[0].BirthGen)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package evolution
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// commutativeOperators are the operators whose operands are sorted when building the canonical form.
+var commutativeOperators = map[string]bool{
+	"+": true,
+	"*": true,
+}
+
+// CanonicalString returns a representation of the tree that is identical for structurally equivalent programs.
+// The operands of commutative operators are sorted and numeric constants are normalized so x*(2+1) and (1.0+2)*x
+// produce the same string. It is computed in a single post-order pass.
+func (d *DualTree) CanonicalString() (string, error) {
+	if d.root == nil {
+		return "", fmt.Errorf("CanonicalString | treeNode root is nil")
+	}
+
+	return canonicalNode(d.root), nil
+}
+
+// Hash returns a 64 bit FNV-1a hash of the canonical form of the tree. Structurally equivalent trees always have the
+// same hash, use IsStructurallyEqual to rule out collisions.
+func (d *DualTree) Hash() (uint64, error) {
+	canonical, err := d.CanonicalString()
+	if err != nil {
+		return 0, err
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(canonical))
+	return hash.Sum64(), nil
+}
+
+// IsStructurallyEqual checks whether two trees represent the same program regardless of node keys and the order of
+// commutative operands.
+func (d *DualTree) IsStructurallyEqual(other *DualTree) bool {
+	if d == nil || other == nil || d.root == nil || other.root == nil {
+		return false
+	}
+
+	return canonicalNode(d.root) == canonicalNode(other.root)
+}
+
+func canonicalNode(node *DualTreeNode) string {
+	if node.left == nil && node.right == nil {
+		if value, err := strconv.ParseFloat(node.value, 64); err == nil {
+			return strconv.FormatFloat(value, 'g', -1, 64)
+		}
+		return node.value
+	}
+	if node.right == nil {
+		return node.value + "(" + canonicalNode(node.left) + ")"
+	}
+	if node.left == nil {
+		return node.value + "(," + canonicalNode(node.right) + ")"
+	}
+
+	left := canonicalNode(node.left)
+	right := canonicalNode(node.right)
+	if commutativeOperators[node.value] && right < left {
+		left, right = right, left
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(node.value) + len(left) + len(right) + 3)
+	sb.WriteString(node.value)
+	sb.WriteString("(")
+	sb.WriteString(left)
+	sb.WriteString(",")
+	sb.WriteString(right)
+	sb.WriteString(")")
+	return sb.String()
+}
+
+// UniquePhenotypes returns the first individual of every distinct program in the given individuals,
+// preserving their order. Individuals without a program are ignored.
+func UniquePhenotypes(individuals []*Individual) []*Individual {
+	seen := make(map[string]bool, len(individuals))
+	unique := make([]*Individual, 0, len(individuals))
+	for _, individual := range individuals {
+		if individual == nil || individual.Program == nil || individual.Program.T == nil {
+			continue
+		}
+		canonical, err := individual.Program.T.CanonicalString()
+		if err != nil || seen[canonical] {
+			continue
+		}
+		seen[canonical] = true
+		unique = append(unique, individual)
+	}
+	return unique
+}
@@ -0,0 +1,102 @@
+package evolution
+
+import (
+	"testing"
+)
+
+func TestDualTree_CanonicalString(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{"identical", "x*x+2", "x*x+2", true},
+		{"commutative add", "x+2", "2+x", true},
+		{"commutative mult", "x*(x+1)", "(1+x)*x", true},
+		{"nested commutative", "(x*3)+(y*2)", "(2*y)+(3*x)", true},
+		{"normalized constants", "x*2", "2.0*x", true},
+		{"sub not commutative", "x-2", "2-x", false},
+		{"div not commutative", "x/2", "2/x", false},
+		{"pow not commutative", "pow(x, 2)", "pow(2, x)", false},
+		{"different variables", "x+1", "y+1", false},
+		{"different structure", "(x+1)+2", "x+(1+2)", false},
+		{"unary function", "sin(x+1)", "sin(1+x)", true},
+		{"different function", "sin(x)", "cos(x)", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseExpression(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ParseExpression(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			canonicalA, err := a.CanonicalString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			canonicalB, err := b.CanonicalString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (canonicalA == canonicalB) != tt.equal {
+				t.Errorf("DualTree.CanonicalString() %v and %v, want equal %v", canonicalA, canonicalB, tt.equal)
+			}
+			if got := a.IsStructurallyEqual(b); got != tt.equal {
+				t.Errorf("DualTree.IsStructurallyEqual() = %v, want %v", got, tt.equal)
+			}
+			hashA, err := a.Hash()
+			if err != nil {
+				t.Fatal(err)
+			}
+			hashB, err := b.Hash()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (hashA == hashB) != tt.equal {
+				t.Errorf("DualTree.Hash() %v and %v, want equal %v", hashA, hashB, tt.equal)
+			}
+		})
+	}
+}
+
+func TestDualTree_CanonicalStringNil(t *testing.T) {
+	if _, err := TreeNil().CanonicalString(); err == nil {
+		t.Errorf("DualTree.CanonicalString() on a nil tree should return an error")
+	}
+	if TreeNil().IsStructurallyEqual(TreeT_X()) {
+		t.Errorf("DualTree.IsStructurallyEqual() a nil tree should not equal any tree")
+	}
+}
+
+func TestUniquePhenotypes(t *testing.T) {
+	individual := func(id, expression string) *Individual {
+		tree, err := ParseExpression(expression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Individual{Id: id, Program: &Program{T: tree}}
+	}
+	individuals := []*Individual{
+		individual("a", "x+1"),
+		individual("b", "1+x"),
+		individual("c", "x*x"),
+		{Id: "d"},
+		individual("e", "x-1"),
+		individual("f", "x*x"),
+	}
+
+	got := UniquePhenotypes(individuals)
+	wantIDs := []string{"a", "c", "e"}
+	if len(got) != len(wantIDs) {
+		t.Fatalf("UniquePhenotypes() returned %d individuals, want %d", len(got), len(wantIDs))
+	}
+	for i := range wantIDs {
+		if got[i].Id != wantIDs[i] {
+			t.Errorf("UniquePhenotypes()[%d] = %v, want %v", i, got[i].Id, wantIDs[i])
+		}
+	}
+}
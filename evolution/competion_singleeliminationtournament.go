@@ -2,11 +2,9 @@ package evolution
 
 import (
 	"fmt"
-	"github.com/martinomburajr/masters-go/utils"
 	"math"
 	"math/rand"
 	"sync"
-	"time"
 )
 
 type SingleEliminationTournamentTopology struct {
@@ -112,21 +110,27 @@ func (s *SingleEliminationTournamentTopology) Topology(currentGeneration *Genera
 
 	antagonistSurvivors, protagonistSurvivors := currentGeneration.ApplySelection(currentGeneration.Antagonists, currentGeneration.Protagonists, params.ErrorChan)
 
-	newGeneration := &Generation{
-		GenerationID: GenerateGenerationID(currentGeneration.count+1,
-			TopologySingleEliminationTournament),
-		Protagonists:                 protagonistSurvivors,
-		Antagonists:                  antagonistSurvivors,
-		engine:                       currentGeneration.engine,
-		isComplete:                   true,
-		hasParentSelectionHappened:   true,
-		hasSurvivorSelectionHappened: true,
-		count:                        currentGeneration.count+1,
-	}
+	newGeneration := currentGeneration.NextGeneration(TopologySingleEliminationTournament, antagonistSurvivors,
+		protagonistSurvivors)
 
 	return newGeneration, nil
 }
 
+// validateSETFitness returns an error if the topology is TopologySingleEliminationTournament and the fitness
+// strategy is not thresholded ratio. The tournament scores each individual against the spec on its own, see
+// singleETCompete, so there is no opponent to evaluate a registered FitnessFunction with.
+func validateSETFitness(topology Topology, fitnessStrategy FitnessStrategy) error {
+	if topology.Type != TopologySingleEliminationTournament {
+		return nil
+	}
+	switch fitnessStrategy.Type {
+	case FitnessMonoThresholdedRatio, FitnessDualThresholdedRatio:
+		return nil
+	}
+	return fmt.Errorf("validateSETFitness | %s only supports %s and %s | got: %q", TopologySingleEliminationTournament,
+		FitnessMonoThresholdedRatio, FitnessDualThresholdedRatio, fitnessStrategy.Type)
+}
+
 func GetMaxFitnessAndDelta(individual *Individual) (maxFit float64, maxDelta float64) {
 	maxFit = math.MinInt16
 	maxDelta = math.MinInt16
@@ -158,64 +162,6 @@ func CloneIndividualsLinkParent(individuals []*Individual) (outgoing []*Individu
 	return outgoing, nil
 }
 
-func (s *SingleEliminationTournamentTopology) Evolve(params EvolutionParams, topology ITopology) (*EvolutionResult,
-	error) {
-	engine := s.Engine
-	err := engine.validate()
-	if err != nil {
-		return nil, err
-	}
-
-	_, _, err = engine.InitializeGenerations(engine.Parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	genCount := CalculateGenerationSize(engine.Parameters)
-
-	for i := 0; i < genCount; i++ {
-		started := time.Now()
-		// 1. CLEANSE
-		engine.Generations[i].CleansePopulations(engine.Parameters)
-
-		// 2. START
-		nextGeneration, err := topology.Topology(engine.Generations[i], params)
-		if err != nil {
-			return nil, err
-		}
-		// 3. EVALUATE
-		if genCount == params.GenerationsCount && params.MaxGenerations < MinAllowableGenerationsToTerminate {
-			shouldTerminateEvolution := engine.EvaluateTerminationCriteria(engine.Generations[i], engine.Parameters)
-			if shouldTerminateEvolution {
-				engine.ProgressBar.Incr()
-				break
-			}
-		}
-		go engine.RunGenerationStatistics(engine.Generations[i])
-
-		if i == engine.Parameters.MaxGenerations-1 {
-			engine.ProgressBar.Incr()
-			break
-		}
-		engine.Generations = append(engine.Generations, nextGeneration)
-		engine.ProgressBar.Incr()
-
-		// 4. LOG
-		elapsed := utils.TimeTrack(started)
-		go WriteGenerationToLog(engine, i, elapsed)
-		go WriteToDataFolders(engine.Parameters.FolderPercentages, i, engine.Parameters.GenerationsCount, engine.Parameters)
-	}
-
-	evolutionResult := &EvolutionResult{}
-	err = evolutionResult.Analyze(engine, engine.Generations, true,
-		engine.Parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	return evolutionResult, nil
-}
-
 type bracket struct {
 	individualA *Individual
 	individualB *Individual
@@ -269,11 +215,21 @@ func singleETCompete(individuals []*Individual, bestAntagonistTree DualTree, par
 				brackets[i].individualA.Deltas = append(brackets[i].individualA.Deltas, individualADelta)
 				brackets[i].individualA.Parent.Fitness = append(brackets[i].individualA.Parent.Fitness, individualAFitness)
 				brackets[i].individualA.Parent.Deltas = append(brackets[i].individualA.Parent.Deltas, individualADelta)
+				err = params.recordCaseDeltas(brackets[i].individualA.Program, brackets[i].individualA,
+					brackets[i].individualA.Parent)
+				if err != nil {
+					return nil, err
+				}
 
 				brackets[i].individualB.Fitness = append(brackets[i].individualB.Fitness, individualBFitness)
 				brackets[i].individualB.Deltas = append(brackets[i].individualB.Deltas, individualBDelta)
 				brackets[i].individualB.Parent.Fitness = append(brackets[i].individualB.Parent.Fitness, individualBFitness)
 				brackets[i].individualB.Parent.Deltas = append(brackets[i].individualB.Parent.Deltas, individualBDelta)
+				err = params.recordCaseDeltas(brackets[i].individualB.Program, brackets[i].individualB,
+					brackets[i].individualB.Parent)
+				if err != nil {
+					return nil, err
+				}
 
 				AntagonistFitnessResolver(perfectFitnessMap, brackets[i].individualA, individualAFitness, individualADelta)
 				AntagonistFitnessResolver(perfectFitnessMap, brackets[i].individualB, individualBFitness,
@@ -306,11 +262,21 @@ func singleETCompete(individuals []*Individual, bestAntagonistTree DualTree, par
 				brackets[i].individualA.Deltas = append(brackets[i].individualA.Deltas, individualADelta)
 				brackets[i].individualA.Parent.Fitness = append(brackets[i].individualA.Parent.Fitness, individualAFitness)
 				brackets[i].individualA.Parent.Deltas = append(brackets[i].individualA.Parent.Deltas, individualADelta)
+				err = params.recordCaseDeltas(brackets[i].individualA.Program, brackets[i].individualA,
+					brackets[i].individualA.Parent)
+				if err != nil {
+					return nil, err
+				}
 
 				brackets[i].individualB.Fitness = append(brackets[i].individualB.Fitness, individualBFitness)
 				brackets[i].individualB.Deltas = append(brackets[i].individualB.Deltas, individualBDelta)
 				brackets[i].individualB.Parent.Fitness = append(brackets[i].individualB.Parent.Fitness, individualBFitness)
 				brackets[i].individualB.Parent.Deltas = append(brackets[i].individualB.Parent.Deltas, individualBDelta)
+				err = params.recordCaseDeltas(brackets[i].individualB.Program, brackets[i].individualB,
+					brackets[i].individualB.Parent)
+				if err != nil {
+					return nil, err
+				}
 
 				ProtagonistFitnessResolver(perfectFitnessMap, brackets[i].individualA, individualAFitness, individualADelta)
 				ProtagonistFitnessResolver(perfectFitnessMap, brackets[i].individualB, individualBFitness,
@@ -401,8 +367,18 @@ func singleETCompeteProtagonists(individuals []*Individual, bestAntagonistTree D
 
 			brackets[i].individualA.Parent.Fitness = append(brackets[i].individualA.Parent.Fitness, individualAFitness)
 			brackets[i].individualA.Parent.Deltas = append(brackets[i].individualA.Parent.Deltas, individualADelta)
+			err = params.recordCaseDeltas(brackets[i].individualA.Program, brackets[i].individualA,
+				brackets[i].individualA.Parent)
+			if err != nil {
+				return nil, err
+			}
 			brackets[i].individualB.Parent.Fitness = append(brackets[i].individualB.Parent.Fitness, individualBFitness)
 			brackets[i].individualB.Parent.Deltas = append(brackets[i].individualB.Parent.Deltas, individualBDelta)
+			err = params.recordCaseDeltas(brackets[i].individualB.Program, brackets[i].individualB,
+				brackets[i].individualB.Parent)
+			if err != nil {
+				return nil, err
+			}
 
 			programCloneA := brackets[i].individualA.Program.CloneWithTree(*brackets[i].individualA.Program.T)
 			programCloneB := brackets[i].individualB.Program.CloneWithTree(*brackets[i].individualB.Program.T)
@@ -1,7 +1,8 @@
 package evolution
 
 import (
-	"fmt"
+	"github.com/martinomburajr/masters-go/utils"
+	"time"
 )
 
 const (
@@ -12,45 +13,88 @@ const (
 	TopologySingleEliminationTournament = "TopologySET"
 )
 
+// ITopology decides how the individuals of a generation are paired and lets them compete. Topology records the
+// fitness of every individual in the current generation and returns the next generation made from its survivors,
+// see Generation.NextGeneration. The generation loop itself is shared by all topologies, see EvolutionEngine.Evolve.
 type ITopology interface {
 	Topology(currentGeneration *Generation, params EvolutionParams) (*Generation, error)
 }
 
-type IEvolve interface {
-	Evolve(params EvolutionParams, topology ITopology) (*EvolutionResult, error)
-}
-
+// Evolve runs the generation loop using the topology registered under Topology.Type, see RegisterTopology.
+// Each generation is cleansed, competes according to the topology, is checked against the termination criteria
+// and has its statistics calculated before the next generation takes its place.
 func (engine *EvolutionEngine) Evolve(params EvolutionParams) (*EvolutionResult, error) {
-	switch engine.Parameters.Topology.Type {
-	case TopologyHallOfFame:
-		hallOfFame := &HallOfFame{Engine: engine}
-		evolutionResult, err := hallOfFame.Evolve(params, hallOfFame)
-		if err != nil {
-			return nil, err
-		}
-		return evolutionResult, nil
-	case TopologyKRandom:
-		kRandom := &KRandom{Engine: engine}
-		evolutionResult, err := kRandom.Evolve(params, kRandom)
+	if engine.Parameters.EnableFitnessCache && engine.Parameters.fitnessCache == nil {
+		engine.Parameters.fitnessCache = NewFitnessCache(engine.Parameters.FitnessCacheCapacity)
+	}
+	params.fitnessCache = engine.Parameters.fitnessCache
+
+	fitnessFunction, err := FitnessFunctionByName(engine.Parameters.FitnessStrategy.Type)
+	if err != nil {
+		return nil, err
+	}
+	engine.Parameters.fitnessFunction = fitnessFunction
+	params.fitnessFunction = fitnessFunction
+
+	topology, err := TopologyByName(engine.Parameters.Topology.Type, engine)
+	if err != nil {
+		return nil, err
+	}
+
+	err = engine.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, err = engine.InitializeGenerations(engine.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	genCount := CalculateGenerationSize(engine.Parameters)
+
+	for i := 0; i < genCount; i++ {
+		started := time.Now()
+		// 1. CLEANSE
+		engine.Generations[i].CleansePopulations(engine.Parameters)
+
+		// 2. START
+		nextGeneration, err := topology.Topology(engine.Generations[i], params)
 		if err != nil {
 			return nil, err
 		}
-		return evolutionResult, nil
-	case TopologyRoundRobin:
-		roundRobin := &RoundRobin{Engine: engine}
-		evolutionResult, err := roundRobin.Evolve(params, roundRobin)
-		if err != nil {
-			return nil, err
+		engine.recordFitnessCacheStats(engine.Generations[i])
+
+		// 3. EVALUATE
+		if genCount == params.GenerationsCount && params.MaxGenerations < MinAllowableGenerationsToTerminate {
+			shouldTerminateEvolution := engine.EvaluateTerminationCriteria(engine.Generations[i], engine.Parameters)
+			if shouldTerminateEvolution {
+				engine.ProgressBar.Incr()
+				break
+			}
 		}
-		return evolutionResult, nil
-	case TopologySingleEliminationTournament:
-		singleEliminationTournament := &SingleEliminationTournamentTopology{Engine: engine}
-		evolutionResult, err := singleEliminationTournament.Evolve(params, singleEliminationTournament)
-		if err != nil {
-			return nil, err
+		go engine.RunGenerationStatistics(engine.Generations[i])
+
+		if i == engine.Parameters.MaxGenerations-1 {
+			engine.ProgressBar.Incr()
+			break
 		}
-		return evolutionResult, nil
-	default:
-		return nil, fmt.Errorf("Compete | invalid Evolutionary Topology set")
+		engine.Generations = append(engine.Generations, nextGeneration)
+		engine.ProgressBar.Incr()
+
+		// 4. LOG
+		elapsed := utils.TimeTrack(started)
+		go WriteGenerationToLog(engine, i, elapsed)
+		go WriteToDataFolders(engine.Parameters.FolderPercentages, i, engine.Parameters.GenerationsCount,
+			engine.Parameters)
 	}
+
+	evolutionResult := &EvolutionResult{}
+	err = evolutionResult.Analyze(engine, engine.Generations, true,
+		engine.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return evolutionResult, nil
 }
@@ -1,9 +1,7 @@
 package evolution
 
 import (
-	"github.com/martinomburajr/masters-go/utils"
 	"math/rand"
-	"time"
 )
 
 type KRandom struct {
@@ -26,16 +24,7 @@ func (s *KRandom) Topology(currentGeneration *Generation,
 
 	antagonistSurvivors, protagonistSurvivors := currentGeneration.ApplySelection(currentGeneration.Antagonists, currentGeneration.Protagonists, params.ErrorChan)
 
-	newGeneration := &Generation{
-		GenerationID:                 GenerateGenerationID(currentGeneration.count+1, TopologyKRandom),
-		Protagonists:                 protagonistSurvivors,
-		Antagonists:                  antagonistSurvivors,
-		engine:                       currentGeneration.engine,
-		isComplete:                   true,
-		hasParentSelectionHappened:   true,
-		hasSurvivorSelectionHappened: true,
-		count:                        currentGeneration.count+1 ,
-	}
+	newGeneration := currentGeneration.NextGeneration(TopologyKRandom, antagonistSurvivors, protagonistSurvivors)
 
 	return newGeneration, nil
 }
@@ -96,8 +85,19 @@ func (s *KRandom) startTournaments(currentGeneration *Generation, tournamentLedg
 			}
 
 			antagonistFitness, protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta,
-			err := ThresholdedRatioFitness(params.Spec, antagonist.Program, protagonist.Program,
-				params.SpecParam.DivideByZeroStrategy)
+			err := params.EvaluateFitness(antagonist.Program, protagonist.Program)
+			if err != nil {
+				return err
+			}
+			err = params.recordCaseDeltas(antagonist.Program, antagonist.Parent)
+			if err != nil {
+				return err
+			}
+			protagonistRecipient := protagonist
+			if protagonist.Parent != nil {
+				protagonistRecipient = protagonist.Parent
+			}
+			err = params.recordCaseDeltas(protagonist.Program, protagonistRecipient)
 			if err != nil {
 				return err
 			}
@@ -139,61 +139,3 @@ func (s *KRandom) startTournaments(currentGeneration *Generation, tournamentLedg
 
 	return nil
 }
-
-func (s *KRandom) Evolve(params EvolutionParams, topology ITopology) (*EvolutionResult,
-	error) {
-	engine := s.Engine
-	err := engine.validate()
-	if err != nil {
-		return nil, err
-	}
-
-	_, _, err = engine.InitializeGenerations(engine.Parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	genCount := CalculateGenerationSize(engine.Parameters)
-
-	for i := 0; i < genCount; i++ {
-		started := time.Now()
-		// 1. CLEANSE
-		engine.Generations[i].CleansePopulations(engine.Parameters)
-
-		// 2. START
-		nextGeneration, err := topology.Topology(engine.Generations[i], params)
-		if err != nil {
-			return nil, err
-		}
-		// 3. EVALUATE
-		if genCount == params.GenerationsCount && params.MaxGenerations < MinAllowableGenerationsToTerminate {
-			shouldTerminateEvolution := engine.EvaluateTerminationCriteria(engine.Generations[i], engine.Parameters)
-			if shouldTerminateEvolution {
-				engine.ProgressBar.Incr()
-				break
-			}
-		}
-		go engine.RunGenerationStatistics(engine.Generations[i])
-
-		if i == engine.Parameters.MaxGenerations-1 {
-			engine.ProgressBar.Incr()
-			break
-		}
-		engine.Generations = append(engine.Generations, nextGeneration)
-		engine.ProgressBar.Incr()
-
-		// 4. LOG
-		elapsed := utils.TimeTrack(started)
-		go WriteGenerationToLog(engine, i, elapsed)
-		go WriteToDataFolders(engine.Parameters.FolderPercentages, i, engine.Parameters.GenerationsCount, engine.Parameters)
-	}
-
-	evolutionResult := &EvolutionResult{}
-	err = evolutionResult.Analyze(engine, engine.Generations, true,
-		engine.Parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	return evolutionResult, nil
-}
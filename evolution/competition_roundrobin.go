@@ -3,7 +3,6 @@ package evolution
 import (
 	"fmt"
 	"github.com/martinomburajr/masters-go/evolog"
-	"github.com/martinomburajr/masters-go/utils"
 	"gonum.org/v1/gonum/stat"
 	"time"
 )
@@ -31,78 +30,12 @@ func (r RoundRobin) Topology(currentGeneration *Generation,
 		clonedProtagonistSurvivors[i] = &protClone
 	}
 
-	newGeneration := &Generation{
-		GenerationID:                 GenerateGenerationID(currentGeneration.count+1, TopologyRoundRobin),
-		Protagonists:                 clonedProtagonistSurvivors,
-		Antagonists:                  clonedAntagonistSurvivors,
-		engine:                       currentGeneration.engine,
-		isComplete:                   true,
-		hasParentSelectionHappened:   true,
-		hasSurvivorSelectionHappened: true,
-		count:                        currentGeneration.count+1,
-	}
+	newGeneration := currentGeneration.NextGeneration(TopologyRoundRobin, clonedAntagonistSurvivors,
+		clonedProtagonistSurvivors)
 
 	return newGeneration, nil
 }
 
-func (s *RoundRobin) Evolve(params EvolutionParams, topology ITopology) (*EvolutionResult,
-	error) {
-	engine := s.Engine
-	err := engine.validate()
-	if err != nil {
-		return nil, err
-	}
-
-	_, _, err = engine.InitializeGenerations(engine.Parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	genCount := CalculateGenerationSize(engine.Parameters)
-
-	for i := 0; i < genCount; i++ {
-		started := time.Now()
-		// 1. CLEANSE
-		engine.Generations[i].CleansePopulations(engine.Parameters)
-
-		// 2. START
-		nextGeneration, err := topology.Topology(engine.Generations[i], params)
-		if err != nil {
-			return nil, err
-		}
-		// 3. EVALUATE
-		if genCount == params.GenerationsCount && params.MaxGenerations < MinAllowableGenerationsToTerminate {
-			shouldTerminateEvolution := engine.EvaluateTerminationCriteria(engine.Generations[i], engine.Parameters)
-			if shouldTerminateEvolution {
-				engine.ProgressBar.Incr()
-				break
-			}
-		}
-		go engine.RunGenerationStatistics(engine.Generations[i])
-
-		if i == engine.Parameters.MaxGenerations-1 {
-			engine.ProgressBar.Incr()
-			break
-		}
-		engine.Generations = append(engine.Generations, nextGeneration)
-		engine.ProgressBar.Incr()
-
-		// 4. LOG
-		elapsed := utils.TimeTrack(started)
-		go WriteGenerationToLog(engine, i, elapsed)
-		go WriteToDataFolders(engine.Parameters.FolderPercentages, i, engine.Parameters.GenerationsCount, engine.Parameters)
-	}
-
-	evolutionResult := &EvolutionResult{}
-	err = evolutionResult.Analyze(engine, engine.Generations, true,
-		engine.Parameters)
-	if err != nil {
-		return nil, err
-	}
-
-	return evolutionResult, nil
-}
-
 // setupEpochs takes in the Generation individuals (
 // protagonists and antagonists) and creates a set of uninitialized epochs
 func (r *RoundRobin) setupEpochs(g *Generation) ([]Epoch, error) {
@@ -236,7 +169,6 @@ func (r *RoundRobin) Compete(g *Generation) error {
 		g.Antagonists[i].FitnessVariance = antVariance
 		g.Antagonists[i].HasCalculatedFitness = true
 		g.Antagonists[i].HasAppliedStrategy = true
-		g.Antagonists[i].Age += 1
 		g.Antagonists[i].AverageDelta = deltaAntMean
 		g.AntagonistAvgFitness = append(g.AntagonistAvgFitness, antMean)
 
@@ -248,7 +180,6 @@ func (r *RoundRobin) Compete(g *Generation) error {
 		g.Protagonists[i].FitnessVariance = variance
 		g.Protagonists[i].HasCalculatedFitness = true
 		g.Protagonists[i].HasAppliedStrategy = true
-		g.Protagonists[i].Age += 1
 		g.Protagonists[i].AverageDelta = deltaMean
 		g.ProtagonistAvgFitness = append(g.ProtagonistAvgFitness, mean)
 	}
@@ -266,7 +197,6 @@ func CoalesceFitnessStatistics(individual *Individual) (fitnessToBeAppendedToGen
 	individual.FitnessVariance = variance
 	individual.HasCalculatedFitness = true
 	individual.HasAppliedStrategy = true
-	individual.Age += 1
 	individual.AverageDelta = deltaMean
 	return mean
 }
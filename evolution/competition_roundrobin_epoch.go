@@ -58,9 +58,17 @@ func (e *Epoch) Start(perfectTreeMap map[string]PerfectTree, params EvolutionPar
 	antagonistFitness, protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta := 0.0, 0.0, 0.0, 0.0
 
 	antagonistFitness, protagonistFitness, antagonistFitnessDelta,
-		protagonistFitnessDelta, err = ThresholdedRatioFitness(e.generation.engine.Parameters.Spec,
+		protagonistFitnessDelta, err = e.generation.engine.Parameters.EvaluateFitness(
 		e.antagonist.Program,
-		e.protagonist.Program, e.generation.engine.Parameters.SpecParam.DivideByZeroStrategy)
+		e.protagonist.Program)
+	if err != nil {
+		return err
+	}
+	err = e.generation.engine.Parameters.recordCaseDeltas(e.antagonist.Program, e.antagonist.Parent)
+	if err != nil {
+		return err
+	}
+	err = e.generation.engine.Parameters.recordCaseDeltas(e.protagonist.Program, e.protagonist.Parent)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,123 @@
+package evolution
+
+import (
+	"testing"
+
+	"github.com/gosuri/uiprogress"
+	"github.com/martinomburajr/masters-go/evolog"
+)
+
+// evolveTestEngine returns an engine that evolves small populations towards x*x for the given number of generations
+// using the given topology. Its logs are discarded and its errors are buffered in Parameters.ErrorChan.
+func evolveTestEngine(topology Topology, generations int) *EvolutionEngine {
+	params := creditParams()
+	params.Topology = topology
+	params.GenerationsCount = generations
+	params.MaxGenerations = generations
+	params.ProtagonistMinGenAvgFit = 2
+	params.MinimumTopProtagonistMeanBeforeTerminate = 0.9
+	params.MinimumGenerationMeanBeforeTerminate = 0.9
+	params.EachPopulationSize = 8
+	params.Strategies.AntagonistAvailableStrategies = []Strategy{StrategyAddXD, StrategyMultXD, StrategySkip}
+	params.Strategies.ProtagonistAvailableStrategies = []Strategy{StrategyAddXD, StrategyMultXD, StrategySkip}
+	params.Strategies.AntagonistStrategyCount = 3
+	params.Strategies.ProtagonistStrategyCount = 3
+	params.Reproduction = Reproduction{CrossoverStrategy: CrossoverSinglePoint, ProbabilityOfMutation: 0.3}
+	params.Selection = Selection{
+		Parent:   ParentSelection{Type: ParentSelectionTournament, TournamentSize: 3},
+		Survivor: SurvivorSelection{Type: SurvivorSelectionFitnessBased, SurvivorPercentage: 0.5},
+	}
+	params.LoggingChan = make(chan evolog.Logger)
+	params.ErrorChan = make(chan error, 100)
+	go func(loggingChan chan evolog.Logger) {
+		for range loggingChan {
+		}
+	}(params.LoggingChan)
+
+	return &EvolutionEngine{
+		Parameters:  params,
+		Generations: make([]*Generation, 1),
+		ProgressBar: uiprogress.NewBar(generations),
+	}
+}
+
+func TestEvolutionEngine_EvolveFitnessCacheStats(t *testing.T) {
+	engine := evolveTestEngine(Topology{Type: TopologyRoundRobin}, 5)
+	engine.Parameters.EnableFitnessCache = true
+	engine.Parameters.FitnessCacheCapacity = 1000
+
+	if _, err := engine.Evolve(engine.Parameters); err != nil {
+		t.Fatal(err)
+	}
+	if len(engine.Parameters.ErrorChan) > 0 {
+		t.Fatal(<-engine.Parameters.ErrorChan)
+	}
+	if len(engine.Generations) != 5 {
+		t.Fatalf("Evolve() should run 5 generations. got: %d", len(engine.Generations))
+	}
+
+	hits, misses := 0, 0
+	for _, generation := range engine.Generations {
+		if generation.FitnessCacheHits+generation.FitnessCacheMisses == 0 {
+			t.Errorf("generation %d made no fitness cache lookups", generation.count)
+		}
+		hits += generation.FitnessCacheHits
+		misses += generation.FitnessCacheMisses
+	}
+	wantHits, wantMisses := engine.Parameters.fitnessCache.Stats()
+	if hits != wantHits || misses != wantMisses {
+		t.Errorf("per-generation fitness cache stats should sum to the total. got: %d hits %d misses, want: %d hits "+
+			"%d misses", hits, misses, wantHits, wantMisses)
+	}
+}
+
+func TestEvolutionEngine_EvolveProgress(t *testing.T) {
+	// Analyze increments the ProgressBar 7 times after the generations have evolved
+	tests := []struct {
+		name     string
+		topology Topology
+		want     int
+	}{
+		{"round-robin", Topology{Type: TopologyRoundRobin}, 4 + 7},
+		{"hall-of-fame", Topology{Type: TopologyHallOfFame, HoFGenerationInterval: 0.5}, 4 + 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := evolveTestEngine(tt.topology, 4)
+			// The bar stops counting at its total, so it needs room for the increments made by Analyze
+			engine.ProgressBar = uiprogress.NewBar(100)
+			if _, err := engine.Evolve(engine.Parameters); err != nil {
+				t.Fatal(err)
+			}
+			if len(engine.Parameters.ErrorChan) > 0 {
+				t.Fatal(<-engine.Parameters.ErrorChan)
+			}
+			if got := engine.ProgressBar.Current(); got != tt.want {
+				t.Errorf("Evolve() incremented the ProgressBar %d times, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateSETFitness(t *testing.T) {
+	tests := []struct {
+		name        string
+		topology    string
+		fitnessType string
+		wantErr     bool
+	}{
+		{"set-dual-thresholded", TopologySingleEliminationTournament, FitnessDualThresholdedRatio, false},
+		{"set-mono-thresholded", TopologySingleEliminationTournament, FitnessMonoThresholdedRatio, false},
+		{"set-ratio", TopologySingleEliminationTournament, FitnessRatio, true},
+		{"set-thresholded-antagonist", TopologySingleEliminationTournament, FitnessThresholdedAntagonistRatio, true},
+		{"round-robin-ratio", TopologyRoundRobin, FitnessRatio, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSETFitness(Topology{Type: tt.topology}, FitnessStrategy{Type: tt.fitnessType})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSETFitness() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
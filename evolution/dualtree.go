@@ -2,8 +2,10 @@ package evolution
 
 import (
 	"fmt"
+	"github.com/martinomburajr/masters-go/eval"
 	"math"
 	"math/rand"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -194,6 +196,9 @@ func (d *DualTree) NonTerminals() ([]*DualTreeNode, error) {
 
 // branch recursively adds non-terminal nodes to the nodes slice
 func branch(node *DualTreeNode, nodes *[]*DualTreeNode) {
+	if node == nil {
+		return
+	}
 	if node.left != nil {
 		branch(node.left, nodes)
 		*nodes = append(*nodes, node)
@@ -505,24 +510,13 @@ func (bst *DualTree) GetNode(value string) (node *DualTreeNode, parent *DualTree
 }
 
 // Clone will perform an O(N) deep clone of a treeNode and its items and return its copy.
+// The structure of the tree is copied node for node so unary non-terminals such as sin are preserved.
 func (bst DualTree) Clone() (DualTree, error) {
-	x := bst
-	expressions, err := x.ToSymbolicExpressionSet()
-	if err != nil {
-		return DualTree{}, err
-	}
-	symbolicExpressionSet := expressions
-
-	if len(symbolicExpressionSet) < 1 {
-		return DualTree{}, nil
-	}
-	t := DualTree{}
-	err = t.FromSymbolicExpressionSet2(symbolicExpressionSet)
-	if err != nil {
+	if bst.root == nil {
 		return DualTree{}, nil
 	}
 
-	return t, nil
+	return DualTree{root: bst.root.cloneSubTree()}, nil
 }
 
 func (bst *DualTree) Size() int {
@@ -971,6 +965,16 @@ func MathPreorder(node *DualTreeNode, sb *strings.Builder) {
 	}
 
 	if node.right != nil && node.left != nil {
+		if eval.IsFunction(node.value) {
+			sb.WriteString(node.value)
+			sb.WriteString("((")
+			MathPreorder(node.left, sb)
+			sb.WriteString("),(")
+			MathPreorder(node.right, sb)
+			sb.WriteString("))")
+			return
+		}
+
 		sb.WriteString("(")
 		MathPreorder(node.left, sb)
 		sb.WriteString(")")
@@ -982,11 +986,71 @@ func MathPreorder(node *DualTreeNode, sb *strings.Builder) {
 		sb.WriteString(")")
 		return
 	}
+	if node.left != nil && eval.IsFunction(node.value) {
+		sb.WriteString(node.value)
+		sb.WriteString("(")
+		MathPreorder(node.left, sb)
+		sb.WriteString(")")
+		return
+	}
 	sb.WriteString(node.value)
 
 	return
 }
 
+// Compile builds an evaluator from the tree that can be evaluated against many IndependentVariableMap's without
+// converting the tree to a string and re-parsing it for every point in the spec.
+// It follows the same structure as ToMathematicalString, operators are only valid if they contain both children
+// and unary functions such as sin read their argument from the left child.
+func (d *DualTree) Compile() (eval.Evaluator, error) {
+	if d.root == nil {
+		return nil, fmt.Errorf("Compile | treeNode root is nil cannot compile expression")
+	}
+
+	return compileNode(d.root)
+}
+
+func compileNode(node *DualTreeNode) (eval.Evaluator, error) {
+	if node.left != nil && node.right != nil {
+		if eval.IsFunction(node.value) {
+			left, err := compileNode(node.left)
+			if err != nil {
+				return nil, err
+			}
+			right, err := compileNode(node.right)
+			if err != nil {
+				return nil, err
+			}
+			return eval.Function(node.value, left, right)
+		}
+		if len(node.value) != 1 {
+			return nil, fmt.Errorf("compileNode | invalid operator %q see node: %s", node.value, node.key)
+		}
+		left, err := compileNode(node.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compileNode(node.right)
+		if err != nil {
+			return nil, err
+		}
+		return eval.BinaryOperator(node.value[0], left, right)
+	}
+	if node.left != nil && eval.IsFunction(node.value) {
+		arg, err := compileNode(node.left)
+		if err != nil {
+			return nil, err
+		}
+		return eval.Function(node.value, arg)
+	}
+
+	value, err := strconv.ParseFloat(node.value, 64)
+	if err == nil {
+		return eval.Constant(value), nil
+	}
+	return eval.Var(node.value), nil
+}
+
 func (d *DualTree) Validate() error {
 	if d.root == nil {
 		return fmt.Errorf("error: treeNode root is nil")
@@ -1072,6 +1136,7 @@ func GenerateRandomTree(depth int, terminals []SymbolicExpression,
 	if err != nil {
 		return nil, fmt.Errorf("error creating random treeNode | %s", err.Error())
 	}
+	pruneUnaryNonTerminals(tree.root)
 
 	err = tree.Validate()
 	return &tree, err
@@ -1143,10 +1208,25 @@ func GenerateRandomTreeEnforceIndependentVariable(depth int, independentVar Symb
 	if err != nil {
 		return nil, fmt.Errorf("error creating random treeNode | %s", err.Error())
 	}
+	pruneUnaryNonTerminals(tree.root)
 	err = tree.Validate()
 	return &tree, err
 }
 
+// pruneUnaryNonTerminals removes the right child of any unary non-terminal e.g. sin. The tree is built as though every
+// non-terminal were binary, so the unary function keeps its left child as its argument.
+func pruneUnaryNonTerminals(node *DualTreeNode) {
+	if node == nil {
+		return
+	}
+	if node.left != nil && NonTerminalArity(node.value) == 1 {
+		node.right = nil
+		node.arity = 1
+	}
+	pruneUnaryNonTerminals(node.left)
+	pruneUnaryNonTerminals(node.right)
+}
+
 func weaver(terminals, nonTerminals []SymbolicExpression) []SymbolicExpression {
 	if len(terminals) < 1 {
 		return []SymbolicExpression{}
@@ -1488,16 +1568,23 @@ func (bst *DualTree) MutateNonTerminal(nonTerminalSet []SymbolicExpression) erro
 		// Counter is a failsafe to prevent infinite looping
 
 		nonTerminalIndex := rand.Intn(len(nodes))
-		nonTerminalSetIndex := rand.Intn(len(nonTerminalSet))
+
+		// Only swap with a non-terminal of the same arity e.g. sin cannot become * as it only has a single child
+		candidates := nonTerminalsWithArity(nonTerminalSet, nodeArity(nodes[nonTerminalIndex]))
+		if len(candidates) < 1 {
+			counter++
+			continue
+		}
+		nonTerminalSetIndex := rand.Intn(len(candidates))
 
 		nodeValue = nodes[nonTerminalIndex].value
-		fromSetValue = nonTerminalSet[nonTerminalSetIndex].value
+		fromSetValue = candidates[nonTerminalSetIndex].value
 
 		if nodeValue == fromSetValue {
 			if counter == counterLimit-1 {
 				break
 			}
-			if len(nonTerminalSet) > 1 {
+			if len(candidates) > 1 {
 				continue
 			}
 		} else {
@@ -1510,6 +1597,28 @@ func (bst *DualTree) MutateNonTerminal(nonTerminalSet []SymbolicExpression) erro
 	return nil
 }
 
+// nodeArity returns the number of children a non-terminal node has in the tree.
+func nodeArity(node *DualTreeNode) int {
+	if node.left != nil && node.right != nil {
+		return 2
+	}
+	if node.left != nil || node.right != nil {
+		return 1
+	}
+	return 0
+}
+
+// nonTerminalsWithArity filters the nonTerminalSet to those that take the given number of children
+func nonTerminalsWithArity(nonTerminalSet []SymbolicExpression, arity int) []SymbolicExpression {
+	candidates := make([]SymbolicExpression, 0, len(nonTerminalSet))
+	for i := range nonTerminalSet {
+		if NonTerminalArity(nonTerminalSet[i].value) == arity {
+			candidates = append(candidates, nonTerminalSet[i])
+		}
+	}
+	return candidates
+}
+
 // ReplaceBranch takes a given tree and randomly selects a branch i.
 // e non-terminal and will swap it with a randomly generated tree of variable depth. This includes the root
 func (bst *DualTree) ReplaceBranch(tree DualTree) error {
@@ -1602,7 +1711,11 @@ func (bst *DualTree) AddSubTree(subTree *DualTree) error {
 		return nil
 	}
 
-	// Can check for arity
+	// Unary non-terminals such as sin only take a left child
+	if NonTerminalArity(node.value) == 1 {
+		node.left = subTree.root
+		return nil
+	}
 	intn := rand.Intn(2)
 	if intn == 0 {
 		node.right = subTree.root
@@ -1660,3 +1773,141 @@ func (bst *DualTree) getLeftMostChild() (child *DualTreeNode, parent *DualTreeNo
 
 	return newLeft, parent, nil
 }
+
+// Hoist replaces the tree with one of its subtrees, the new root is randomly selected from every node except the
+// current root. If the tree is a lone terminal it will be ignored.
+func (bst *DualTree) Hoist() error {
+	if bst.root == nil {
+		return fmt.Errorf("Hoist | treeNode you are hoisting has nil root")
+	}
+
+	subTrees := make([]*DualTreeNode, 0)
+	bst.InOrderTraverse(func(node *DualTreeNode) {
+		if node != bst.root {
+			subTrees = append(subTrees, node)
+		}
+	})
+	if len(subTrees) == 0 {
+		return nil
+	}
+
+	bst.root = subTrees[rand.Intn(len(subTrees))]
+	return nil
+}
+
+// Shrink replaces a randomly selected non-terminal (including the root) and everything below it with a terminal
+// from the terminalSet. If the tree is a lone terminal it will be ignored.
+func (bst *DualTree) Shrink(terminalSet []SymbolicExpression) error {
+	if bst.root == nil {
+		return fmt.Errorf("Shrink | treeNode you are shrinking has nil root")
+	}
+	if len(terminalSet) < 1 {
+		return fmt.Errorf("Shrink | terminal set cannot be empty")
+	}
+
+	nodes, err := bst.NonTerminals()
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	node := nodes[rand.Intn(len(nodes))]
+	node.value = terminalSet[rand.Intn(len(terminalSet))].value
+	node.arity = 0
+	node.left = nil
+	node.right = nil
+	return nil
+}
+
+// DuplicateSubTree copies a randomly selected non-terminal and everything below it over a randomly selected terminal.
+// The copy receives new keys. If the tree is a lone terminal it will be ignored.
+func (bst *DualTree) DuplicateSubTree() error {
+	if bst.root == nil {
+		return fmt.Errorf("DuplicateSubTree | treeNode you are duplicating in has nil root")
+	}
+
+	branches, err := bst.NonTerminals()
+	if err != nil {
+		return err
+	}
+	if len(branches) == 0 {
+		return nil
+	}
+	leafs, err := bst.Terminals()
+	if err != nil {
+		return err
+	}
+
+	clone := branches[rand.Intn(len(branches))].cloneSubTree()
+	leaf := leafs[rand.Intn(len(leafs))]
+	leaf.value = clone.value
+	leaf.arity = clone.arity
+	leaf.left = clone.left
+	leaf.right = clone.right
+	return nil
+}
+
+// SwapChildren swaps the left and right children of a randomly selected non-terminal that has two children.
+// Unary non-terminals only take a left child and are never selected. If there is no such non-terminal it will be
+// ignored.
+func (bst *DualTree) SwapChildren() error {
+	if bst.root == nil {
+		return fmt.Errorf("SwapChildren | treeNode you are swapping in has nil root")
+	}
+
+	branches, err := bst.NonTerminals()
+	if err != nil {
+		return err
+	}
+	binary := make([]*DualTreeNode, 0, len(branches))
+	for _, node := range branches {
+		if nodeArity(node) == 2 {
+			binary = append(binary, node)
+		}
+	}
+	if len(binary) == 0 {
+		return nil
+	}
+
+	node := binary[rand.Intn(len(binary))]
+	node.left, node.right = node.right, node.left
+	return nil
+}
+
+// PerturbConstant adds or subtracts k from a randomly selected numeric terminal. Variables are never selected,
+// if the tree contains no numeric terminals it will be ignored.
+func (bst *DualTree) PerturbConstant(k float64) error {
+	if bst.root == nil {
+		return fmt.Errorf("PerturbConstant | treeNode you are perturbing has nil root")
+	}
+	if k <= 0 || math.IsNaN(k) || math.IsInf(k, 0) {
+		return fmt.Errorf("PerturbConstant | k must be a positive number | got: %v", k)
+	}
+
+	leafs, err := bst.Terminals()
+	if err != nil {
+		return err
+	}
+	constants := make([]*DualTreeNode, 0, len(leafs))
+	values := make([]float64, 0, len(leafs))
+	for _, leaf := range leafs {
+		value, err := strconv.ParseFloat(leaf.value, 64)
+		if err != nil {
+			continue
+		}
+		constants = append(constants, leaf)
+		values = append(values, value)
+	}
+	if len(constants) == 0 {
+		return nil
+	}
+
+	i := rand.Intn(len(constants))
+	if rand.Intn(2) == 0 {
+		k = -k
+	}
+	constants[i].value = strconv.FormatFloat(values[i]+k, 'f', -1, 64)
+	return nil
+}
@@ -115,3 +115,24 @@ func BenchmarkGenerateRandomTree_20(b *testing.B) {
 		GenerateRandomTree(depth, terminals, nonTerminals)
 	}
 }
+
+func BenchmarkDualTree_ToMathematicalStringEval(b *testing.B) {
+	b.ReportAllocs()
+	tree := TreeXAddXMult4Sub9Mult0()
+	independents := IndependentVariableMap{"x": 12}
+	for i := 0; i < b.N; i++ {
+		expression, _ := tree.ToMathematicalString()
+		EvaluateMathematicalExpression(expression, independents)
+	}
+}
+
+func BenchmarkDualTree_CompileEval(b *testing.B) {
+	b.ReportAllocs()
+	tree := TreeXAddXMult4Sub9Mult0()
+	independents := IndependentVariableMap{"x": 12}
+	evaluator, _ := tree.Compile()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		evaluator.EvaluateWithVar(independents)
+	}
+}
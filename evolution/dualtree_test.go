@@ -3,7 +3,9 @@ package evolution
 import (
 	"fmt"
 	"log"
+	"math"
 	"reflect"
+	"strconv"
 	"sync"
 	"testing"
 )
@@ -105,6 +107,9 @@ func TestDualTree_ToMathematicalString(t *testing.T) {
 				Mult.value, Const4.value, Sub.value, Const9.value, Mult.value, Const0.value), false},
 
 		{"100000*x*x*x", Tree_100000XXX(), "(((100000)*(x))*((x)*(x)))", false},
+		{"sin(sin(sin(x)))", TreeVine_D3(), "(sin(sin(sin(x))))", false},
+		{"sin(x)*4", TreeSinXMult4(), "((sin(x))*(4))", false},
+		{"pow(x+1,2)", TreePowXAdd1_2(), "(pow(((x)+(1)),(2)))", false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -121,6 +126,86 @@ func TestDualTree_ToMathematicalString(t *testing.T) {
 	}
 }
 
+func TestDualTree_Compile(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  *DualTree
+		wantErr bool
+	}{
+		{"nil", TreeNil(), true},
+		{"T", TreeT_X(), false},
+		{"10", TreeT_10(), false},
+		{"x*10", Tree_X10(), false},
+		{"T-NT-T", TreeT_NT_T_0(), false},
+		{"T-NT-T-NT-T", TreeT_NT_T_NT_T_0(), false},
+		{"T-NT-T-NT-T", TreeXAddXMult4Sub9Mult0(), false},
+		{"100000*x*x*x", Tree_100000XXX(), false},
+		{"sin(sin(sin(x)))", TreeVine_D3(), false},
+		{"sin(x)*4", TreeSinXMult4(), false},
+		{"pow(x+1,2)", TreePowXAdd1_2(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluator, err := tt.fields.Compile()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DualTree.Compile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			expression, err := tt.fields.ToMathematicalString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, x := range []float64{-3, 0, 1, 2.5, 10} {
+				independents := IndependentVariableMap{"x": x}
+				want, err := EvaluateMathematicalExpression(expression, independents)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := evaluator.EvaluateWithVar(independents)
+				if err != nil {
+					t.Errorf("DualTree.Compile() evaluation error = %v", err)
+					return
+				}
+				if got != want {
+					t.Errorf("DualTree.Compile() x = %v got %v, want %v", x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDualTree_CompileDomainError(t *testing.T) {
+	evaluator, err := TreeLogX().Compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		x       float64
+		want    float64
+		wantErr bool
+	}{
+		{"log(1)", 1, 0, false},
+		{"log(0)", 0, 0, true},
+		{"log(-1)", -1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluator.EvaluateWithVar(IndependentVariableMap{"x": tt.x})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DualTree.Compile() evaluation error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("DualTree.Compile() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDualTree_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -184,6 +269,7 @@ func TestGenerateRandomTree(t *testing.T) {
 				Sub}},
 			TreeT_NT_T_NT_T_NT_T_NT_T_NT_T_NT_T_NT_T_0(),
 			false},
+		{"depth-1-unary", args{1, []SymbolicExpression{X1}, []SymbolicExpression{Sin}}, TreeSinX(), false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -212,6 +298,42 @@ func TestGenerateRandomTree(t *testing.T) {
 	}
 }
 
+func TestGenerateRandomTree_UnaryNonTerminals(t *testing.T) {
+	terminals := []SymbolicExpression{X1, Const1, Const2, Const3}
+	nonTerminals := []SymbolicExpression{Add, Mult, Sin, Cos, Pow}
+	for depth := 1; depth <= 4; depth++ {
+		for i := 0; i < 50; i++ {
+			tree, err := GenerateRandomTree(depth, terminals, nonTerminals)
+			if err != nil {
+				t.Fatalf("GenerateRandomTree() error = %v", err)
+			}
+			tree.InOrderTraverse(func(node *DualTreeNode) {
+				if NonTerminalArity(node.value) == 1 && node.left != nil && node.right != nil {
+					t.Errorf("GenerateRandomTree() unary node %s should not have a right child", node.value)
+				}
+			})
+
+			expression, err := tree.ToMathematicalString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			evaluator, err := tree.Compile()
+			if err != nil {
+				t.Fatalf("DualTree.Compile() %s error = %v", expression, err)
+			}
+			want, wantErr := EvaluateMathematicalExpression(expression, IndependentVariableMap{"x": 0.5})
+			got, err := evaluator.EvaluateWithVar(IndependentVariableMap{"x": 0.5})
+			if (err != nil) != (wantErr != nil) {
+				t.Errorf("DualTree.Compile() %s error = %v, want %v", expression, err, wantErr)
+				continue
+			}
+			if err == nil && math.Abs(got-want) > 1e-9 {
+				t.Errorf("DualTree.Compile() %s got %v, want %v", expression, got, want)
+			}
+		}
+	}
+}
+
 func TestGenerateRandomSymbolicExpressionSet(t *testing.T) {
 	tests := []struct {
 		name string
@@ -637,6 +759,9 @@ func TestDualTree_MutateNonTerminal(t *testing.T) {
 		{"T-NT-T-NT-T-NT-T-NT-T-SAME", TreeT_NT_T_NT_T_NT_T_NT_T_0(), TreeT_NT_T_NT_T_NT_T_NT_T_0(),
 			[]SymbolicExpression{Mult, Add, Sub},
 			false},
+		{"Unary-Only-Binary-Set", TreeVine_D3(), TreeVine_D3(), []SymbolicExpression{Add, Mult}, false},
+		{"Unary", TreeVine_D3(), TreeVine_D3(), []SymbolicExpression{Sin, Cos}, false},
+		{"Mixed", TreeSinXMult4(), TreeSinXMult4(), []SymbolicExpression{Cos, Add, Pow}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -662,6 +787,10 @@ func TestDualTree_MutateNonTerminal(t *testing.T) {
 					if !oldTreeNonTerminals[i].IsValEqual(*newTreeNonTerminals[i]) {
 						diffCount++
 					}
+					if NonTerminalArity(oldTreeNonTerminals[i].value) != NonTerminalArity(newTreeNonTerminals[i].value) {
+						t.Errorf("mutated non-terminal should keep its arity. got: %s | original: %s",
+							newTreeNonTerminals[i].value, oldTreeNonTerminals[i].value)
+					}
 					if diffCount > 1 {
 						t.Errorf("old and new treeNode should by different by only a single node. "+
 							"got: %#v |  original: %#v", newTreeNonTerminals, oldTreeNonTerminals)
@@ -675,6 +804,248 @@ func TestDualTree_MutateNonTerminal(t *testing.T) {
 	}
 }
 
+func TestDualTree_Hoist(t *testing.T) {
+	tests := []struct {
+		name    string
+		tree    *DualTree
+		oldTree *DualTree
+		wantErr bool
+	}{
+		{"nil", TreeNil(), TreeNil(), true},
+		{"T", TreeT_X(), TreeT_X(), false},
+		{"T-NT-T", TreeT_NT_T_0(), TreeT_NT_T_0(), false},
+		{"T-NT-T-NT-T-NT-T", TreeT_NT_T_NT_T_NT_T_0(), TreeT_NT_T_NT_T_NT_T_0(), false},
+		{"Unary", TreeVine_D3(), TreeVine_D3(), false},
+		{"Mixed", TreeSinXMult4(), TreeSinXMult4(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if err = tt.tree.Hoist(); (err != nil) != tt.wantErr {
+				t.Errorf("DualTree.Hoist() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := tt.tree.Validate(); err != nil {
+					t.Errorf("hoisted tree should be valid: %v", err)
+				}
+				oldSize := tt.oldTree.Size()
+				if oldSize == 1 && tt.tree.Size() != 1 {
+					t.Errorf("a lone terminal should not be hoisted. got size: %d", tt.tree.Size())
+				}
+				if oldSize > 1 && tt.tree.Size() >= oldSize {
+					t.Errorf("hoisted tree should be smaller than the original %d | got: %d", oldSize,
+						tt.tree.Size())
+				}
+			}
+		})
+	}
+}
+
+func TestDualTree_Shrink(t *testing.T) {
+	tests := []struct {
+		name    string
+		tree    *DualTree
+		oldTree *DualTree
+		args    []SymbolicExpression
+		wantErr bool
+	}{
+		{"nil", TreeNil(), TreeNil(), []SymbolicExpression{Const4}, true},
+		{"err-nil-symbExpressSet", TreeT_NT_T_0(), TreeT_NT_T_0(), nil, true},
+		{"err-empty-symbExpressSet", TreeT_NT_T_0(), TreeT_NT_T_0(), make([]SymbolicExpression, 0), true},
+		{"T", TreeT_X(), TreeT_X(), []SymbolicExpression{Const4}, false},
+		{"T-NT-T", TreeT_NT_T_0(), TreeT_NT_T_0(), []SymbolicExpression{Const8, X1}, false},
+		{"T-NT-T-NT-T-NT-T", TreeT_NT_T_NT_T_NT_T_0(), TreeT_NT_T_NT_T_NT_T_0(), []SymbolicExpression{Const4},
+			false},
+		{"Unary", TreeVine_D3(), TreeVine_D3(), []SymbolicExpression{X1}, false},
+		{"Mixed", TreeSinXMult4(), TreeSinXMult4(), []SymbolicExpression{Const8}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if err = tt.tree.Shrink(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("DualTree.Shrink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := tt.tree.Validate(); err != nil {
+					t.Errorf("shrunk tree should be valid: %v", err)
+				}
+				oldNonTerminals, err := tt.oldTree.NonTerminals()
+				if err != nil {
+					t.Error(err)
+				}
+				newNonTerminals, err := tt.tree.NonTerminals()
+				if err != nil {
+					t.Error(err)
+				}
+				if len(oldNonTerminals) == 0 && tt.tree.Size() != 1 {
+					t.Errorf("a lone terminal should not be shrunk. got size: %d", tt.tree.Size())
+				}
+				if len(oldNonTerminals) > 0 && len(newNonTerminals) >= len(oldNonTerminals) {
+					t.Errorf("shrunk tree should have fewer non-terminals than %d | got: %d",
+						len(oldNonTerminals), len(newNonTerminals))
+				}
+			}
+		})
+	}
+}
+
+func TestDualTree_DuplicateSubTree(t *testing.T) {
+	tests := []struct {
+		name    string
+		tree    *DualTree
+		oldTree *DualTree
+		wantErr bool
+	}{
+		{"nil", TreeNil(), TreeNil(), true},
+		{"T", TreeT_X(), TreeT_X(), false},
+		{"T-NT-T", TreeT_NT_T_0(), TreeT_NT_T_0(), false},
+		{"T-NT-T-NT-T-NT-T", TreeT_NT_T_NT_T_NT_T_0(), TreeT_NT_T_NT_T_NT_T_0(), false},
+		{"Unary", TreeVine_D3(), TreeVine_D3(), false},
+		{"Mixed", TreeSinXMult4(), TreeSinXMult4(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if err = tt.tree.DuplicateSubTree(); (err != nil) != tt.wantErr {
+				t.Errorf("DualTree.DuplicateSubTree() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := tt.tree.Validate(); err != nil {
+					t.Errorf("tree with a duplicated subtree should be valid: %v", err)
+				}
+				oldSize := tt.oldTree.Size()
+				if oldSize == 1 && tt.tree.Size() != 1 {
+					t.Errorf("a lone terminal has no subtree to duplicate. got size: %d", tt.tree.Size())
+				}
+				if oldSize > 1 && tt.tree.Size() <= oldSize {
+					t.Errorf("tree with a duplicated subtree should be larger than %d | got: %d", oldSize,
+						tt.tree.Size())
+				}
+
+				keys := make(map[string]bool)
+				tt.tree.InOrderTraverse(func(node *DualTreeNode) {
+					if keys[node.key] {
+						t.Errorf("duplicated subtree should receive new keys, %s appears twice", node.key)
+					}
+					keys[node.key] = true
+				})
+			}
+		})
+	}
+}
+
+func TestDualTree_SwapChildren(t *testing.T) {
+	tests := []struct {
+		name    string
+		tree    *DualTree
+		oldTree *DualTree
+		want    string
+		wantErr bool
+	}{
+		{"nil", TreeNil(), TreeNil(), "", true},
+		{"T", TreeT_X(), TreeT_X(), "(x)", false},
+		{"T-NT-T", TreeT_NT_T_0(), TreeT_NT_T_0(), "((4)*(x))", false},
+		{"Unary", TreeVine_D3(), TreeVine_D3(), "(sin(sin(sin(x))))", false},
+		{"Mixed", TreeSinXMult4(), TreeSinXMult4(), "((4)*(sin(x)))", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if err = tt.tree.SwapChildren(); (err != nil) != tt.wantErr {
+				t.Errorf("DualTree.SwapChildren() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := tt.tree.Validate(); err != nil {
+					t.Errorf("tree with swapped children should be valid: %v", err)
+				}
+				if tt.tree.Size() != tt.oldTree.Size() {
+					t.Errorf("swapping children should not change the size %d | got: %d", tt.oldTree.Size(),
+						tt.tree.Size())
+				}
+				got, err := tt.tree.ToMathematicalString()
+				if err != nil {
+					t.Error(err)
+				}
+				if got != tt.want {
+					t.Errorf("DualTree.SwapChildren() got = %s, want %s", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDualTree_PerturbConstant(t *testing.T) {
+	tests := []struct {
+		name    string
+		tree    *DualTree
+		oldTree *DualTree
+		k       float64
+		wantErr bool
+	}{
+		{"nil", TreeNil(), TreeNil(), 1, true},
+		{"err-zero-k", TreeT_1(), TreeT_1(), 0, true},
+		{"err-negative-k", TreeT_1(), TreeT_1(), -1, true},
+		{"T-Variable", TreeT_X(), TreeT_X(), 1, false},
+		{"T", TreeT_1(), TreeT_1(), 1, false},
+		{"T-NT-T", TreeT_NT_T_0(), TreeT_NT_T_0(), 0.5, false},
+		{"T-NT-T-NT-T-NT-T", TreeT_NT_T_NT_T_NT_T_0(), TreeT_NT_T_NT_T_NT_T_0(), 2, false},
+		{"Mixed", TreeSinXMult4(), TreeSinXMult4(), 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if err = tt.tree.PerturbConstant(tt.k); (err != nil) != tt.wantErr {
+				t.Errorf("DualTree.PerturbConstant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := tt.tree.Validate(); err != nil {
+					t.Errorf("perturbed tree should be valid: %v", err)
+				}
+				oldTreeLeafs, err := tt.oldTree.Terminals()
+				if err != nil {
+					t.Error(err)
+				}
+				newTreeLeafs, err := tt.tree.Terminals()
+				if err != nil {
+					t.Error(err)
+				}
+				if len(oldTreeLeafs) != len(newTreeLeafs) {
+					t.Errorf("len of oldTree is not equal to new treeNode %d | got: %d", len(oldTreeLeafs),
+						len(newTreeLeafs))
+				}
+
+				hasConstant := false
+				diffCount := 0
+				for i := 0; i < len(oldTreeLeafs) && i < len(newTreeLeafs); i++ {
+					oldValue, err := strconv.ParseFloat(oldTreeLeafs[i].value, 64)
+					if err == nil {
+						hasConstant = true
+					}
+					if oldTreeLeafs[i].IsValEqual(*newTreeLeafs[i]) {
+						continue
+					}
+					diffCount++
+					newValue, err := strconv.ParseFloat(newTreeLeafs[i].value, 64)
+					if err != nil {
+						t.Errorf("perturbed terminal should be numeric. got: %s", newTreeLeafs[i].value)
+						continue
+					}
+					if math.Abs(math.Abs(newValue-oldValue)-tt.k) > 1e-9 {
+						t.Errorf("perturbed terminal should differ by %v. got: %v | original: %v", tt.k, newValue,
+							oldValue)
+					}
+				}
+				if hasConstant && diffCount != 1 {
+					t.Errorf("old and new treeNode should differ by a single constant. got %d differences", diffCount)
+				}
+				if !hasConstant && diffCount != 0 {
+					t.Errorf("a tree without constants should not change. got %d differences", diffCount)
+				}
+			}
+		})
+	}
+}
+
 func TestDualTree_HasDiverseNonTerminalSet(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -74,7 +74,7 @@ func (d *DualTreeNode) ToSymbolicExpression() (SymbolicExpression, error) {
 	}
 
 	kind := 0
-	if d.arity == 2 {
+	if d.arity > 0 {
 		kind = 1
 	}
 	return SymbolicExpression{
@@ -111,3 +111,14 @@ func (d DualTreeNode) Clone() DualTreeNode {
 	d.key = RandString(5)
 	return d
 }
+
+// cloneSubTree recursively copies a node and all of its children. Every copied node receives a new key.
+func (d *DualTreeNode) cloneSubTree() *DualTreeNode {
+	if d == nil {
+		return nil
+	}
+	clone := d.Clone()
+	clone.left = d.left.cloneSubTree()
+	clone.right = d.right.cloneSubTree()
+	return &clone
+}
@@ -0,0 +1,154 @@
+package evolution
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// ErrorMetricRMSE is the root mean squared error, it is used if FitnessStrategy.ErrorMetric is not set.
+	ErrorMetricRMSE = "RMSE"
+	// ErrorMetricMAE is the mean absolute error.
+	ErrorMetricMAE = "MAE"
+	// ErrorMetricMaxAE is the largest absolute error at any point of the spec.
+	ErrorMetricMaxAE = "MaxAE"
+	// ErrorMetricNRMSE is the RMSE divided by the range of the dependent values of the spec.
+	ErrorMetricNRMSE = "NRMSE"
+	// ErrorMetricOneMinusR2 is 1 - R², the residual sum of squares divided by the total sum of squares of the spec.
+	ErrorMetricOneMinusR2 = "OneMinusR2"
+)
+
+// AllErrorMetrics lists every supported error metric.
+var AllErrorMetrics = []string{ErrorMetricRMSE, ErrorMetricMAE, ErrorMetricMaxAE, ErrorMetricNRMSE,
+	ErrorMetricOneMinusR2}
+
+// ValidateErrorMetric returns an error if the metric is not one of AllErrorMetrics. An empty metric is valid and
+// defaults to ErrorMetricRMSE.
+func ValidateErrorMetric(metric string) error {
+	if metric == "" {
+		return nil
+	}
+	for _, errorMetric := range AllErrorMetrics {
+		if metric == errorMetric {
+			return nil
+		}
+	}
+	return fmt.Errorf("ValidateErrorMetric | unknown error metric %q, use one of %v", metric, AllErrorMetrics)
+}
+
+// ResolvedErrorMetric returns the error metric of the fitness strategy, ErrorMetricRMSE if none is set.
+func (f FitnessStrategy) ResolvedErrorMetric() string {
+	if f.ErrorMetric == "" {
+		return ErrorMetricRMSE
+	}
+	return f.ErrorMetric
+}
+
+// AggregateError reduces the residuals of a program at every point of the spec to a single error using the given
+// metric. observed holds the dependent values of the spec, it is only used by ErrorMetricNRMSE and
+// ErrorMetricOneMinusR2. If the observed values are constant both fall back to the unnormalised RMSE and MSE
+// respectively, so that a perfect program still has an error of 0.
+func AggregateError(metric string, residuals, observed []float64) float64 {
+	if len(residuals) < 1 {
+		return 0
+	}
+	size := float64(len(residuals))
+
+	switch metric {
+	case ErrorMetricMAE:
+		sum := 0.0
+		for _, residual := range residuals {
+			sum += math.Abs(residual)
+		}
+		return sum / size
+	case ErrorMetricMaxAE:
+		max := 0.0
+		for _, residual := range residuals {
+			max = math.Max(max, math.Abs(residual))
+		}
+		return max
+	case ErrorMetricNRMSE:
+		rmse := AggregateError(ErrorMetricRMSE, residuals, observed)
+		if len(observed) < 1 {
+			return rmse
+		}
+		min, max := observed[0], observed[0]
+		for _, value := range observed {
+			min = math.Min(min, value)
+			max = math.Max(max, value)
+		}
+		if max == min {
+			return rmse
+		}
+		return rmse / (max - min)
+	case ErrorMetricOneMinusR2:
+		sumSquaredResiduals := 0.0
+		for _, residual := range residuals {
+			sumSquaredResiduals += residual * residual
+		}
+		if len(observed) < 1 {
+			return sumSquaredResiduals / size
+		}
+		mean := 0.0
+		for _, value := range observed {
+			mean += value
+		}
+		mean = mean / float64(len(observed))
+		sumSquaredTotal := 0.0
+		for _, value := range observed {
+			sumSquaredTotal += (value - mean) * (value - mean)
+		}
+		if sumSquaredTotal == 0 {
+			return sumSquaredResiduals / size
+		}
+		return sumSquaredResiduals / sumSquaredTotal
+	default:
+		sumSquaredResiduals := 0.0
+		for _, residual := range residuals {
+			sumSquaredResiduals += residual * residual
+		}
+		return math.Sqrt(sumSquaredResiduals / size)
+	}
+}
+
+// ErrorMetric returns the error metric the thresholds of the spec were computed with.
+func (spec SpecMulti) ErrorMetric() string {
+	if len(spec) < 1 || spec[0].ErrorMetric == "" {
+		return ErrorMetricRMSE
+	}
+	return spec[0].ErrorMetric
+}
+
+// aggregateError reduces residuals, which must hold a value for every point of the spec, using the error metric of
+// the spec.
+func (spec SpecMulti) aggregateError(residuals []float64) float64 {
+	metric := spec.ErrorMetric()
+	if metric != ErrorMetricNRMSE && metric != ErrorMetricOneMinusR2 {
+		return AggregateError(metric, residuals, nil)
+	}
+
+	observed := make([]float64, len(spec))
+	for i := range spec {
+		observed[i] = spec[i].Dependent
+	}
+	return AggregateError(metric, residuals, observed)
+}
+
+// antagonistThresholdError is the error an antagonist must reach to gain positive fitness. The thresholds of each
+// point are treated as residuals and aggregated with the error metric of the spec.
+func (spec SpecMulti) antagonistThresholdError() float64 {
+	thresholds := make([]float64, len(spec))
+	for i := range spec {
+		thresholds[i] = math.Abs(spec[i].AntagonistThreshold)
+	}
+	return spec.aggregateError(thresholds)
+}
+
+// protagonistThresholdError is the error a protagonist must stay within to gain positive fitness.
+func (spec SpecMulti) protagonistThresholdError() float64 {
+	thresholds := make([]float64, len(spec))
+	for i := range spec {
+		thresholds[i] = math.Abs(spec[i].ProtagonistThreshold)
+	}
+	return spec.aggregateError(thresholds)
+}
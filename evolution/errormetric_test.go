@@ -0,0 +1,83 @@
+package evolution
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAggregateError(t *testing.T) {
+	residuals := []float64{1, -2, 3, -4}
+	observed := []float64{0, 2, 4, 6}
+	constant := []float64{2, 2, 2, 2}
+	tests := []struct {
+		name      string
+		metric    string
+		residuals []float64
+		observed  []float64
+		want      float64
+	}{
+		{"default", "", residuals, observed, math.Sqrt(7.5)},
+		{"rmse", ErrorMetricRMSE, residuals, observed, math.Sqrt(7.5)},
+		{"mae", ErrorMetricMAE, residuals, observed, 2.5},
+		{"maxae", ErrorMetricMaxAE, residuals, observed, 4},
+		{"nrmse", ErrorMetricNRMSE, residuals, observed, math.Sqrt(7.5) / 6},
+		{"nrmse-constant-observed", ErrorMetricNRMSE, residuals, constant, math.Sqrt(7.5)},
+		{"one-minus-r2", ErrorMetricOneMinusR2, residuals, observed, 1.5},
+		{"one-minus-r2-constant-observed", ErrorMetricOneMinusR2, residuals, constant, 7.5},
+		{"perfect", ErrorMetricOneMinusR2, []float64{0, 0, 0, 0}, observed, 0},
+		{"empty", ErrorMetricMAE, []float64{}, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AggregateError(tt.metric, tt.residuals, tt.observed); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("AggregateError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateErrorMetric(t *testing.T) {
+	for _, metric := range append([]string{""}, AllErrorMetrics...) {
+		if err := ValidateErrorMetric(metric); err != nil {
+			t.Errorf("ValidateErrorMetric(%q) error = %v", metric, err)
+		}
+	}
+	if err := ValidateErrorMetric("MSE"); err == nil {
+		t.Errorf("ValidateErrorMetric(MSE) should return an error")
+	}
+}
+
+func TestThresholdedRatioFitness_ErrorMetric(t *testing.T) {
+	rmseX := math.Sqrt(46) // RMSE of x against x*x over the fitnessCacheTestSpec
+	tests := []struct {
+		metric     string
+		wantA      float64
+		wantP      float64
+		wantADelta float64
+	}{
+		{ErrorMetricRMSE, (rmseX - 4) / rmseX, -0.5, rmseX},
+		{ErrorMetricMAE, 0.2, -0.5, 5},
+		{ErrorMetricMaxAE, 2.0 / 3, -0.5, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.metric, func(t *testing.T) {
+			spec := fitnessCacheTestSpec()
+			spec.setThresholds(SpecParam{DivideByZeroPenalty: -1}, FitnessStrategy{ErrorMetric: tt.metric})
+			for i := range spec {
+				spec[i].AntagonistThreshold = 4
+				spec[i].ProtagonistThreshold = 1
+			}
+
+			gotA, gotP, gotADelta, gotPDelta, err := ThresholdedRatioFitness(spec, fitnessCacheTestProgram(t, "x"),
+				fitnessCacheTestProgram(t, "x*x+2"), DivByZeroSteadyPenalize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(gotA-tt.wantA) > 1e-9 || math.Abs(gotP-tt.wantP) > 1e-9 ||
+				math.Abs(gotADelta-tt.wantADelta) > 1e-9 || math.Abs(gotPDelta-2) > 1e-9 {
+				t.Errorf("ThresholdedRatioFitness() = %v %v %v %v, want %v %v %v 2", gotA, gotP, gotADelta,
+					gotPDelta, tt.wantA, tt.wantP, tt.wantADelta)
+			}
+		})
+	}
+}
@@ -21,6 +21,12 @@ type EvolutionEngine struct {
 	successfulGenerationsByAvg                  int
 	minimumTopProtagonistThreshold              int
 	minimumMeanProtagonistInGenerationThreshold int
+	// fitnessCacheHits and fitnessCacheMisses are the cache counters at the end of the previous generation.
+	fitnessCacheHits   int
+	fitnessCacheMisses int
+	// mutationRates are the antagonist and protagonist mutation rates adapted by MutationRateOneFifth,
+	// indexed by kind.
+	mutationRates [2]float64
 
 	ProgressBar *uiprogress.Bar
 }
@@ -133,6 +139,15 @@ func (engine *EvolutionEngine) InitializeGenerations(params EvolutionParams) (an
 	return antagonists, protagonists, err
 }
 
+// recordFitnessCacheStats sets the fitness cache lookups made since the previous call on the generation. It must be
+// called once the generation has competed and before the next generation competes.
+func (engine *EvolutionEngine) recordFitnessCacheStats(generation *Generation) {
+	hits, misses := engine.Parameters.fitnessCache.Stats()
+	generation.FitnessCacheHits = hits - engine.fitnessCacheHits
+	generation.FitnessCacheMisses = misses - engine.fitnessCacheMisses
+	engine.fitnessCacheHits, engine.fitnessCacheMisses = hits, misses
+}
+
 func (engine *EvolutionEngine) RunGenerationStatistics(currentGeneration *Generation) {
 	correlation := stat.Correlation(currentGeneration.AntagonistAvgFitness,
 		currentGeneration.ProtagonistAvgFitness, nil)
@@ -162,6 +177,11 @@ func (engine *EvolutionEngine) RunGenerationStatistics(currentGeneration *Genera
 	currentGeneration.Correlation = correlation
 	currentGeneration.Covariance = covariance
 
+	currentGeneration.Mutex.Lock()
+	currentGeneration.AntagonistUniquePhenotypes = len(UniquePhenotypes(currentGeneration.Antagonists))
+	currentGeneration.ProtagonistUniquePhenotypes = len(UniquePhenotypes(currentGeneration.Protagonists))
+	currentGeneration.Mutex.Unlock()
+
 	if currentGeneration.BestAntagonist.Id == "" {
 		bestAnt := &Individual{AverageFitness: math.MinInt64}
 		currentGeneration.Mutex.Lock()
@@ -264,6 +284,31 @@ func (engine *EvolutionEngine) validate() error {
 	if engine.Parameters.Selection.Parent.TournamentSize >= engine.Parameters.EachPopulationSize {
 		return fmt.Errorf("Tournament Size should not be greater than the population size.")
 	}
+	if err := engine.Parameters.Selection.Parent.validate(engine.Parameters.EachPopulationSize); err != nil {
+		return err
+	}
+	if err := engine.Parameters.Selection.Survivor.validate(engine.Parameters.EachPopulationSize); err != nil {
+		return err
+	}
+	if err := engine.Parameters.ALPS.validate(engine.Parameters); err != nil {
+		return err
+	}
+	if err := validateAvailableStrategies(engine.Parameters.Strategies,
+		engine.Parameters.SpecParam.AvailableSymbolicExpressions.Terminals); err != nil {
+		return err
+	}
+	if err := validateGenome(engine.Parameters.Strategies, engine.Parameters.Reproduction); err != nil {
+		return err
+	}
+	if err := engine.Parameters.Reproduction.MutationRate.validate(); err != nil {
+		return err
+	}
+	if err := ValidateErrorMetric(engine.Parameters.FitnessStrategy.ErrorMetric); err != nil {
+		return err
+	}
+	if err := validateSETFitness(engine.Parameters.Topology, engine.Parameters.FitnessStrategy); err != nil {
+		return err
+	}
 	//err := e.StartIndividual.Validate()
 	//if err != nil {
 	//	return err
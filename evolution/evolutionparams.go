@@ -13,7 +13,10 @@ type EvolutionParams struct {
 	// StartIndividual - Output Only - This is set by the SpecParam Expression. Do not set it manually
 	StartIndividual Program
 	// Spec - Output Only - This is set by the SpecParam Expression. Do not set it manually
-	Spec      SpecMulti `json:"spec"`
+	Spec SpecMulti `json:"spec"`
+	// TestSpec - Output Only - This is the held out set defined by SpecParam.TestSplit. It is never used to calculate
+	// fitness, only to score how well the top protagonists generalise.
+	TestSpec  SpecMulti `json:"testSpec"`
 	SpecParam SpecParam `json:"specParam"`
 	// MaxGenerations activates the ability for a variable number of generations before the simulation ends.
 	// The value must be greater than 9 for the activation to begin, if not,
@@ -39,12 +42,25 @@ type EvolutionParams struct {
 	// This value must be even otherwise pairwise operations such as crossover will fail
 	EachPopulationSize int  `json:"eachPopulationSize",csv:"eachPopulationSize"`
 	EnableParallelism  bool `json:"enableParallelism",csv:"enableParallelism"`
+	// EnableFitnessCache memoises fitness evaluations of identical antagonist and protagonist programs within and
+	// across generations. Hits and misses are reported in the generation log.
+	EnableFitnessCache bool `json:"enableFitnessCache"`
+	// FitnessCacheCapacity bounds the number of evaluations held by the fitness cache,
+	// it defaults to DefaultFitnessCacheCapacity.
+	FitnessCacheCapacity int `json:"fitnessCacheCapacity"`
+	// fitnessCache is created at the start of Evolve if EnableFitnessCache is set. It is shared by all copies of the
+	// params.
+	fitnessCache *FitnessCache
+	// fitnessFunction is the FitnessFunction registered under FitnessStrategy.Type, resolved at the start of Evolve.
+	fitnessFunction FitnessFunction
 
 	Strategies Strategies `json:"strategies",csv:"strategies"`
 
 	FitnessStrategy FitnessStrategy `json:"fitnessStrategy",csv:"fitnessStrategy"`
 	Reproduction    Reproduction    `json:"reproduction",csv:"reproduction"`
 	Selection       Selection       `json:"selection",csv:"selection"`
+	// ALPS splits each population into age layers that select and breed separately, see ALPS.
+	ALPS ALPS `json:"alps",csv:"alps"`
 
 	// FitnessCalculatorType allows user to select the fitness calculator.
 	// The more complex the function 1 is better but slower. 0 for simple polynomials with single digit constants e.
@@ -91,6 +107,15 @@ type StatisticsOutput struct {
 	OutputPath string `json:"outputPath"`
 	Name       string `json:"name"`
 	OutputDir  string `json:"outputDir"`
+	// SimplifyEquations writes the algebraically simplified equation of each individual next to its raw equation in
+	// the statistics CSVs. The raw equations are always written.
+	SimplifyEquations bool `json:"simplifyEquations"`
+	// EnableStrategyCredits assigns credit to every strategy for the fitness of the individuals in each run,
+	// see StrategyCredits. Ablating genes replays competitions so it is off by default.
+	EnableStrategyCredits bool `json:"enableStrategyCredits"`
+	// StrategyAblationSamples is the number of competitions each ablated genome is averaged over when assigning
+	// credit to strategies. If it is not set DefaultStrategyAblationSamples is used.
+	StrategyAblationSamples int `json:"strategyAblationSamples"`
 }
 
 type AvailableVariablesAndOperators struct {
@@ -109,14 +134,35 @@ type Strategies struct {
 	//AvailableStrategies            []Strategy `json:"availableStrategies"`
 	AntagonistAvailableStrategies  []Strategy `json:"antagonistAvailableStrategies"`
 	ProtagonistAvailableStrategies []Strategy `json:"protagonistAvailableStrategies"`
+	// AntagonistStrategyWeights and ProtagonistStrategyWeights are the relative probabilities of sampling each of the
+	// available strategies when generating or mutating a Strategy genome, e.g. a weight of 0.1 for StrategyFellTree
+	// against 1 for every other strategy makes it rare. If they are empty every strategy is equally likely.
+	AntagonistStrategyWeights  []float64 `json:"antagonistStrategyWeights"`
+	ProtagonistStrategyWeights []float64 `json:"protagonistStrategyWeights"`
 
 	AntagonistStrategyCount  int `json:"antagonistStrategyCount"`
 	ProtagonistStrategyCount int `json:"protagonistStrategyCount"`
 
+	// AntagonistMinStrategyCount and AntagonistMaxStrategyCount bound the length of an antagonist's Strategy genome
+	// once it is changed by length changing mutations or CrossoverCutAndSplice. They default to 1 and
+	// AntagonistStrategyCount respectively.
+	AntagonistMinStrategyCount int `json:"antagonistMinStrategyCount"`
+	AntagonistMaxStrategyCount int `json:"antagonistMaxStrategyCount"`
+	// ProtagonistMinStrategyCount and ProtagonistMaxStrategyCount bound the length of a protagonist's Strategy genome.
+	// They default to 1 and ProtagonistStrategyCount respectively.
+	ProtagonistMinStrategyCount int `json:"protagonistMinStrategyCount"`
+	ProtagonistMaxStrategyCount int `json:"protagonistMaxStrategyCount"`
+
 	DepthOfRandomNewTrees int `json:"depthOfRandomNewTrees"`
+
+	// EnableTrace records how each strategy transformed the tree every time an individual's Strategy is applied,
+	// see StrategyTrace. The traces of the best individuals are written as JSON next to best.csv.
+	EnableTrace bool `json:"enableTrace"`
 }
 
 type FitnessStrategy struct {
+	// Type is the name of a registered FitnessFunction, see RegisterFitnessFunction. TopologySET scores individuals
+	// without an opponent and only supports FitnessMonoThresholdedRatio and FitnessDualThresholdedRatio.
 	Type string `json:"type"`
 	// AntagonistThresholdMultiplier is the multiplier applied to the antagonist delta when calculating fitness.
 	// A large value means that antagonists have to attain a greater delta from the spec in order to gain adequate
@@ -131,6 +177,10 @@ type FitnessStrategy struct {
 	// this value is used in both DualThresholdedRatioFitness and ThresholdedRatioFitness as a fitness value for
 	// both antagonist and protagonists thresholds.
 	ProtagonistThresholdMultiplier float64 `json:"protagonistThresholdMultiplier"`
+
+	// ErrorMetric aggregates the error of a program over the spec, see AllErrorMetrics. The thresholds are
+	// aggregated with the same metric. If it is empty ErrorMetricRMSE is used.
+	ErrorMetric string `json:"errorMetric"`
 }
 
 type SpecParam struct {
@@ -148,6 +198,61 @@ type SpecParam struct {
 	AvailableSymbolicExpressions AvailableSymbolicExpressions
 	DivideByZeroStrategy         string  `json:"divideByZeroStrategy",csv:"divideByZeroStrategy"`
 	DivideByZeroPenalty          float64 `json:"divideByZeroPenalty",csv:"divideByZeroPenalty"`
+
+	// Variables defines the range and seed of each independent variable in the spec e.g. x, y and z for x*y+z.
+	// If it is empty, a single variable x is sampled using Range and Seed.
+	Variables []VariableParam `json:"variables"`
+	// Sampling determines how points are drawn from the variable space. Either SpecSamplingGrid (default) or
+	// SpecSamplingRandom
+	Sampling string `json:"sampling"`
+	// SampleCount is the number of points drawn when using SpecSamplingRandom. If less than 1, Range is used.
+	SampleCount int `json:"sampleCount"`
+
+	// DatasetPath is the path to a .csv or .json file of observed data points. If it is set,
+	// the spec is loaded from the file instead of being generated from Expression,
+	// and Expression is only used to seed the StartIndividual. If Expression is empty the first independent
+	// variable is used as the seed.
+	DatasetPath string `json:"datasetPath"`
+	// DatasetDependent is the name of the dependent column in a CSV dataset. If empty the last column is used.
+	DatasetDependent string `json:"datasetDependent"`
+
+	// TestSplit defines a held out test set used to check whether the top protagonists generalise beyond the spec.
+	// One of SpecTestSplitInterpolation, SpecTestSplitExtrapolation or SpecTestSplitRandom. If empty,
+	// no test set is created.
+	TestSplit string `json:"testSplit"`
+	// TestRatio is the fraction of the spec held out when using SpecTestSplitRandom. Defaults to 0.2
+	TestRatio float64 `json:"testRatio"`
+	// TestRange is the number of points beyond either end of each variable's range used when using
+	// SpecTestSplitExtrapolation. Defaults to 2
+	TestRange int `json:"testRange"`
+}
+
+const (
+	// SpecTestSplitInterpolation tests on points halfway between the points of the spec e.g. 0.5, 1.5 for a spec of
+	// 0, 1, 2
+	SpecTestSplitInterpolation = "SpecTestSplitInterpolation"
+	// SpecTestSplitExtrapolation tests on points outside the range of the spec e.g. -2, -1 and 3, 4 for a spec of 0, 1,
+	// 2 with a TestRange of 2
+	SpecTestSplitExtrapolation = "SpecTestSplitExtrapolation"
+	// SpecTestSplitRandom moves a random TestRatio of the spec points into the test set.
+	// It is the only split available to datasets as there is no expression to generate new points from.
+	SpecTestSplitRandom = "SpecTestSplitRandom"
+)
+
+const (
+	// SpecSamplingGrid samples every integer point of each variable's range, and every combination of them.
+	// A spec with variables x and y of range 5 will contain 25 points.
+	SpecSamplingGrid = "SpecSamplingGrid"
+	// SpecSamplingRandom draws SampleCount points uniformly from the continuous range of each variable
+	SpecSamplingRandom = "SpecSamplingRandom"
+)
+
+// VariableParam defines the sampled range of a single independent variable.
+// A Range of 4 and a Seed of -2 will include -2, -1, 0 and 1.
+type VariableParam struct {
+	Name  string `json:"name"`
+	Range int    `json:"range"`
+	Seed  int    `json:"seed"`
 }
 
 type Reproduction struct {
@@ -159,6 +264,33 @@ type Reproduction struct {
 	CrossoverPercentage   float64 `json:"crossoverPercentage",csv:"crossoverPercentage"`
 	ProbabilityOfMutation float64 `json:"probabilityOfMutation",csv:"probabilityOfMutation"`
 	KPointCrossover       int     `json:"kPointCrossover",csv:"kPointCrossover"`
+	// MutationOperators are the operators a mutating individual picks from at random, see MutateGenome.
+	// If it is empty a single gene is substituted as with MutationSubstitute.
+	MutationOperators []string `json:"mutationOperators"`
+	// MutationOperatorWeights are the relative probabilities of picking each of the MutationOperators.
+	// If it is empty every operator is equally likely.
+	MutationOperatorWeights []float64 `json:"mutationOperatorWeights"`
+	// MutationRate adapts ProbabilityOfMutation during evolution, see MutationRate.
+	MutationRate MutationRate `json:"mutationRate",csv:"mutationRate"`
+}
+
+// MutationRate controls how the probability of mutating an individual changes over the course of evolution.
+// ProbabilityOfMutation is used as the initial rate.
+type MutationRate struct {
+	// Type is one of MutationRateFixed, MutationRateSchedule, MutationRateOneFifth or MutationRateSelfAdaptive.
+	// If it is empty the rate is fixed.
+	Type string `json:"type",csv:"type"`
+	// FinalRate is the rate MutationRateSchedule reaches in the final generation.
+	FinalRate float64 `json:"finalRate",csv:"finalRate"`
+	// MinRate and MaxRate bound the adapted rate. They default to DefaultMinMutationRate and 1.
+	MinRate float64 `json:"minRate",csv:"minRate"`
+	MaxRate float64 `json:"maxRate",csv:"maxRate"`
+	// AdaptationFactor in (0, 1) scales the rate down whenever fewer than a fifth of mutations succeed when using
+	// MutationRateOneFifth, and up otherwise. It defaults to DefaultMutationRateAdaptationFactor.
+	AdaptationFactor float64 `json:"adaptationFactor",csv:"adaptationFactor"`
+	// LearningRate is the standard deviation of the log-normal perturbation applied to an individual's own rate
+	// when using MutationRateSelfAdaptive. It defaults to DefaultMutationRateLearningRate.
+	LearningRate float64 `json:"learningRate",csv:"learningRate"`
 }
 type Selection struct {
 	Parent   ParentSelection   `json:"parentSelection",csv:"parentSelection"`
@@ -168,6 +300,22 @@ type Selection struct {
 type ParentSelection struct {
 	Type           string `json:"type",csv:"type"`
 	TournamentSize int    `json:"tournamentSize",csv:"tournamentSize"`
+	// EliteCount is the number of fittest individuals that become parents when using ParentSelectionElitism.
+	// It must be between 1 and EachPopulationSize.
+	EliteCount int `json:"eliteCount",csv:"eliteCount"`
+	// SelectionPressure controls how strongly rank selection favours the fittest individuals,
+	// see LinearRankSelection and ExponentialRankSelection. It defaults to DefaultSelectionPressure.
+	SelectionPressure float64 `json:"selectionPressure",csv:"selectionPressure"`
+	// Temperature is the starting temperature of Boltzmann selection, it defaults to DefaultBoltzmannTemperature.
+	Temperature float64 `json:"temperature",csv:"temperature"`
+	// TemperatureDecay is the fraction the Boltzmann temperature cools by every generation. 0 keeps it constant.
+	TemperatureDecay float64 `json:"temperatureDecay",csv:"temperatureDecay"`
+	// TruncationRatio is the fraction of the fittest individuals truncation selection chooses parents from.
+	// It defaults to DefaultTruncationRatio.
+	TruncationRatio float64 `json:"truncationRatio",csv:"truncationRatio"`
+	// Epsilon is how far above the best case score an individual may be to survive a case of epsilon-lexicase
+	// selection. 0 uses the median absolute deviation of each case.
+	Epsilon float64 `json:"epsilon",csv:"epsilon"`
 }
 
 type SurvivorSelection struct {
@@ -176,6 +324,21 @@ type SurvivorSelection struct {
 	// 1 means all parents move on. 0 means only children move on. Any number in betwee is a percentage value.
 	// It cannot be greater than 1 or less than 0.
 	SurvivorPercentage float64 `json:"survivorPercentage",csv:"survivorPercentage"`
+	// ReplacementCount is the number of worst parents replaced by children when using
+	// SurvivorSelectionSteadyState. It must be between 1 and EachPopulationSize.
+	ReplacementCount int `json:"replacementCount",csv:"replacementCount"`
+	// MaxAge is the number of generations an individual can survive into before it is replaced when using
+	// SurvivorSelectionAgeBased. It must be at least 1.
+	MaxAge int `json:"maxAge",csv:"maxAge"`
+}
+
+// ALPS configures the Age-Layered Population Structure mode. Each population is split into Layers age layers of equal
+// size, individuals only take part in parent and survivor selection with the individuals of their own layer.
+// Every AgeGap generations the bottom layer is replaced by new random individuals. ALPS is disabled if Layers is
+// less than 2.
+type ALPS struct {
+	Layers int `json:"layers",csv:"layers"`
+	AgeGap int `json:"ageGap",csv:"ageGap"`
 }
 
 func (e EvolutionParams) ToString() string {
@@ -201,19 +364,30 @@ func (e EvolutionParams) ToString() string {
 	builder.WriteString(strings.ReplaceAll(fmt.Sprintf("F%sa%.2fp%.2f",
 		fitness[:len(fitness)/2], e.FitnessStrategy.AntagonistThresholdMultiplier,
 		e.FitnessStrategy.ProtagonistThresholdMultiplier), ".", ""))
+	if e.FitnessStrategy.ResolvedErrorMetric() != ErrorMetricRMSE {
+		builder.WriteString(e.FitnessStrategy.ErrorMetric)
+	}
 	builder.WriteString("-")
 	//Parent
 	builder.WriteString(fmt.Sprintf("P%sTornSz%d", e.Selection.Parent.Type[0:2], e.Selection.Parent.TournamentSize))
+	builder.WriteString(e.Selection.Parent.toString())
 	builder.WriteString("-")
 	builder.WriteString(fmt.Sprintf("Tree%d", e.Strategies.DepthOfRandomNewTrees))
 	builder.WriteString("-")
 	//Survivor
 	builder.WriteString(strings.ReplaceAll(fmt.Sprintf("S%sPr%.2f", e.Selection.Survivor.Type[0:2],
 		e.Selection.Survivor.SurvivorPercentage), ".", ""))
+	builder.WriteString(e.Selection.Survivor.toString())
+	if e.ALPS.IsEnabled() {
+		builder.WriteString(fmt.Sprintf("ALPS%dx%d", e.ALPS.Layers, e.ALPS.AgeGap))
+	}
 	builder.WriteString("-")
 	// ReproductionPercentage
 	builder.WriteString(strings.ReplaceAll(fmt.Sprintf("Cro%.2fMut%.2f", e.Reproduction.CrossoverPercentage,
 		e.Reproduction.ProbabilityOfMutation), ".", ""))
+	if e.Reproduction.MutationRate.IsAdaptive() {
+		builder.WriteString(strings.TrimPrefix(e.Reproduction.MutationRate.Type, "MutationRate"))
+	}
 	builder.WriteString("-")
 	// StrategyCount
 	builder.WriteString(fmt.Sprintf("PSc%dASc%d", e.Strategies.ProtagonistStrategyCount,
@@ -22,6 +22,10 @@ type EvolutionResult struct {
 	CorrelationStd float64
 	Generational   Generational
 
+	// StrategyCredits is the credit every strategy is given for the fitness of antagonists and protagonists in the run,
+	// see StrategyCredits. It is only set if StatisticsOutput.EnableStrategyCredits is set.
+	StrategyCredits []StrategyCredit
+
 	ThoroughlySortedGenerations []*Generation
 	OutputFile                  string
 
@@ -49,6 +53,12 @@ type Generational struct {
 	ProtagonistSkewInEachGeneration       []float64
 	ProtagonistExKurtosisInEachGeneration []float64
 	ProtagonistAvgFitnessInEachGeneration []float64
+
+	AntagonistUniquePhenotypesInEachGeneration  []int
+	ProtagonistUniquePhenotypesInEachGeneration []int
+
+	AntagonistMutationRateInEachGeneration  []float64
+	ProtagonistMutationRateInEachGeneration []float64
 }
 
 func (e *EvolutionResult) Analyze(evolutionEngine *EvolutionEngine, generations []*Generation, isMoreFitnessBetter bool,
@@ -138,6 +148,13 @@ func (e *EvolutionResult) Analyze(evolutionEngine *EvolutionEngine, generations
 	if err != nil {
 		return err
 	}
+	if params.StatisticsOutput.EnableStrategyCredits {
+		e.StrategyCredits, err = StrategyCredits(&e.TopAntagonistInRun, &e.TopProtagonistInRun, generations[:genCount],
+			params)
+		if err != nil {
+			return err
+		}
+	}
 
 	e.Generational.BestAntagonistInEachGenerationByAvgFitness = make([]Individual, genCount)
 	e.Generational.BestProtagonistInEachGenerationByAvgFitness = make([]Individual, genCount)
@@ -153,6 +170,10 @@ func (e *EvolutionResult) Analyze(evolutionEngine *EvolutionEngine, generations
 	e.Generational.ProtagonistExKurtosisInEachGeneration = make([]float64, genCount)
 	e.Generational.CorrelationInEachGeneration = make([]float64, genCount)
 	e.Generational.CovarianceInEachGeneration = make([]float64, genCount)
+	e.Generational.AntagonistUniquePhenotypesInEachGeneration = make([]int, genCount)
+	e.Generational.ProtagonistUniquePhenotypesInEachGeneration = make([]int, genCount)
+	e.Generational.AntagonistMutationRateInEachGeneration = make([]float64, genCount)
+	e.Generational.ProtagonistMutationRateInEachGeneration = make([]float64, genCount)
 	evolutionEngine.ProgressBar.Incr()
 
 	for i := 0; i < genCount; i++ {
@@ -170,6 +191,10 @@ func (e *EvolutionResult) Analyze(evolutionEngine *EvolutionEngine, generations
 		e.Generational.ProtagonistExKurtosisInEachGeneration[i] = evolutionEngine.Generations[i].ProtagonistExKurtosis
 		e.Generational.CorrelationInEachGeneration[i] = evolutionEngine.Generations[i].Correlation
 		e.Generational.CovarianceInEachGeneration[i] = evolutionEngine.Generations[i].Covariance
+		e.Generational.AntagonistUniquePhenotypesInEachGeneration[i] = evolutionEngine.Generations[i].AntagonistUniquePhenotypes
+		e.Generational.ProtagonistUniquePhenotypesInEachGeneration[i] = evolutionEngine.Generations[i].ProtagonistUniquePhenotypes
+		e.Generational.AntagonistMutationRateInEachGeneration[i] = evolutionEngine.Generations[i].AntagonistMutationRate
+		e.Generational.ProtagonistMutationRateInEachGeneration[i] = evolutionEngine.Generations[i].ProtagonistMutationRate
 	}
 	e.HasBeenAnalyzed = true
 	evolutionEngine.ProgressBar.Incr()
@@ -0,0 +1,306 @@
+package evolution
+
+import (
+	"fmt"
+	"github.com/martinomburajr/masters-go/eval"
+	"strings"
+)
+
+const (
+	tokenNumber = iota
+	tokenIdentifier
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+	tokenEnd
+)
+
+// ExpressionParseError is returned by ParseExpression when the input is not a valid infix expression. Position is
+// the 1-based index of the offending character within the original expression.
+type ExpressionParseError struct {
+	Expression string
+	Position   int
+	Message    string
+}
+
+func (e *ExpressionParseError) Error() string {
+	return fmt.Sprintf("ParseExpression | %s at position %d in %q", e.Message, e.Position, e.Expression)
+}
+
+type expressionToken struct {
+	kind     int
+	value    string
+	position int
+}
+
+func (t expressionToken) String() string {
+	if t.kind == tokenEnd {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// binaryPrecedence holds the binding power of each infix operator. All operators are left associative.
+var binaryPrecedence = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+}
+
+// unaryPrecedence is the binding power of a prefix minus. It binds tighter than every binary operator so -x*y is
+// parsed as (-x)*y.
+const unaryPrecedence = 3
+
+// ParseExpression tokenizes a standard infix expression and builds the DualTree it describes using precedence
+// climbing. Multi-digit and decimal constants, variables of any length, parentheses, the binary operators + - * /
+// and the functions known to the eval package e.g. sin(x) or pow(x, 2) are supported. A prefix minus on a constant
+// produces a negative constant, on anything else it produces (0 - operand).
+func ParseExpression(expression string) (*DualTree, error) {
+	tokens, err := tokenizeExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &expressionParser{expression: expression, tokens: tokens}
+	if parser.peek().kind == tokenEnd {
+		return nil, parser.errorf(parser.peek(), "empty expression")
+	}
+
+	root, err := parser.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token.kind != tokenEnd {
+		return nil, parser.errorf(token, "unexpected %s", token)
+	}
+
+	return &DualTree{root: root}, nil
+}
+
+// tokenizeExpression splits the expression into tokens, ignoring whitespace. The returned slice always ends with a
+// tokenEnd token.
+func tokenizeExpression(expression string) ([]expressionToken, error) {
+	tokens := make([]expressionToken, 0, len(expression)+1)
+	for i := 0; i < len(expression); {
+		char := expression[i]
+		switch {
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			i++
+		case isDigit(char) || char == '.':
+			start := i
+			seenPoint := false
+			for ; i < len(expression) && (isDigit(expression[i]) || expression[i] == '.'); i++ {
+				if expression[i] == '.' {
+					if seenPoint {
+						return nil, &ExpressionParseError{expression, i + 1, "unexpected second decimal point"}
+					}
+					seenPoint = true
+				}
+			}
+			value := expression[start:i]
+			if value == "." {
+				return nil, &ExpressionParseError{expression, start + 1, "decimal point without digits"}
+			}
+			if strings.HasPrefix(value, ".") {
+				// the eval lexer requires a leading digit
+				value = "0" + value
+			}
+			tokens = append(tokens, expressionToken{tokenNumber, value, start + 1})
+		case isLetter(char) || char == '_':
+			start := i
+			for ; i < len(expression) && (isLetter(expression[i]) || isDigit(expression[i]) || expression[i] == '_'); i++ {
+			}
+			tokens = append(tokens, expressionToken{tokenIdentifier, expression[start:i], start + 1})
+		case char == '+' || char == '-' || char == '*' || char == '/':
+			tokens = append(tokens, expressionToken{tokenOperator, string(char), i + 1})
+			i++
+		case char == '(':
+			tokens = append(tokens, expressionToken{tokenLeftParen, "(", i + 1})
+			i++
+		case char == ')':
+			tokens = append(tokens, expressionToken{tokenRightParen, ")", i + 1})
+			i++
+		case char == ',':
+			tokens = append(tokens, expressionToken{tokenComma, ",", i + 1})
+			i++
+		default:
+			return nil, &ExpressionParseError{expression, i + 1, fmt.Sprintf("unexpected character %q", char)}
+		}
+	}
+	return append(tokens, expressionToken{tokenEnd, "", len(expression) + 1}), nil
+}
+
+func isDigit(char byte) bool {
+	return '0' <= char && char <= '9'
+}
+
+func isLetter(char byte) bool {
+	return ('a' <= char && char <= 'z') || ('A' <= char && char <= 'Z')
+}
+
+type expressionParser struct {
+	expression string
+	tokens     []expressionToken
+	pos        int
+}
+
+func (p *expressionParser) peek() expressionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *expressionParser) next() expressionToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEnd {
+		p.pos++
+	}
+	return token
+}
+
+func (p *expressionParser) errorf(token expressionToken, format string, args ...interface{}) error {
+	return &ExpressionParseError{p.expression, token.position, fmt.Sprintf(format, args...)}
+}
+
+func (p *expressionParser) expect(kind int, description string) (expressionToken, error) {
+	token := p.next()
+	if token.kind != kind {
+		return token, p.errorf(token, "expected %s but found %s", description, token)
+	}
+	return token, nil
+}
+
+// parseBinary parses operands joined by operators whose precedence is at least minPrecedence.
+func (p *expressionParser) parseBinary(minPrecedence int) (*DualTreeNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		token := p.peek()
+		precedence, ok := binaryPrecedence[token.value]
+		if token.kind != tokenOperator || !ok || precedence < minPrecedence {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseBinary(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = newParsedNonTerminal(token.value, left, right)
+	}
+}
+
+// parseUnary parses a prefix minus or plus followed by an operand.
+func (p *expressionParser) parseUnary() (*DualTreeNode, error) {
+	token := p.peek()
+	if token.kind != tokenOperator || (token.value != "-" && token.value != "+") {
+		return p.parsePrimary()
+	}
+	p.next()
+
+	if token.value == "+" {
+		return p.parseUnaryOperand()
+	}
+
+	if number := p.peek(); number.kind == tokenNumber {
+		p.next()
+		return newParsedTerminal("-" + number.value), nil
+	}
+
+	operand, err := p.parseUnaryOperand()
+	if err != nil {
+		return nil, err
+	}
+	return newParsedNonTerminal("-", newParsedTerminal("0"), operand), nil
+}
+
+// parseUnaryOperand parses the operand of a prefix operator.
+func (p *expressionParser) parseUnaryOperand() (*DualTreeNode, error) {
+	return p.parseBinary(unaryPrecedence)
+}
+
+// parsePrimary parses a constant, a variable, a function call or a parenthesised sub-expression.
+func (p *expressionParser) parsePrimary() (*DualTreeNode, error) {
+	token := p.next()
+	switch token.kind {
+	case tokenNumber:
+		return newParsedTerminal(token.value), nil
+	case tokenIdentifier:
+		if p.peek().kind == tokenLeftParen {
+			return p.parseFunction(token)
+		}
+		if eval.IsFunction(token.value) {
+			return nil, p.errorf(token, "function %q must be called with arguments", token.value)
+		}
+		return newParsedTerminal(token.value), nil
+	case tokenLeftParen:
+		node, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRightParen, "\")\""); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case tokenEnd:
+		return nil, p.errorf(token, "unexpected end of expression")
+	default:
+		return nil, p.errorf(token, "unexpected %s", token)
+	}
+}
+
+// parseFunction parses the argument list of a call to one of the eval functions. Unary functions hold their
+// argument in the left child, binary functions use both children.
+func (p *expressionParser) parseFunction(name expressionToken) (*DualTreeNode, error) {
+	arity, ok := eval.FunctionArity(name.value)
+	if !ok {
+		return nil, p.errorf(name, "unknown function %q", name.value)
+	}
+	p.next()
+
+	args := make([]*DualTreeNode, 0, arity)
+	if p.peek().kind != tokenRightParen {
+		for {
+			arg, err := p.parseBinary(1)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if _, err := p.expect(tokenRightParen, "\",\" or \")\""); err != nil {
+		return nil, err
+	}
+	if len(args) != arity {
+		return nil, p.errorf(name, "function %q takes %d argument(s) but got %d", name.value, arity, len(args))
+	}
+
+	node := &DualTreeNode{key: RandString(5), value: name.value, arity: arity}
+	if arity > 0 {
+		node.left = args[0]
+	}
+	if arity > 1 {
+		node.right = args[1]
+	}
+	return node, nil
+}
+
+func newParsedTerminal(value string) *DualTreeNode {
+	terminal := CreateTerminal(value)
+	return terminal.ToDualTreeNode(RandString(5))
+}
+
+func newParsedNonTerminal(value string, left, right *DualTreeNode) *DualTreeNode {
+	nonTerminal := CreateBinaryNonTerminal(value)
+	node := nonTerminal.ToDualTreeNode(RandString(5))
+	node.left = left
+	node.right = right
+	return node
+}
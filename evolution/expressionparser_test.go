@@ -0,0 +1,107 @@
+package evolution
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantMath string
+		vars     IndependentVariableMap
+		want     float64
+	}{
+		{"constant", "4", "(4)", IndependentVariableMap{}, 4},
+		{"multi-digit", "12", "(12)", IndependentVariableMap{}, 12},
+		{"decimal", "2.5*x", "((2.5)*(x))", IndependentVariableMap{"x": 2}, 5},
+		{"leading decimal point", ".5+x", "((0.5)+(x))", IndependentVariableMap{"x": 1}, 1.5},
+		{"precedence", "x*x+2", "(((x)*(x))+(2))", IndependentVariableMap{"x": 3}, 11},
+		{"precedence right", "2+x*x", "((2)+((x)*(x)))", IndependentVariableMap{"x": 3}, 11},
+		{"left associative sub", "10-x-1", "(((10)-(x))-(1))", IndependentVariableMap{"x": 3}, 6},
+		{"left associative div", "x/2/2", "(((x)/(2))/(2))", IndependentVariableMap{"x": 8}, 2},
+		{"parentheses", "(x+1)*(x-1)", "(((x)+(1))*((x)-(1)))", IndependentVariableMap{"x": 3}, 8},
+		{"nested parentheses", "((x))", "(x)", IndependentVariableMap{"x": 3}, 3},
+		{"whitespace", " x *  12 ", "((x)*(12))", IndependentVariableMap{"x": 2}, 24},
+		{"negative constant", "x*-3", "((x)*(-3))", IndependentVariableMap{"x": 2}, -6},
+		{"negated variable", "-x*y", "(((0)-(x))*(y))", IndependentVariableMap{"x": 2, "y": 5}, -10},
+		{"negated group", "-(x+1)", "((0)-((x)+(1)))", IndependentVariableMap{"x": 2}, -3},
+		{"unary plus", "+x", "(x)", IndependentVariableMap{"x": 2}, 2},
+		{"multivariate", "x*y+z", "(((x)*(y))+(z))", IndependentVariableMap{"x": 2, "y": 3, "z": 4}, 10},
+		{"long identifier", "rate*2", "((rate)*(2))", IndependentVariableMap{"rate": 4}, 8},
+		{"unary function", "sin(x)+1", "((sin(x))+(1))", IndependentVariableMap{"x": 0}, 1},
+		{"binary function", "pow(x+1, 2)", "(pow(((x)+(1)),(2)))", IndependentVariableMap{"x": 2}, 9},
+		{"nested functions", "sqrt(abs(x))", "(sqrt(abs(x)))", IndependentVariableMap{"x": -16}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := ParseExpression(tt.input)
+			if err != nil {
+				t.Fatalf("ParseExpression() error = %v", err)
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("ParseExpression() produced an invalid tree: %v", err)
+			}
+			gotMath, err := tree.ToMathematicalString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotMath != tt.wantMath {
+				t.Errorf("ParseExpression() ToMathematicalString = %v, want %v", gotMath, tt.wantMath)
+			}
+			evaluator, err := tree.Compile()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := evaluator.EvaluateWithVar(tt.vars)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ParseExpression() evaluated to %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExpression_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantPosition int
+	}{
+		{"empty", "", 1},
+		{"blank", "   ", 4},
+		{"trailing operator", "x+", 3},
+		{"leading operator", "*x", 1},
+		{"double operator", "x*/2", 3},
+		{"unclosed parenthesis", "(x+1", 5},
+		{"unopened parenthesis", "x+1)", 4},
+		{"empty parentheses", "()", 2},
+		{"invalid character", "x^2", 2},
+		{"two decimal points", "1.2.3", 4},
+		{"lone decimal point", "x+.", 3},
+		{"missing operator", "2x", 2},
+		{"unknown function", "foo(x)", 1},
+		{"too few arguments", "pow(x)", 1},
+		{"too many arguments", "sin(x, 2)", 1},
+		{"bare function name", "sin+1", 1},
+		{"trailing comma", "pow(x,)", 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := ParseExpression(tt.input)
+			if err == nil {
+				t.Fatalf("ParseExpression() = %v, want error", tree)
+			}
+			parseErr, ok := err.(*ExpressionParseError)
+			if !ok {
+				t.Fatalf("ParseExpression() error type = %T, want *ExpressionParseError", err)
+			}
+			if parseErr.Position != tt.wantPosition {
+				t.Errorf("ParseExpression() error position = %d, want %d (%v)", parseErr.Position, tt.wantPosition, err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package evolution
 
 import (
 	"fmt"
+	"github.com/martinomburajr/masters-go/eval"
 	"math"
 )
 
@@ -46,7 +47,8 @@ func ThresholdedRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
 // It returns information regarding thresholds as well,
 // they can be ignored if the function does not require information on the thresholds.
 // Furthermore these values are averaged based on the length of the spec.
-// A nil or empty spec will throw an error. It takes advantage of RMSE
+// A nil or empty spec will throw an error. The deltas and thresholds are aggregated with the error metric of the spec,
+// RMSE by default.
 func thresholdedRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
 	divByZeroStrategy string) (antagonistFitness,
 	protagonistFitness, antagonistFitnessError, protagonistFitnessError float64, err error) {
@@ -54,14 +56,12 @@ func thresholdedRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
 	fitnessPenalization := spec[0].DivideByZeroPenalty
 	badDeltaValue := math.NaN()
 
-	antagonistExpression, protagonistExpression, err := generateExpressions(antagonist, protagonist)
+	antagonistEvaluator, protagonistEvaluator, err := compilePrograms(antagonist, protagonist)
 	if err != nil {
 		return fitnessPenalization, fitnessPenalization, fitnessPenalization, fitnessPenalization, err
 	}
-	deltaProtagonist := 0.0
-	deltaAntagonist := 0.0
-	deltaAntagonistThreshold := 0.0
-	deltaProtagonistThreshold := 0.0
+	antagonistResiduals := make([]float64, len(spec))
+	protagonistResiduals := make([]float64, len(spec))
 	antagonistDividedByZeroCount := 0
 	protagonistDividedByZeroCount := 0
 	isAntagonistValid := true
@@ -69,9 +69,8 @@ func thresholdedRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
 
 	for i := range spec {
 		independentX := spec[i].Independents
-		independentXVal := spec[i].Independents["x"]
 		if isAntagonistValid {
-			dependentAntagonistVar, err := antagonist.EvalMulti(independentX, antagonistExpression)
+			dependentAntagonistVar, err := antagonistEvaluator.EvaluateWithVar(independentX)
 			if err != nil {
 				switch divByZeroStrategy {
 				case DivByZeroIgnore:
@@ -85,7 +84,7 @@ func thresholdedRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
 					dependentAntagonistVar = 0
 
 				case DivByZeroSteadyPenalize:
-					if independentXVal != 0 {
+					if !independentX.HasZero() {
 						// If the spec does not contain a zero,
 						// yet you still divide by zero. Give maximum penalty!
 						if math.IsNaN(dependentAntagonistVar) || dependentAntagonistVar == 0 {
@@ -100,13 +99,11 @@ func thresholdedRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
 					}
 				}
 			} else {
-				diff := (spec[i].Dependent - dependentAntagonistVar)
-				deltaAntagonist += diff * diff
+				antagonistResiduals[i] = spec[i].Dependent - dependentAntagonistVar
 			}
 		}
-		deltaAntagonistThreshold += (math.Abs(spec[i].AntagonistThreshold) * math.Abs(spec[i].AntagonistThreshold))
 		if isProtagonistValid {
-			dependentProtagonistVar, err := protagonist.EvalMulti(independentX, protagonistExpression)
+			dependentProtagonistVar, err := protagonistEvaluator.EvaluateWithVar(independentX)
 			if err != nil {
 				switch divByZeroStrategy {
 				case DivByZeroIgnore:
@@ -120,7 +117,7 @@ func thresholdedRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
 					dependentProtagonistVar = 0
 
 				case DivByZeroSteadyPenalize:
-					if independentXVal != 0 {
+					if !independentX.HasZero() {
 						if math.IsNaN(dependentProtagonistVar) || dependentProtagonistVar == 0 {
 							isProtagonistValid = false
 							protagonistFitness = fitnessPenalization
@@ -133,19 +130,15 @@ func thresholdedRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
 					}
 				}
 			} else {
-				diff := (spec[i].Dependent - dependentProtagonistVar)
-				deltaProtagonist += diff * diff
+				protagonistResiduals[i] = spec[i].Dependent - dependentProtagonistVar
 			}
 		}
-		deltaProtagonistThreshold += (spec[i].ProtagonistThreshold * spec[i].ProtagonistThreshold)
 	}
 
-	specLen := float64(len(spec))
-
-	deltaAntagonist = math.Sqrt(deltaAntagonist / specLen)
-	deltaProtagonist = math.Sqrt(deltaProtagonist / specLen)
-	deltaAntagonistThreshold = math.Sqrt(deltaAntagonistThreshold / specLen)
-	deltaProtagonistThreshold = math.Sqrt(deltaProtagonistThreshold / specLen)
+	deltaAntagonist := spec.aggregateError(antagonistResiduals)
+	deltaProtagonist := spec.aggregateError(protagonistResiduals)
+	deltaAntagonistThreshold := spec.antagonistThresholdError()
+	deltaProtagonistThreshold := spec.protagonistThresholdError()
 
 	if !isProtagonistValid && !isAntagonistValid {
 		// TODO is math.Nan the best alternative?
@@ -229,18 +222,12 @@ func thresholdedRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
 // Root mean square error is commonly used in climatology,
 // forecasting, and regression analysis to verify experimental results.
 func RMSE(forecast, observed []float64) float64 {
-	size := len(observed)
-
-	sumDiffSquared := 0.0
-	for i := 0; i < size; i++ {
-		diff := forecast[i] - observed[i]
-		diffSquared := diff * diff
-		sumDiffSquared += diffSquared
+	residuals := make([]float64, len(observed))
+	for i := range observed {
+		residuals[i] = forecast[i] - observed[i]
 	}
 
-	normalized := sumDiffSquared / float64(size)
-
-	return math.Sqrt(normalized)
+	return AggregateError(ErrorMetricRMSE, residuals, observed)
 }
 
 // fitnessParameterValidator is a convenience function that evaluates the input parameters to a fitness argument
@@ -299,6 +286,22 @@ func generateExpressions(antagonist, protagonist *Program) (antagonistExpression
 	return antagonistMathematicalExpression, protagonistMathematicalExpression, nil
 }
 
+// compilePrograms returns evaluators of the antagonist and protagonist trees that can be reused across the spec.
+func compilePrograms(antagonist, protagonist *Program) (antagonistEvaluator, protagonistEvaluator eval.Evaluator,
+	err error) {
+	antagonistEvaluator, err = antagonist.Compile()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	protagonistEvaluator, err = protagonist.Compile()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return antagonistEvaluator, protagonistEvaluator, nil
+}
+
 // calculateDelta calculates the absolute value between the truth and the supplied value
 func calculateDelta(truth float64, value float64) float64 {
 	return math.Abs(truth - value)
@@ -0,0 +1,171 @@
+package evolution
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultFitnessCacheCapacity is the number of fitness evaluations kept if EvolutionParams.FitnessCacheCapacity is not
+// set.
+const DefaultFitnessCacheCapacity = 10000
+
+const (
+	fitnessCacheDual        = "dual"
+	fitnessCacheAntagonist  = "antagonist"
+	fitnessCacheProtagonist = "protagonist"
+	fitnessCacheCases       = "cases"
+)
+
+// FitnessCache is a bounded, concurrency safe memo of fitness evaluations. Entries are keyed by the canonical
+// expressions of the competing programs, the fitness strategy and the identity of the spec they were evaluated
+// against. Once the capacity is reached the least recently used entry is evicted.
+type FitnessCache struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+
+	hits   int
+	misses int
+}
+
+// fitnessCacheEntry holds the values returned by a single fitness evaluation.
+type fitnessCacheEntry struct {
+	key                string
+	antagonistFitness  float64
+	protagonistFitness float64
+	antagonistDelta    float64
+	protagonistDelta   float64
+	caseDeltas         []float64
+}
+
+// NewFitnessCache returns an empty cache holding at most capacity entries. A capacity less than 1 uses
+// DefaultFitnessCacheCapacity.
+func NewFitnessCache(capacity int) *FitnessCache {
+	if capacity < 1 {
+		capacity = DefaultFitnessCacheCapacity
+	}
+	return &FitnessCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Stats returns the number of lookups that were served from the cache and the number that had to be evaluated.
+func (c *FitnessCache) Stats() (hits int, misses int) {
+	if c == nil {
+		return 0, 0
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.hits, c.misses
+}
+
+// Len returns the number of entries currently held.
+func (c *FitnessCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
+
+func (c *FitnessCache) get(key string) (fitnessCacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return fitnessCacheEntry{}, false
+	}
+	c.hits++
+	c.order.MoveToFront(element)
+	return element.Value.(fitnessCacheEntry), true
+}
+
+func (c *FitnessCache) put(entry fitnessCacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[entry.key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(fitnessCacheEntry).key)
+	}
+}
+
+// Evaluate returns the memoised result of fitnessFunction for the given pair, evaluating and storing it on a miss.
+// The name identifies the fitness function in the key. A nil cache always evaluates.
+func (c *FitnessCache) Evaluate(name string, fitnessFunction FitnessFunction, spec SpecMulti, antagonist,
+	protagonist *Program, divByZeroStrategy string) (antagonistFitness float64,
+	protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta float64, err error) {
+	entry, err := c.evaluate(fitnessCacheDual+name+divByZeroStrategy, spec, antagonist, protagonist,
+		func() (entry fitnessCacheEntry, err error) {
+			entry.antagonistFitness, entry.protagonistFitness, entry.antagonistDelta, entry.protagonistDelta, err =
+				fitnessFunction.Evaluate(spec, antagonist, protagonist, divByZeroStrategy)
+			return entry, err
+		})
+	return entry.antagonistFitness, entry.protagonistFitness, entry.antagonistDelta, entry.protagonistDelta, err
+}
+
+// evaluate looks up the evaluation of the given programs and calls fitness on a miss. Errors are never cached.
+// A nil cache always calls fitness.
+func (c *FitnessCache) evaluate(strategy string, spec SpecMulti, antagonist, protagonist *Program,
+	fitness func() (fitnessCacheEntry, error)) (fitnessCacheEntry, error) {
+	if c == nil {
+		return fitness()
+	}
+	key, ok := fitnessCacheKey(strategy, spec, antagonist, protagonist)
+	if !ok {
+		return fitness()
+	}
+	if entry, hit := c.get(key); hit {
+		return entry, nil
+	}
+
+	entry, err := fitness()
+	if err != nil {
+		return entry, err
+	}
+	entry.key = key
+	c.put(entry)
+	return entry, nil
+}
+
+// fitnessCacheKey builds the key of an evaluation. Either program may be nil for single sided fitness strategies.
+// The spec is identified by its backing array, specs are built once before the evolution starts and never modified
+// in place. It fails if a program has no tree.
+func fitnessCacheKey(strategy string, spec SpecMulti, antagonist, protagonist *Program) (string, bool) {
+	if len(spec) < 1 {
+		return "", false
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(strategy)
+	sb.WriteString(fmt.Sprintf("|%p/%d|", &spec[0], len(spec)))
+	for _, program := range []*Program{antagonist, protagonist} {
+		if program != nil {
+			if program.T == nil {
+				return "", false
+			}
+			canonical, err := program.T.CanonicalString()
+			if err != nil {
+				return "", false
+			}
+			sb.WriteString(canonical)
+		}
+		sb.WriteString("|")
+	}
+	return sb.String(), true
+}
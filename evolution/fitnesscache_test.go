@@ -0,0 +1,174 @@
+package evolution
+
+import (
+	"sync"
+	"testing"
+)
+
+func fitnessCacheTestSpec() SpecMulti {
+	spec := make(SpecMulti, 4)
+	for i := range spec {
+		x := float64(i + 1)
+		spec[i] = EquationPairing{
+			Independents:         IndependentVariableMap{"x": x},
+			Dependent:            x * x,
+			AntagonistThreshold:  4,
+			ProtagonistThreshold: 1,
+			DivideByZeroPenalty:  -1,
+		}
+	}
+	return spec
+}
+
+var thresholdedRatio = FitnessFunctionFunc(ThresholdedRatioFitness)
+
+func fitnessCacheTestProgram(t *testing.T, expression string) *Program {
+	tree, err := ParseExpression(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Program{T: tree}
+}
+
+func TestFitnessCache_Evaluate(t *testing.T) {
+	spec := fitnessCacheTestSpec()
+	cache := NewFitnessCache(10)
+	pairs := []struct {
+		antagonist  string
+		protagonist string
+		wantHit     bool
+	}{
+		{"x+4", "x*x", false},
+		{"x+4", "x*x", true},
+		{"4+x", "x*x", true},
+		{"x+4", "x*x+1", false},
+		{"x-4", "x*x", false},
+		{"4-x", "x*x", false},
+	}
+	for _, pair := range pairs {
+		antagonist := fitnessCacheTestProgram(t, pair.antagonist)
+		protagonist := fitnessCacheTestProgram(t, pair.protagonist)
+
+		wantA, wantP, wantADelta, wantPDelta, err := ThresholdedRatioFitness(spec, antagonist, protagonist,
+			DivByZeroSteadyPenalize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hits, _ := cache.Stats()
+		gotA, gotP, gotADelta, gotPDelta, err := cache.Evaluate(FitnessDualThresholdedRatio, thresholdedRatio, spec,
+			antagonist, protagonist, DivByZeroSteadyPenalize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotA != wantA || gotP != wantP || gotADelta != wantADelta || gotPDelta != wantPDelta {
+			t.Errorf("FitnessCache.Evaluate(%s, %s) = %v %v %v %v, want %v %v %v %v",
+				pair.antagonist, pair.protagonist, gotA, gotP, gotADelta, gotPDelta, wantA, wantP, wantADelta,
+				wantPDelta)
+		}
+		newHits, _ := cache.Stats()
+		if (newHits > hits) != pair.wantHit {
+			t.Errorf("FitnessCache.Evaluate(%s, %s) hit = %v, want %v", pair.antagonist,
+				pair.protagonist, newHits > hits, pair.wantHit)
+		}
+	}
+
+	hits, misses := cache.Stats()
+	if hits != 2 || misses != 4 {
+		t.Errorf("FitnessCache.Stats() = %d hits %d misses, want 2 hits 4 misses", hits, misses)
+	}
+}
+
+func TestFitnessCache_SpecIdentity(t *testing.T) {
+	cache := NewFitnessCache(10)
+	antagonist := fitnessCacheTestProgram(t, "x+4")
+	protagonist := fitnessCacheTestProgram(t, "x*x")
+
+	for _, spec := range []SpecMulti{fitnessCacheTestSpec(), fitnessCacheTestSpec()} {
+		if _, _, _, _, err := cache.Evaluate(FitnessDualThresholdedRatio, thresholdedRatio, spec, antagonist,
+			protagonist, DivByZeroSteadyPenalize); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits, misses := cache.Stats(); hits != 0 || misses != 2 {
+		t.Errorf("FitnessCache.Stats() = %d hits %d misses, want 0 hits 2 misses", hits, misses)
+	}
+}
+
+func TestFitnessCache_Bounded(t *testing.T) {
+	spec := fitnessCacheTestSpec()
+	cache := NewFitnessCache(2)
+	protagonist := fitnessCacheTestProgram(t, "x*x")
+	for _, expression := range []string{"x", "x+1", "x+2", "x"} {
+		if _, _, _, _, err := cache.Evaluate(FitnessDualThresholdedRatio, thresholdedRatio, spec,
+			fitnessCacheTestProgram(t, expression), protagonist, DivByZeroSteadyPenalize); err != nil {
+			t.Fatal(err)
+		}
+		if cache.Len() > 2 {
+			t.Fatalf("FitnessCache.Len() = %d, want at most 2", cache.Len())
+		}
+	}
+	// x was the least recently used entry when x+2 was added so it must have been evicted.
+	if hits, misses := cache.Stats(); hits != 0 || misses != 4 {
+		t.Errorf("FitnessCache.Stats() = %d hits %d misses, want 0 hits 4 misses", hits, misses)
+	}
+}
+
+func TestFitnessCache_Concurrent(t *testing.T) {
+	spec := fitnessCacheTestSpec()
+	cache := NewFitnessCache(4)
+	expressions := []string{"x", "x+1", "x*x", "x*2", "x-3", "x/2"}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, expression := range expressions {
+				antagonist := &Program{T: mustParseExpression(expression)}
+				protagonist := &Program{T: mustParseExpression("x*x")}
+				if _, _, _, _, err := cache.Evaluate(FitnessDualThresholdedRatio, thresholdedRatio, spec, antagonist,
+					protagonist, DivByZeroSteadyPenalize); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	hits, misses := cache.Stats()
+	if hits+misses != 8*len(expressions) {
+		t.Errorf("FitnessCache.Stats() = %d lookups, want %d", hits+misses, 8*len(expressions))
+	}
+	if cache.Len() > 4 {
+		t.Errorf("FitnessCache.Len() = %d, want at most 4", cache.Len())
+	}
+}
+
+func TestFitnessCache_Nil(t *testing.T) {
+	var cache *FitnessCache
+	spec := fitnessCacheTestSpec()
+	antagonist := fitnessCacheTestProgram(t, "x+4")
+	protagonist := fitnessCacheTestProgram(t, "x*x")
+
+	wantA, wantP, _, _, _ := ThresholdedRatioFitness(spec, antagonist, protagonist, DivByZeroSteadyPenalize)
+	gotA, gotP, _, _, err := cache.Evaluate(FitnessDualThresholdedRatio, thresholdedRatio, spec, antagonist,
+		protagonist, DivByZeroSteadyPenalize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotA != wantA || gotP != wantP {
+		t.Errorf("FitnessCache.Evaluate() on a nil cache = %v %v, want %v %v", gotA, gotP, wantA,
+			wantP)
+	}
+	if hits, misses := cache.Stats(); hits != 0 || misses != 0 {
+		t.Errorf("FitnessCache.Stats() on a nil cache = %d %d, want 0 0", hits, misses)
+	}
+}
+
+func mustParseExpression(expression string) *DualTree {
+	tree, err := ParseExpression(expression)
+	if err != nil {
+		panic(err)
+	}
+	return tree
+}
@@ -0,0 +1,311 @@
+package evolution
+
+import (
+	"fmt"
+	"math"
+)
+
+// FitnessFunction scores an antagonist and a protagonist that competed against each other on the spec.
+// Fitness values lie in [-1, 1] where greater is better, the deltas are the error of each program against the spec.
+// An individual that cannot be evaluated according to the divByZeroStrategy receives the spec's DivideByZeroPenalty
+// as its fitness and a NaN delta.
+type FitnessFunction interface {
+	Evaluate(spec SpecMulti, antagonist, protagonist *Program, divByZeroStrategy string) (antagonistFitness,
+		protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta float64, err error)
+}
+
+// FitnessFunctionFunc allows an ordinary function to be used as a FitnessFunction.
+type FitnessFunctionFunc func(spec SpecMulti, antagonist, protagonist *Program, divByZeroStrategy string) (
+	antagonistFitness, protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta float64, err error)
+
+func (f FitnessFunctionFunc) Evaluate(spec SpecMulti, antagonist, protagonist *Program,
+	divByZeroStrategy string) (antagonistFitness, protagonistFitness, antagonistFitnessDelta,
+	protagonistFitnessDelta float64, err error) {
+	return f(spec, antagonist, protagonist, divByZeroStrategy)
+}
+
+var fitnessFunctions = newRegistry[string, FitnessFunction]("fitness function", "RegisterFitnessFunction",
+	"FitnessFunctionByName")
+
+func init() {
+	fitnessFunctions.registerBuiltIn(map[string]FitnessFunction{
+		FitnessMonoThresholdedRatio:       FitnessFunctionFunc(ThresholdedRatioFitness),
+		FitnessDualThresholdedRatio:       FitnessFunctionFunc(ThresholdedRatioFitness),
+		FitnessAbsolute:                   FitnessFunctionFunc(AbsoluteFitness),
+		FitnessRatio:                      FitnessFunctionFunc(RatioFitness),
+		FitnessProtagonistThresholdTally:  FitnessFunctionFunc(ProtagonistThresholdTallyFitness),
+		FitnessThresholdedAntagonistRatio: FitnessFunctionFunc(ThresholdedAntagonistRatioFitness),
+	})
+}
+
+// RegisterFitnessFunction makes a fitness function available to FitnessStrategy.Type under the given name.
+// It returns an error if the name is empty or already taken.
+func RegisterFitnessFunction(name string, fitnessFunction FitnessFunction) error {
+	return fitnessFunctions.register(name, fitnessFunction)
+}
+
+// FitnessFunctionByName returns the registered fitness function with the given name.
+func FitnessFunctionByName(name string) (FitnessFunction, error) {
+	return fitnessFunctions.byName(name)
+}
+
+// RegisteredFitnessFunctions returns the sorted names of all registered fitness functions.
+func RegisteredFitnessFunctions() []string {
+	return fitnessFunctions.names()
+}
+
+// EvaluateFitness scores the antagonist against the protagonist on params.Spec using the fitness function selected by
+// FitnessStrategy.Type. The result is served from the fitness cache if it is enabled.
+func (params *EvolutionParams) EvaluateFitness(antagonist, protagonist *Program) (antagonistFitness,
+	protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta float64, err error) {
+	fitnessFunction := params.fitnessFunction
+	if fitnessFunction == nil {
+		fitnessFunction, err = FitnessFunctionByName(params.FitnessStrategy.Type)
+		if err != nil {
+			return math.MaxInt64, math.MaxInt64, math.MaxInt64, math.MaxInt64, err
+		}
+	}
+
+	return params.fitnessCache.Evaluate(params.FitnessStrategy.Type, fitnessFunction, params.Spec, antagonist,
+		protagonist, params.SpecParam.DivideByZeroStrategy)
+}
+
+// EvaluateCaseDeltas returns the error of the program at every EquationPairing of params.Spec. The errors only depend
+// on the program and are served from the fitness cache if it is enabled.
+func (params *EvolutionParams) EvaluateCaseDeltas(program *Program) ([]float64, error) {
+	entry, err := params.fitnessCache.evaluate(fitnessCacheCases+params.SpecParam.DivideByZeroStrategy, params.Spec,
+		nil, program, func() (entry fitnessCacheEntry, err error) {
+			entry.caseDeltas, err = ProgramCaseDeltas(params.Spec, program, params.SpecParam.DivideByZeroStrategy)
+			return entry, err
+		})
+	if err != nil {
+		return nil, err
+	}
+	caseDeltas := make([]float64, len(entry.caseDeltas))
+	copy(caseDeltas, entry.caseDeltas)
+	return caseDeltas, nil
+}
+
+// recordCaseDeltas appends the case deltas of the program to the CaseDeltas of every recipient.
+func (params *EvolutionParams) recordCaseDeltas(program *Program, recipients ...*Individual) error {
+	caseDeltas, err := params.EvaluateCaseDeltas(program)
+	if err != nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		recipient.CaseDeltas = append(recipient.CaseDeltas, caseDeltas)
+	}
+	return nil
+}
+
+// ProgramCaseDeltas returns the absolute error of the program at each EquationPairing of the spec instead of
+// aggregating them into a single delta. Points that could not be evaluated but were tolerated by the
+// divByZeroStrategy have an error of 0. If the program cannot be evaluated according to the divByZeroStrategy every
+// case is NaN.
+func ProgramCaseDeltas(spec SpecMulti, program *Program, divByZeroStrategy string) ([]float64, error) {
+	if len(spec) < 1 {
+		return nil, fmt.Errorf("ProgramCaseDeltas | spec cannot be empty")
+	}
+	if program == nil || program.T == nil {
+		return nil, fmt.Errorf("ProgramCaseDeltas | program cannot be nil")
+	}
+	evaluator, err := program.Compile()
+	if err != nil {
+		return nil, err
+	}
+
+	errors := programErrors(spec, evaluator.EvaluateWithVar, divByZeroStrategy)
+	if !errors.valid {
+		for i := range errors.residuals {
+			errors.residuals[i] = math.NaN()
+		}
+	}
+	return errors.residuals, nil
+}
+
+// AbsoluteFitness scores each program on its own error against the spec, ignoring both the thresholds and the
+// opponent. The protagonist receives 1/(1+error) and the antagonist error/(1+error) so both approach 1 as
+// they fulfil their goal.
+func AbsoluteFitness(spec SpecMulti, antagonist, protagonist *Program, divByZeroStrategy string) (antagonistFitness,
+	protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta float64, err error) {
+	antagonistErrors, protagonistErrors, err := competitorErrors(spec, antagonist, protagonist, divByZeroStrategy)
+	if err != nil {
+		return math.MaxInt64, math.MaxInt64, math.MaxInt64, math.MaxInt64, err
+	}
+
+	antagonistFitness, antagonistFitnessDelta = antagonistErrors.score(spec, func(delta float64) float64 {
+		return delta / (1 + delta)
+	})
+	protagonistFitness, protagonistFitnessDelta = protagonistErrors.score(spec, func(delta float64) float64 {
+		return 1 / (1 + delta)
+	})
+	return antagonistFitness, protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta, nil
+}
+
+// RatioFitness compares the error of both programs directly. The protagonist receives (Ea - Ep) / (Ea + Ep) and the
+// antagonist the negation of it, so the fitness of one is exactly the loss of the other. If either program is
+// invalid the other receives the best possible fitness.
+func RatioFitness(spec SpecMulti, antagonist, protagonist *Program, divByZeroStrategy string) (antagonistFitness,
+	protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta float64, err error) {
+	antagonistErrors, protagonistErrors, err := competitorErrors(spec, antagonist, protagonist, divByZeroStrategy)
+	if err != nil {
+		return math.MaxInt64, math.MaxInt64, math.MaxInt64, math.MaxInt64, err
+	}
+
+	deltaAntagonist := spec.aggregateError(antagonistErrors.residuals)
+	deltaProtagonist := spec.aggregateError(protagonistErrors.residuals)
+	ratio := 0.0
+	if deltaAntagonist+deltaProtagonist > 0 {
+		ratio = (deltaAntagonist - deltaProtagonist) / (deltaAntagonist + deltaProtagonist)
+	}
+
+	antagonistFitness, antagonistFitnessDelta = antagonistErrors.score(spec, func(float64) float64 {
+		if !protagonistErrors.valid {
+			return 1
+		}
+		return -ratio
+	})
+	protagonistFitness, protagonistFitnessDelta = protagonistErrors.score(spec, func(float64) float64 {
+		if !antagonistErrors.valid {
+			return 1
+		}
+		return ratio
+	})
+	return antagonistFitness, protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta, nil
+}
+
+// ProtagonistThresholdTallyFitness counts the spec points each program lands on the correct side of its threshold.
+// A protagonist point counts if its error is within the ProtagonistThreshold, an antagonist point counts if its error
+// is beyond the AntagonistThreshold. The tallied fraction is scaled to [-1, 1].
+func ProtagonistThresholdTallyFitness(spec SpecMulti, antagonist, protagonist *Program,
+	divByZeroStrategy string) (antagonistFitness, protagonistFitness, antagonistFitnessDelta,
+	protagonistFitnessDelta float64, err error) {
+	antagonistErrors, protagonistErrors, err := competitorErrors(spec, antagonist, protagonist, divByZeroStrategy)
+	if err != nil {
+		return math.MaxInt64, math.MaxInt64, math.MaxInt64, math.MaxInt64, err
+	}
+
+	antagonistFitness, antagonistFitnessDelta = antagonistErrors.score(spec, func(float64) float64 {
+		return antagonistErrors.tally(func(i int, residual float64) bool {
+			return residual >= math.Abs(spec[i].AntagonistThreshold)
+		})
+	})
+	protagonistFitness, protagonistFitnessDelta = protagonistErrors.score(spec, func(float64) float64 {
+		return protagonistErrors.tally(func(i int, residual float64) bool {
+			return residual <= math.Abs(spec[i].ProtagonistThreshold)
+		})
+	})
+	return antagonistFitness, protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta, nil
+}
+
+// ThresholdedAntagonistRatioFitness only thresholds the antagonist. The antagonist is scored against its threshold
+// as in ThresholdedRatioFitness, the protagonist is scored relative to the error of the antagonist it faced,
+// reaching 1 when it matches the spec and -1 when it does far worse than the antagonist.
+func ThresholdedAntagonistRatioFitness(spec SpecMulti, antagonist, protagonist *Program,
+	divByZeroStrategy string) (antagonistFitness, protagonistFitness, antagonistFitnessDelta,
+	protagonistFitnessDelta float64, err error) {
+	antagonistErrors, protagonistErrors, err := competitorErrors(spec, antagonist, protagonist, divByZeroStrategy)
+	if err != nil {
+		return math.MaxInt64, math.MaxInt64, math.MaxInt64, math.MaxInt64, err
+	}
+
+	deltaAntagonist := spec.aggregateError(antagonistErrors.residuals)
+	antagonistFitness, antagonistFitnessDelta = antagonistErrors.score(spec, func(float64) float64 {
+		threshold := spec.antagonistThresholdError()
+		switch {
+		case deltaAntagonist >= threshold && deltaAntagonist == 0:
+			return -1
+		case deltaAntagonist >= threshold:
+			return (deltaAntagonist - threshold) / deltaAntagonist
+		default:
+			return -1 * ((threshold - deltaAntagonist) / threshold)
+		}
+	})
+	protagonistFitness, protagonistFitnessDelta = protagonistErrors.score(spec, func(deltaProtagonist float64) float64 {
+		switch {
+		case deltaProtagonist == 0 || !antagonistErrors.valid:
+			return 1
+		case deltaProtagonist <= deltaAntagonist:
+			return (deltaAntagonist - deltaProtagonist) / deltaAntagonist
+		default:
+			return -1 * ((deltaProtagonist - deltaAntagonist) / deltaProtagonist)
+		}
+	})
+	return antagonistFitness, protagonistFitness, antagonistFitnessDelta, protagonistFitnessDelta, nil
+}
+
+// specErrors holds the absolute error of a program at every point of the spec. Points that could not be evaluated
+// but were tolerated by the divByZeroStrategy have an error of 0, as in ThresholdedRatioFitness.
+type specErrors struct {
+	residuals          []float64
+	valid              bool
+	dividedByZeroCount int
+}
+
+func competitorErrors(spec SpecMulti, antagonist, protagonist *Program, divByZeroStrategy string) (antagonistErrors,
+	protagonistErrors specErrors, err error) {
+	err = fitnessParameterValidator(spec, antagonist, protagonist)
+	if err != nil {
+		return specErrors{}, specErrors{}, err
+	}
+	antagonistEvaluator, protagonistEvaluator, err := compilePrograms(antagonist, protagonist)
+	if err != nil {
+		return specErrors{}, specErrors{}, err
+	}
+
+	antagonistErrors = programErrors(spec, antagonistEvaluator.EvaluateWithVar, divByZeroStrategy)
+	protagonistErrors = programErrors(spec, protagonistEvaluator.EvaluateWithVar, divByZeroStrategy)
+	return antagonistErrors, protagonistErrors, nil
+}
+
+func programErrors(spec SpecMulti, evaluate func(map[string]float64) (float64, error),
+	divByZeroStrategy string) specErrors {
+	errors := specErrors{residuals: make([]float64, len(spec)), valid: true}
+	for i := range spec {
+		value, err := evaluate(spec[i].Independents)
+		if err == nil {
+			errors.residuals[i] = math.Abs(spec[i].Dependent - value)
+			continue
+		}
+
+		switch divByZeroStrategy {
+		case DivByZeroPenalize:
+			errors.valid = false
+			return errors
+		case DivByZeroSteadyPenalize:
+			if !spec[i].Independents.HasZero() && (math.IsNaN(value) || value == 0) {
+				errors.valid = false
+				return errors
+			}
+			errors.dividedByZeroCount++
+		}
+	}
+	return errors
+}
+
+// score returns the fitness and delta of a program, fitness is called with the error of the program aggregated with
+// the error metric of the spec. Invalid programs receive the DivideByZeroPenalty and a NaN delta,
+// positive fitness is reduced by 10% for every tolerated division by zero.
+func (errors specErrors) score(spec SpecMulti, fitness func(delta float64) float64) (float64, float64) {
+	if !errors.valid {
+		return spec[0].DivideByZeroPenalty, math.NaN()
+	}
+
+	delta := spec.aggregateError(errors.residuals)
+	value := fitness(delta)
+	if errors.dividedByZeroCount > 0 && value > 0 {
+		value = value - (value * 0.1 * float64(errors.dividedByZeroCount))
+	}
+	return value, delta
+}
+
+// tally returns the fraction of points that satisfy counts scaled to [-1, 1].
+func (errors specErrors) tally(counts func(i int, residual float64) bool) float64 {
+	count := 0
+	for i, residual := range errors.residuals {
+		if counts(i, residual) {
+			count++
+		}
+	}
+	return 2*float64(count)/float64(len(errors.residuals)) - 1
+}
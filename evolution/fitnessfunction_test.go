@@ -0,0 +1,136 @@
+package evolution
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitnessFunctions(t *testing.T) {
+	rmseX := math.Sqrt(46) // RMSE of x against x*x over the fitnessCacheTestSpec
+	tests := []struct {
+		name            string
+		fitnessFunction FitnessFunction
+		antagonist      string
+		protagonist     string
+		wantA           float64
+		wantP           float64
+		wantADelta      float64
+		wantPDelta      float64
+	}{
+		{"absolute-perfect", FitnessFunctionFunc(AbsoluteFitness), "x*x+5", "x*x", 5.0 / 6, 1, 5, 0},
+		{"absolute", FitnessFunctionFunc(AbsoluteFitness), "x", "x*x+2", rmseX / (1 + rmseX), 1.0 / 3, rmseX, 2},
+		{"ratio-perfect", FitnessFunctionFunc(RatioFitness), "x*x+5", "x*x", -1, 1, 5, 0},
+		{"ratio", FitnessFunctionFunc(RatioFitness), "x", "x*x+2", -(rmseX - 2) / (rmseX + 2),
+			(rmseX - 2) / (rmseX + 2), rmseX, 2},
+		{"ratio-equal", FitnessFunctionFunc(RatioFitness), "x*x", "x*x", 0, 0, 0, 0},
+		{"tally-perfect", FitnessFunctionFunc(ProtagonistThresholdTallyFitness), "x*x+5", "x*x", 1, 1, 5, 0},
+		{"tally", FitnessFunctionFunc(ProtagonistThresholdTallyFitness), "x", "x*x+2", 0, -1, rmseX, 2},
+		{"thresholded-antagonist-ratio-perfect", FitnessFunctionFunc(ThresholdedAntagonistRatioFitness), "x*x+5",
+			"x*x", 0.2, 1, 5, 0},
+		{"thresholded-antagonist-ratio", FitnessFunctionFunc(ThresholdedAntagonistRatioFitness), "x", "x*x+2",
+			(rmseX - 4) / rmseX, (rmseX - 2) / rmseX, rmseX, 2},
+		{"thresholded-antagonist-ratio-worse-protagonist", FitnessFunctionFunc(ThresholdedAntagonistRatioFitness),
+			"x*x+2", "x*x+8", -0.5, -0.75, 2, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotA, gotP, gotADelta, gotPDelta, err := tt.fitnessFunction.Evaluate(fitnessCacheTestSpec(),
+				fitnessCacheTestProgram(t, tt.antagonist), fitnessCacheTestProgram(t, tt.protagonist),
+				DivByZeroSteadyPenalize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, got := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"antagonistFitness", gotA, tt.wantA},
+				{"protagonistFitness", gotP, tt.wantP},
+				{"antagonistFitnessDelta", gotADelta, tt.wantADelta},
+				{"protagonistFitnessDelta", gotPDelta, tt.wantPDelta},
+			} {
+				if math.Abs(got.got-got.want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", got.name, got.got, got.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFitnessFunctions_DivByZeroPenalize(t *testing.T) {
+	for _, name := range []string{FitnessAbsolute, FitnessRatio, FitnessProtagonistThresholdTally,
+		FitnessThresholdedAntagonistRatio} {
+		t.Run(name, func(t *testing.T) {
+			fitnessFunction, err := FitnessFunctionByName(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotA, gotP, _, gotPDelta, err := fitnessFunction.Evaluate(fitnessCacheTestSpec(),
+				fitnessCacheTestProgram(t, "x"), fitnessCacheTestProgram(t, "x/(x-1)"), DivByZeroPenalize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotP != -1 || !math.IsNaN(gotPDelta) {
+				t.Errorf("protagonist = %v %v, want the DivideByZeroPenalty and NaN", gotP, gotPDelta)
+			}
+			if gotA < -1 || gotA > 1 {
+				t.Errorf("antagonistFitness = %v, want a value in [-1, 1]", gotA)
+			}
+		})
+	}
+}
+
+func TestRegisterFitnessFunction(t *testing.T) {
+	for _, name := range []string{FitnessAbsolute, FitnessRatio, FitnessProtagonistThresholdTally,
+		FitnessThresholdedAntagonistRatio, FitnessMonoThresholdedRatio, FitnessDualThresholdedRatio} {
+		if _, err := FitnessFunctionByName(name); err != nil {
+			t.Errorf("FitnessFunctionByName(%s) error = %v", name, err)
+		}
+	}
+	if _, err := FitnessFunctionByName("FitnessUnknown"); err == nil {
+		t.Errorf("FitnessFunctionByName(FitnessUnknown) should return an error")
+	}
+
+	constant := FitnessFunctionFunc(func(spec SpecMulti, antagonist, protagonist *Program,
+		divByZeroStrategy string) (float64, float64, float64, float64, error) {
+		return 0.25, 0.5, 1, 2, nil
+	})
+	tests := []struct {
+		name            string
+		fitnessFunction FitnessFunction
+		wantErr         bool
+	}{
+		{"", constant, true},
+		{"FitnessTestConstant", nil, true},
+		{FitnessDualThresholdedRatio, constant, true},
+		{"FitnessTestConstant", constant, false},
+		{"FitnessTestConstant", constant, true},
+	}
+	for _, tt := range tests {
+		if err := RegisterFitnessFunction(tt.name, tt.fitnessFunction); (err != nil) != tt.wantErr {
+			t.Errorf("RegisterFitnessFunction(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	found := false
+	for _, name := range RegisteredFitnessFunctions() {
+		found = found || name == "FitnessTestConstant"
+	}
+	if !found {
+		t.Errorf("RegisteredFitnessFunctions() does not contain FitnessTestConstant")
+	}
+
+	params := EvolutionParams{
+		Spec:            fitnessCacheTestSpec(),
+		FitnessStrategy: FitnessStrategy{Type: "FitnessTestConstant"},
+	}
+	gotA, gotP, gotADelta, gotPDelta, err := params.EvaluateFitness(fitnessCacheTestProgram(t, "x"),
+		fitnessCacheTestProgram(t, "x*x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotA != 0.25 || gotP != 0.5 || gotADelta != 1 || gotPDelta != 2 {
+		t.Errorf("EvolutionParams.EvaluateFitness() = %v %v %v %v, want 0.25 0.5 1 2", gotA, gotP, gotADelta,
+			gotPDelta)
+	}
+}
@@ -41,6 +41,21 @@ type Generation struct {
 	ProtagonistSkew       float64
 	ProtagonistExKurtosis float64
 	ProtagonistAvgFitness []float64
+
+	// AntagonistUniquePhenotypes and ProtagonistUniquePhenotypes count the structurally distinct programs in the
+	// generation, see DualTree.CanonicalString.
+	AntagonistUniquePhenotypes  int
+	ProtagonistUniquePhenotypes int
+
+	// FitnessCacheHits and FitnessCacheMisses count the fitness cache lookups made during the generation.
+	// Both are 0 if EvolutionParams.EnableFitnessCache is not set.
+	FitnessCacheHits   int
+	FitnessCacheMisses int
+
+	// AntagonistMutationRate and ProtagonistMutationRate are the rates each population was mutated with when breeding
+	// the next generation, see MutationRate.
+	AntagonistMutationRate  float64
+	ProtagonistMutationRate float64
 }
 
 func (g *Generation) ToString() string {
@@ -56,12 +71,19 @@ func (g *Generation) ToString() string {
 	sb.WriteString(fmt.Sprintf("AntagonistVarianceInGeneration : %.2f\n", g.AntagonistVariance))
 	sb.WriteString(fmt.Sprintf("AntagonistSkewInGeneration : %.2f\n", g.AntagonistSkew))
 	sb.WriteString(fmt.Sprintf("AntagonistExKurtosisInGeneration : %.2f\n", g.AntagonistExKurtosis))
+	sb.WriteString(fmt.Sprintf("AntagonistUniquePhenotypesInGeneration : %d\n", g.AntagonistUniquePhenotypes))
+	sb.WriteString(fmt.Sprintf("AntagonistMutationRateInGeneration : %.3f\n", g.AntagonistMutationRate))
 	sb.WriteString("<===================================>\n")
 	sb.WriteString(fmt.Sprintf("ProtagonistAverageInGeneration : %.2f\n", g.ProtagonistAverage))
 	sb.WriteString(fmt.Sprintf("ProtagonistStdDevInGeneration : %.2f\n", g.ProtagonistStdDev))
 	sb.WriteString(fmt.Sprintf("ProtagonistVarianceInGeneration : %.2f\n", g.ProtagonistVariance))
 	sb.WriteString(fmt.Sprintf("ProtagonistSkewInGeneration : %.2f\n", g.ProtagonistSkew))
-	sb.WriteString(fmt.Sprintf("ProtagonistExKurtosisInGeneration : %.2f\n\n\n", g.ProtagonistExKurtosis))
+	sb.WriteString(fmt.Sprintf("ProtagonistExKurtosisInGeneration : %.2f\n", g.ProtagonistExKurtosis))
+	sb.WriteString(fmt.Sprintf("ProtagonistUniquePhenotypesInGeneration : %d\n", g.ProtagonistUniquePhenotypes))
+	sb.WriteString(fmt.Sprintf("ProtagonistMutationRateInGeneration : %.3f\n", g.ProtagonistMutationRate))
+	sb.WriteString("<===================================>\n")
+	sb.WriteString(fmt.Sprintf("FitnessCacheHitsInGeneration : %d\n", g.FitnessCacheHits))
+	sb.WriteString(fmt.Sprintf("FitnessCacheMissesInGeneration : %d\n\n\n", g.FitnessCacheMisses))
 
 	return sb.String()
 }
@@ -103,15 +125,7 @@ func (g *Generation) ApplySelection(antagonists, protagonists []*Individual, err
 	antagonistSurvivors []*Individual, protagonistSurvivors []*Individual) {
 	antSurvivorChan := make(chan []*Individual)
 	go func(g *Generation, antagonists []*Individual) {
-		antWinnerParents, err := g.ApplyParentSelection(antagonists)
-		if err != nil {
-			errorChan <- err
-		}
-		antSelectedParents, antSelectedChildren, err := g.ApplyReproduction(antWinnerParents, IndividualAntagonist)
-		if err != nil {
-			errorChan <- err
-		}
-		antSurvivors, err := g.ApplySurvivorSelection(antSelectedParents, antSelectedChildren)
+		antSurvivors, err := g.selectSurvivors(antagonists, IndividualAntagonist)
 		if err != nil {
 			errorChan <- err
 		}
@@ -121,15 +135,7 @@ func (g *Generation) ApplySelection(antagonists, protagonists []*Individual, err
 
 	proSurvivorChan := make(chan []*Individual)
 	go func(g *Generation, protagonists []*Individual) {
-		proWinnerParents, err := g.ApplyParentSelection(protagonists)
-		if err != nil {
-			errorChan <- err
-		}
-		proSelectedParents, proSelectedChildren, err := g.ApplyReproduction(proWinnerParents, IndividualProtagonist)
-		if err != nil {
-			errorChan <- err
-		}
-		proSurvivors, err := g.ApplySurvivorSelection(proSelectedParents, proSelectedChildren)
+		proSurvivors, err := g.selectSurvivors(protagonists, IndividualProtagonist)
 		if err != nil {
 			errorChan <- err
 		}
@@ -163,10 +169,45 @@ func (g *Generation) ApplySelection(antagonists, protagonists []*Individual, err
 	return antagonistSurvivors, protagonistSurvivors
 }
 
+// selectSurvivors applies parent selection, reproduction and survivor selection to the population. In ALPS mode each
+// age layer is selected separately, see selectLayeredSurvivors.
+func (g *Generation) selectSurvivors(population []*Individual, kind int) ([]*Individual, error) {
+	g.adaptMutationRate(population, kind)
+	if g.engine.Parameters.ALPS.IsEnabled() {
+		return g.selectLayeredSurvivors(population, kind)
+	}
+
+	winnerParents, err := g.ApplyParentSelection(population)
+	if err != nil {
+		return nil, err
+	}
+	selectedParents, selectedChildren, err := g.ApplyReproduction(winnerParents, kind)
+	if err != nil {
+		return nil, err
+	}
+	return g.ApplySurvivorSelection(selectedParents, selectedChildren)
+}
+
 func GenerateGenerationID(count int, topology string) string {
 	return fmt.Sprintf("GEN-%-s-%d", topology, count)
 }
 
+// NextGeneration returns the generation that follows g made up of the given antagonists and protagonists,
+// usually the survivors of ApplySelection. It is used by every ITopology to hand the next generation back to the
+// generation loop.
+func (g *Generation) NextGeneration(topology string, antagonists, protagonists []*Individual) *Generation {
+	return &Generation{
+		GenerationID:                 GenerateGenerationID(g.count+1, topology),
+		Protagonists:                 protagonists,
+		Antagonists:                  antagonists,
+		engine:                       g.engine,
+		isComplete:                   true,
+		hasParentSelectionHappened:   true,
+		hasSurvivorSelectionHappened: true,
+		count:                        g.count + 1,
+	}
+}
+
 func (g *Generation) CleansePopulations(params EvolutionParams) {
 	wg := sync.WaitGroup{}
 	wg.Add(2)
@@ -206,7 +247,68 @@ func (g *Generation) ApplyParentSelection(currentPopulation []*Individual) ([]*I
 		g.hasParentSelectionHappened = true
 		return selectedInvididuals, nil
 	case ParentSelectionElitism:
-		selectedInvididuals, err := Elitism(currentPopulation, true)
+		selectedInvididuals, err := Elitism(currentPopulation, g.engine.Parameters.Selection.Parent.EliteCount, true)
+		if err != nil {
+			return nil, err
+		}
+		g.hasParentSelectionHappened = true
+		return selectedInvididuals, nil
+	case ParentSelectionFitnessProportionate:
+		selectedInvididuals, err := FitnessProportionateSelection(currentPopulation)
+		if err != nil {
+			return nil, err
+		}
+		g.hasParentSelectionHappened = true
+		return selectedInvididuals, nil
+	case ParentSelectionStochasticUniversalSampling:
+		selectedInvididuals, err := StochasticUniversalSampling(currentPopulation)
+		if err != nil {
+			return nil, err
+		}
+		g.hasParentSelectionHappened = true
+		return selectedInvididuals, nil
+	case ParentSelectionLinearRank:
+		selectedInvididuals, err := LinearRankSelection(currentPopulation,
+			g.engine.Parameters.Selection.Parent.selectionPressure())
+		if err != nil {
+			return nil, err
+		}
+		g.hasParentSelectionHappened = true
+		return selectedInvididuals, nil
+	case ParentSelectionExponentialRank:
+		selectedInvididuals, err := ExponentialRankSelection(currentPopulation,
+			g.engine.Parameters.Selection.Parent.selectionPressure())
+		if err != nil {
+			return nil, err
+		}
+		g.hasParentSelectionHappened = true
+		return selectedInvididuals, nil
+	case ParentSelectionBoltzmann:
+		selectedInvididuals, err := BoltzmannSelection(currentPopulation,
+			BoltzmannTemperature(g.engine.Parameters.Selection.Parent, g.count))
+		if err != nil {
+			return nil, err
+		}
+		g.hasParentSelectionHappened = true
+		return selectedInvididuals, nil
+	case ParentSelectionTruncation:
+		selectedInvididuals, err := TruncationSelection(currentPopulation,
+			g.engine.Parameters.Selection.Parent.truncationRatio())
+		if err != nil {
+			return nil, err
+		}
+		g.hasParentSelectionHappened = true
+		return selectedInvididuals, nil
+	case ParentSelectionLexicase:
+		selectedInvididuals, err := LexicaseSelection(currentPopulation)
+		if err != nil {
+			return nil, err
+		}
+		g.hasParentSelectionHappened = true
+		return selectedInvididuals, nil
+	case ParentSelectionEpsilonLexicase:
+		selectedInvididuals, err := EpsilonLexicaseSelection(currentPopulation,
+			g.engine.Parameters.Selection.Parent.Epsilon)
 		if err != nil {
 			return nil, err
 		}
@@ -281,11 +383,28 @@ func (g *Generation) ApplyReproduction(incomingParents []*Individual, kind int)
 			children[i] = &child1
 			children[i+1] = &child2
 		}
+	case CrossoverCutAndSplice:
+		minLength, maxLength := g.engine.Parameters.Strategies.StrategyCountBounds(kind)
+		for i := 0; i < len(incomingParents); i += 2 {
+			child1, child2, err := CutAndSpliceCrossover(incomingParents[i], incomingParents[i+1], minLength,
+				maxLength)
+			if err != nil {
+				return nil, nil, err
+			}
+			child1.BirthGen = g.count
+			child2.BirthGen = g.count
+			child1.Age = 0
+			child2.Age = 0
+			children[i] = &child1
+			children[i+1] = &child2
+		}
 	default:
 		return nil, nil, fmt.Errorf("no appropriate FixedPointCrossover operation was selected")
 	}
 
-	return Mutate(incomingParents, children, kind, g.engine.Parameters)
+	params := g.engine.Parameters
+	params.Reproduction.ProbabilityOfMutation = g.mutationRate(kind)
+	return Mutate(incomingParents, children, kind, params)
 }
 
 // ApplySurvivorSelection applies the preselected survivor selection Strategy.
@@ -295,14 +414,28 @@ func (g *Generation) ApplyReproduction(incomingParents []*Individual, kind int)
 func (g *Generation) ApplySurvivorSelection(outgoingParents []*Individual,
 	children []*Individual) ([]*Individual, error) {
 
+	var survivors []*Individual
+	var err error
 	switch g.engine.Parameters.Selection.Survivor.Type {
 	case SurvivorSelectionFitnessBased:
-		return FitnessBasedSurvivorSelection(outgoingParents, children, g.engine.Parameters)
+		survivors, err = FitnessBasedSurvivorSelection(outgoingParents, children, g.engine.Parameters)
 	case SurvivorSelectionRandom:
-		return RandomSurvivorSelection(outgoingParents, children, g.engine.Parameters)
+		survivors, err = RandomSurvivorSelection(outgoingParents, children, g.engine.Parameters)
+	case SurvivorSelectionGenerational:
+		survivors, err = GenerationalSurvivorSelection(outgoingParents, children, g.engine.Parameters)
+	case SurvivorSelectionSteadyState:
+		survivors, err = SteadyStateSurvivorSelection(outgoingParents, children, g.engine.Parameters)
+	case SurvivorSelectionAgeBased:
+		survivors, err = AgeBasedSurvivorSelection(outgoingParents, children, g.engine.Parameters)
 	default:
 		return nil, fmt.Errorf("Invalid Survivor Selection Selected")
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	ageSurvivors(outgoingParents, survivors)
+	return survivors, nil
 }
 
 // GenerateRandom creates a a random set of individuals based on the parameters passed into the
@@ -340,12 +473,13 @@ func (g *Generation) GenerateRandomIndividuals(kind int, params EvolutionParams)
 
 		var randomStrategies []Strategy
 
+		availableStrategies, weights := params.Strategies.AvailableStrategies(kind)
 		if kind == IndividualAntagonist {
-			randomStrategies = GenerateRandomStrategy(params.Strategies.AntagonistStrategyCount,
-				params.Strategies.AntagonistAvailableStrategies)
+			randomStrategies = GenerateWeightedRandomStrategy(params.Strategies.AntagonistStrategyCount,
+				availableStrategies, weights)
 		} else if kind == IndividualProtagonist {
-			randomStrategies = GenerateRandomStrategy(params.Strategies.ProtagonistStrategyCount,
-				params.Strategies.ProtagonistAvailableStrategies)
+			randomStrategies = GenerateWeightedRandomStrategy(params.Strategies.ProtagonistStrategyCount,
+				availableStrategies, weights)
 		}
 
 		id := fmt.Sprintf("%s-%d", KindToString(kind), i)
@@ -19,6 +19,7 @@ type Individual struct {
 	Strategy                 []Strategy
 	Fitness                  []float64
 	Deltas                   []float64
+	CaseDeltas               [][]float64 // The error at every point of the spec, one entry per competition
 	FitnessVariance          float64
 	FitnessStdDev            float64
 	HasAppliedStrategy       bool
@@ -32,10 +33,19 @@ type Individual struct {
 	BestDelta                float64
 	AverageDelta             float64
 	NoOfCompetitions         int
+	MutationRate             float64 // The individual's own mutation rate when using MutationRateSelfAdaptive
+	// StrategyTraces holds a StrategyTrace for every epoch the individual competed in if Strategies.EnableTrace is
+	// set.
+	StrategyTraces []StrategyTrace
 	Mutex sync.Mutex
 	// BirthGen represents the generation where this individual was spawned
 
 	Program *Program // The best program generated
+
+	// isMutated is set if the individual was mutated when it was last bred, mutationBaseline is then the fitness it
+	// has to beat for the mutation to count as a success, see OneFifthSuccessRule.
+	isMutated        bool
+	mutationBaseline float64
 }
 
 func (individual Individual) Clone() (Individual, error) {
@@ -46,6 +56,10 @@ func (individual Individual) Clone() (Individual, error) {
 		}
 		individual.Program = &programClone
 	}
+	individual.Strategy = cloneStrategy(individual.Strategy)
+	// Appending to the StrategyTraces of the clone must not overwrite those appended to the original
+	traces := individual.StrategyTraces
+	individual.StrategyTraces = traces[:len(traces):len(traces)]
 	return individual, nil
 }
 
@@ -62,11 +76,13 @@ func (individual Individual) CloneCleanse() (Individual, error) {
 	individual.Id += "**"
 	individual.Fitness = nil
 	individual.Deltas = nil
+	individual.CaseDeltas = nil
 	individual.AverageFitness = 0
 	individual.AverageDelta = 0
 	individual.Program = &Program{}
 	individual.FitnessStdDev = 0
 	individual.FitnessVariance = 0
+	individual.StrategyTraces = nil
 
 	return individual, nil
 }
@@ -87,14 +103,8 @@ func (individual *Individual) ApplyAntagonistStrategy(params EvolutionParams) er
 		return err
 	}
 	individual.Program = &program
-	for _, strategy := range individual.Strategy {
-		err := individual.Program.ApplyStrategy(strategy,
-			params.SpecParam.AvailableSymbolicExpressions.Terminals,
-			params.SpecParam.AvailableSymbolicExpressions.NonTerminals,
-			params.Strategies.DepthOfRandomNewTrees)
-		if err != nil {
-			return err
-		}
+	if err := individual.applyStrategies(params); err != nil {
+		return err
 	}
 	individual.HasAppliedStrategy = true
 	if individual.Parent != nil {
@@ -127,14 +137,8 @@ func (individual *Individual) ApplyProtagonistStrategy(antagonistTree DualTree,
 	}
 	individual.Program.T = &tree
 
-	for _, strategy := range individual.Strategy {
-		err := individual.Program.ApplyStrategy(strategy,
-			params.SpecParam.AvailableSymbolicExpressions.Terminals,
-			params.SpecParam.AvailableSymbolicExpressions.NonTerminals,
-			params.Strategies.DepthOfRandomNewTrees)
-		if err != nil {
-			return err
-		}
+	if err := individual.applyStrategies(params); err != nil {
+		return err
 	}
 	individual.HasAppliedStrategy = true
 	individual.HasAppliedStrategy = true
@@ -151,9 +155,21 @@ func (individual Individual) CloneWithTree(tree DualTree) Individual {
 
 	programClone := individual.Program.CloneWithTree(tree)
 	individual.Program = &programClone
+	individual.Strategy = cloneStrategy(individual.Strategy)
 	return individual
 }
 
+// cloneStrategy copies a Strategy so that a clone gets its own genes, crossover and mutation change the genes of
+// an individual in place.
+func cloneStrategy(strategy []Strategy) []Strategy {
+	if strategy == nil {
+		return nil
+	}
+	clone := make([]Strategy, len(strategy))
+	copy(clone, strategy)
+	return clone
+}
+
 type Antagonist Individual
 type Protagonist Individual
 
@@ -187,6 +203,8 @@ func (individual *Individual) ToString() strings.Builder {
 	return sb
 }
 
+// CalculateProtagonistThresholdedFitness scores the protagonist against the spec on its own.
+// The result is served from the fitness cache if it is enabled.
 func (individual *Individual) CalculateProtagonistThresholdedFitness(params EvolutionParams) (
 	protagonistFitness float64,
 	delta float64, err error) {
@@ -199,26 +217,34 @@ func (individual *Individual) CalculateProtagonistThresholdedFitness(params Evol
 			" antagonist")
 	}
 
+	entry, err := params.fitnessCache.evaluate(fitnessCacheProtagonist+params.SpecParam.DivideByZeroStrategy,
+		params.Spec, nil, individual.Program, func() (fitnessCacheEntry, error) {
+			fitness, delta, err := individual.protagonistThresholdedFitness(params)
+			return fitnessCacheEntry{protagonistFitness: fitness, protagonistDelta: delta}, err
+		})
+	return entry.protagonistFitness, entry.protagonistDelta, err
+}
+
+func (individual *Individual) protagonistThresholdedFitness(params EvolutionParams) (protagonistFitness float64,
+	delta float64, err error) {
 	fitnessPenalization := params.Spec[0].DivideByZeroPenalty
 	badDeltaValue := math.NaN()
 	divByZeroStrategy := params.SpecParam.DivideByZeroStrategy
 
-	protagonistExpression, err := individual.Program.T.ToMathematicalString()
+	protagonistEvaluator, err := individual.Program.Compile()
 	if err != nil {
 		return 0, 0, err
 	}
 
-	deltaProtagonist := 0.0
-	deltaProtagonistThreshold := 0.0
+	protagonistResiduals := make([]float64, len(params.Spec))
 	protagonistDividedByZeroCount := 0
 	isProtagonistValid := true
 	spec := params.Spec
 
 	for i := range spec {
 		independentX := spec[i].Independents
-		independentXVal := spec[i].Independents["x"]
 		if isProtagonistValid {
-			dependentProtagonistVar, err := individual.Program.EvalMulti(independentX, protagonistExpression)
+			dependentProtagonistVar, err := protagonistEvaluator.EvaluateWithVar(independentX)
 			if err != nil {
 				switch divByZeroStrategy {
 				case DivByZeroIgnore:
@@ -232,7 +258,7 @@ func (individual *Individual) CalculateProtagonistThresholdedFitness(params Evol
 					dependentProtagonistVar = 0
 
 				case DivByZeroSteadyPenalize:
-					if independentXVal != 0 {
+					if !independentX.HasZero() {
 						if math.IsNaN(dependentProtagonistVar) || dependentProtagonistVar == 0 {
 							isProtagonistValid = false
 							protagonistFitness = fitnessPenalization
@@ -245,17 +271,13 @@ func (individual *Individual) CalculateProtagonistThresholdedFitness(params Evol
 					}
 				}
 			} else {
-				diff := spec[i].Dependent - dependentProtagonistVar
-				deltaProtagonist += diff * diff
+				protagonistResiduals[i] = spec[i].Dependent - dependentProtagonistVar
 			}
 		}
-		deltaProtagonistThreshold += spec[i].ProtagonistThreshold * spec[i].ProtagonistThreshold
 	}
 
-	specLen := float64(len(spec))
-
-	deltaProtagonist = math.Sqrt(deltaProtagonist / specLen)
-	deltaProtagonistThreshold = math.Sqrt(deltaProtagonistThreshold / specLen)
+	deltaProtagonist := spec.aggregateError(protagonistResiduals)
+	deltaProtagonistThreshold := spec.protagonistThresholdError()
 
 	if isProtagonistValid {
 		if deltaProtagonist <= deltaProtagonistThreshold {
@@ -284,6 +306,21 @@ func (individual *Individual) CalculateProtagonistThresholdedFitness(params Evol
 	return protagonistFitness, deltaProtagonist, nil
 }
 
+// GeneralisationScore scores a protagonist on the held out params.TestSpec using the same thresholded fitness it is
+// evolved with. A protagonist that fits the spec but not the test set has not generalised.
+// Both values are NaN if there is no test set.
+func (individual *Individual) GeneralisationScore(params EvolutionParams) (testFitness float64, testDelta float64,
+	err error) {
+	if len(params.TestSpec) < 1 {
+		return math.NaN(), math.NaN(), nil
+	}
+
+	params.Spec = params.TestSpec
+	return individual.CalculateProtagonistThresholdedFitness(params)
+}
+
+// CalculateAntagonistThresholdedFitness scores the antagonist against the spec on its own.
+// The result is served from the fitness cache if it is enabled.
 func (individual *Individual) CalculateAntagonistThresholdedFitness(params EvolutionParams) (antagonistFitness float64,
 	delta float64, err error) {
 	if !individual.HasAppliedStrategy {
@@ -294,17 +331,26 @@ func (individual *Individual) CalculateAntagonistThresholdedFitness(params Evolu
 			" protagonist")
 	}
 
+	entry, err := params.fitnessCache.evaluate(fitnessCacheAntagonist+params.SpecParam.DivideByZeroStrategy,
+		params.Spec, individual.Program, nil, func() (fitnessCacheEntry, error) {
+			fitness, delta, err := individual.antagonistThresholdedFitness(params)
+			return fitnessCacheEntry{antagonistFitness: fitness, antagonistDelta: delta}, err
+		})
+	return entry.antagonistFitness, entry.antagonistDelta, err
+}
+
+func (individual *Individual) antagonistThresholdedFitness(params EvolutionParams) (antagonistFitness float64,
+	delta float64, err error) {
 	fitnessPenalization := params.Spec[0].DivideByZeroPenalty
 	badDeltaValue := math.NaN()
 	divByZeroStrategy := params.SpecParam.DivideByZeroStrategy
 
-	antagonistExpression, err := individual.Program.T.ToMathematicalString()
+	antagonistEvaluator, err := individual.Program.Compile()
 	if err != nil {
 		return 0, 0, err
 	}
 
-	deltaAntagonist := 0.0
-	deltaAntagonistThreshold := 0.0
+	antagonistResiduals := make([]float64, len(params.Spec))
 	antagonistDividedByZeroCount := 0
 	isAntagonistValid := true
 
@@ -312,9 +358,8 @@ func (individual *Individual) CalculateAntagonistThresholdedFitness(params Evolu
 
 	for i := range spec {
 		independentX := spec[i].Independents
-		independentXVal := spec[i].Independents["x"]
 		if isAntagonistValid {
-			dependentAntagonistVar, err := individual.Program.EvalMulti(independentX, antagonistExpression)
+			dependentAntagonistVar, err := antagonistEvaluator.EvaluateWithVar(independentX)
 			if err != nil {
 				switch divByZeroStrategy {
 				case DivByZeroIgnore:
@@ -328,7 +373,7 @@ func (individual *Individual) CalculateAntagonistThresholdedFitness(params Evolu
 					dependentAntagonistVar = 0
 
 				case DivByZeroSteadyPenalize:
-					if independentXVal != 0 {
+					if !independentX.HasZero() {
 						// If the spec does not contain a zero,
 						// yet you still divide by zero. Give maximum penalty!
 						if math.IsNaN(dependentAntagonistVar) || dependentAntagonistVar == 0 {
@@ -343,17 +388,13 @@ func (individual *Individual) CalculateAntagonistThresholdedFitness(params Evolu
 					}
 				}
 			} else {
-				diff := spec[i].Dependent - dependentAntagonistVar
-				deltaAntagonist += diff * diff
+				antagonistResiduals[i] = spec[i].Dependent - dependentAntagonistVar
 			}
 		}
-		deltaAntagonistThreshold += math.Abs(spec[i].AntagonistThreshold) * math.Abs(spec[i].AntagonistThreshold)
 	}
 
-	specLen := float64(len(spec))
-
-	deltaAntagonist = math.Sqrt(deltaAntagonist / specLen)
-	deltaAntagonistThreshold = math.Sqrt(deltaAntagonistThreshold / specLen)
+	deltaAntagonist := spec.aggregateError(antagonistResiduals)
+	deltaAntagonistThreshold := spec.antagonistThresholdError()
 
 	if !isAntagonistValid {
 		// TODO is math.Nan the best alternative?
@@ -389,6 +430,13 @@ func GenerateIndividualID(identifier string, individualKind int) string {
 // GenerateRandomStrategy creates a random Strategy list that contains some or all of the availableStrategies.
 // They are randomly selected and populated.
 func GenerateRandomStrategy(number int, availableStrategies []Strategy) []Strategy {
+	return GenerateWeightedRandomStrategy(number, availableStrategies, nil)
+}
+
+// GenerateWeightedRandomStrategy creates a random Strategy list like GenerateRandomStrategy,
+// but samples each of the availableStrategies in proportion to its weight. If weights is empty every strategy is
+// equally likely.
+func GenerateWeightedRandomStrategy(number int, availableStrategies []Strategy, weights []float64) []Strategy {
 	if number < 1 {
 		number = 1
 	}
@@ -399,13 +447,55 @@ func GenerateRandomStrategy(number int, availableStrategies []Strategy) []Strate
 	strategies := make([]Strategy, number)
 
 	for i := 0; i < number; i++ {
-		strategyIndex := rand.Intn(len(availableStrategies))
-		strategies[i] = availableStrategies[strategyIndex]
+		strategies[i] = RandomStrategy(availableStrategies, weights)
 	}
 
 	return strategies
 }
 
+// RandomStrategy returns one of the availableStrategies, sampled in proportion to its weight. If weights is empty
+// or does not have a weight for each strategy every strategy is equally likely.
+func RandomStrategy(availableStrategies []Strategy, weights []float64) Strategy {
+	if len(weights) < 1 || len(weights) != len(availableStrategies) {
+		return availableStrategies[rand.Intn(len(availableStrategies))]
+	}
+	total := 0.0
+	for i := range weights {
+		total += weights[i]
+	}
+	return availableStrategies[spinWheel(weights, total*rand.Float64())]
+}
+
+// AvailableStrategies returns the strategies available to the given kind of individual and their sampling weights.
+func (s Strategies) AvailableStrategies(kind int) ([]Strategy, []float64) {
+	if kind == IndividualAntagonist {
+		return s.AntagonistAvailableStrategies, s.AntagonistStrategyWeights
+	}
+	return s.ProtagonistAvailableStrategies, s.ProtagonistStrategyWeights
+}
+
+// validateWeights checks that there is a non-negative weight for each of count items and that they are not all 0.
+// An empty list of weights is valid.
+func validateWeights(name string, weights []float64, count int) error {
+	if len(weights) < 1 {
+		return nil
+	}
+	if len(weights) != count {
+		return fmt.Errorf("%s must have %d weights, one for each item, but has %d", name, count, len(weights))
+	}
+	total := 0.0
+	for _, weight := range weights {
+		if weight < 0 || math.IsNaN(weight) {
+			return fmt.Errorf("%s cannot be negative", name)
+		}
+		total += weight
+	}
+	if total <= 0 {
+		return fmt.Errorf("%s must not all be 0", name)
+	}
+	return nil
+}
+
 // KindToString checks the Kind and returns the appropriate string representation
 func KindToString(kind int) string {
 	switch kind {
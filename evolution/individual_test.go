@@ -1,6 +1,7 @@
 package evolution
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -30,6 +31,103 @@ func TestGenerateRandomStrategy(t *testing.T) {
 	}
 }
 
+func TestGenerateWeightedRandomStrategy(t *testing.T) {
+	availableStrategies := []Strategy{StrategyFellTree, StrategyMutateTerminal, StrategySkip}
+	tests := []struct {
+		name    string
+		weights []float64
+		// wantShare is the expected share of each available strategy
+		wantShare []float64
+	}{
+		{"uniform", nil, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{"weighted", []float64{1, 8, 1}, []float64{0.1, 0.8, 0.1}},
+		{"never", []float64{0, 3, 1}, []float64{0, 0.75, 0.25}},
+		{"mismatched weights are ignored", []float64{1, 8}, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const number = 20000
+			got := GenerateWeightedRandomStrategy(number, availableStrategies, tt.weights)
+			if len(got) != number {
+				t.Fatalf("GenerateWeightedRandomStrategy() has %d strategies, want %d", len(got), number)
+			}
+			counts := map[Strategy]int{}
+			for _, strategy := range got {
+				counts[strategy]++
+			}
+			for i, strategy := range availableStrategies {
+				share := float64(counts[strategy]) / number
+				if math.Abs(share-tt.wantShare[i]) > 0.02 || (tt.wantShare[i] == 0 && share != 0) {
+					t.Errorf("share of %s = %.3f, want %.3f", strategy, share, tt.wantShare[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIndividual_MutateWeighted(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		individual := &Individual{Strategy: []Strategy{StrategySkip, StrategySkip}}
+		err := individual.MutateWeighted([]Strategy{StrategyFellTree, StrategyMutateTerminal}, []float64{0, 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, strategy := range individual.Strategy {
+			if strategy == StrategyFellTree {
+				t.Fatalf("MutateWeighted() = %v, sampled a strategy with weight 0", individual.Strategy)
+			}
+		}
+	}
+}
+
+func TestGeneration_GenerateRandomIndividuals_Weights(t *testing.T) {
+	params := EvolutionParams{EachPopulationSize: 10}
+	params.Strategies = Strategies{
+		AntagonistAvailableStrategies:  []Strategy{StrategyFellTree, StrategyMutateTerminal},
+		AntagonistStrategyWeights:      []float64{0, 1},
+		AntagonistStrategyCount:        5,
+		ProtagonistAvailableStrategies: []Strategy{StrategyFellTree, StrategyMutateTerminal},
+		ProtagonistStrategyWeights:     []float64{1, 0},
+		ProtagonistStrategyCount:       5,
+	}
+	for kind, want := range map[int]Strategy{IndividualAntagonist: StrategyMutateTerminal,
+		IndividualProtagonist: StrategyFellTree} {
+		individuals, err := (&Generation{}).GenerateRandomIndividuals(kind, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, individual := range individuals {
+			for _, strategy := range individual.Strategy {
+				if strategy != want {
+					t.Fatalf("%s strategy = %v, want only %s", KindToString(kind), individual.Strategy, want)
+				}
+			}
+		}
+	}
+}
+
+func Test_validateWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []float64
+		count   int
+		wantErr bool
+	}{
+		{"empty", nil, 3, false},
+		{"ok", []float64{0, 1, 2.5}, 3, false},
+		{"too few", []float64{1, 2}, 3, true},
+		{"negative", []float64{1, -1, 2}, 3, true},
+		{"zero", []float64{0, 0, 0}, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateWeights("Weights", tt.weights, tt.count); (err != nil) != tt.wantErr {
+				t.Errorf("validateWeights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 //func TestGenerateRandomIndividuals(t *testing.T) {
 //	type depth struct {
 //		number                int
@@ -550,6 +648,22 @@ func TestIndividual_Clone(t *testing.T) {
 	}
 }
 
+func TestIndividual_CloneStrategy(t *testing.T) {
+	individual := Individual{Strategy: []Strategy{StrategySkip, StrategyFellTree}, Program: &Prog1}
+
+	clone, err := individual.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeClone := individual.CloneWithTree(*TreeT_X())
+	clone.Strategy[0] = StrategyMultXD
+	treeClone.Strategy[1] = StrategyAddXD
+
+	if individual.Strategy[0] != StrategySkip || individual.Strategy[1] != StrategyFellTree {
+		t.Errorf("changing the Strategy of a clone should not change the original. got: %v", individual.Strategy)
+	}
+}
+
 func TestIndividual_CloneWithTree(t *testing.T) {
 	type fields struct {
 		Id                       string
@@ -15,6 +15,7 @@ func CleansePopulation(individuals []*Individual, treeReplacer DualTree) ([]*Ind
 			newIndividual := individual.CloneWithTree(tree)
 			newIndividual.Fitness = make([]float64, 0)
 			newIndividual.Deltas = make([]float64, 0)
+			newIndividual.CaseDeltas = make([][]float64, 0)
 			newIndividual.HasCalculatedFitness = false
 			newIndividual.HasAppliedStrategy = false
 			newIndividual.AverageFitness = -1
@@ -24,7 +25,6 @@ func CleansePopulation(individuals []*Individual, treeReplacer DualTree) ([]*Ind
 			newIndividual.FitnessVariance = 0
 			newIndividual.FitnessStdDev = 0
 			newIndividual.Program.T = &tree
-			newIndividual.Strategy = individuals[i].Strategy
 			individuals[i] = &newIndividual
 		} else {
 			newIndividual, err := individuals[i].Clone()
@@ -33,6 +33,7 @@ func CleansePopulation(individuals []*Individual, treeReplacer DualTree) ([]*Ind
 			}
 			newIndividual.Fitness = make([]float64, 0)
 			newIndividual.Deltas = make([]float64, 0)
+			newIndividual.CaseDeltas = make([][]float64, 0)
 			newIndividual.FitnessVariance = 0
 			newIndividual.FitnessStdDev = 0
 			newIndividual.HasCalculatedFitness = false
@@ -42,7 +43,6 @@ func CleansePopulation(individuals []*Individual, treeReplacer DualTree) ([]*Ind
 			newIndividual.AverageDelta = -1
 			newIndividual.BestFitness = -1
 			newIndividual.BestDelta = -1
-			newIndividual.Strategy = individuals[i].Strategy
 			individuals[i] = &newIndividual
 			individuals[i].Program = &Program{}
 		}
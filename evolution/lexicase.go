@@ -0,0 +1,160 @@
+package evolution
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// LexicaseSelection selects every parent by filtering the population on the spec points one at a time in a random
+// order. Only the individuals with the lowest case score survive each case, once a single individual remains or the
+// cases run out a random survivor becomes the parent. This keeps specialists that are good on some regions of the
+// spec even if their aggregated delta is poor. Individuals are compared using their CaseDeltas, see caseScores.
+func LexicaseSelection(population []*Individual) ([]*Individual, error) {
+	scores, err := caseScores(population)
+	if err != nil {
+		return nil, err
+	}
+	return lexicaseSelect(population, scores, make([]float64, len(scores[0]))), nil
+}
+
+// EpsilonLexicaseSelection is LexicaseSelection where an individual survives a case if its score is within epsilon of
+// the best score on that case, which suits the continuous errors of symbolic regression. An epsilon of 0 uses the
+// median absolute deviation of the scores on each case.
+func EpsilonLexicaseSelection(population []*Individual, epsilon float64) ([]*Individual, error) {
+	if epsilon < 0 {
+		return nil, fmt.Errorf("EpsilonLexicaseSelection | epsilon cannot be negative")
+	}
+	scores, err := caseScores(population)
+	if err != nil {
+		return nil, err
+	}
+
+	epsilons := make([]float64, len(scores[0]))
+	for c := range epsilons {
+		if epsilon > 0 {
+			epsilons[c] = epsilon
+			continue
+		}
+		caseScores := make([]float64, 0, len(scores))
+		for i := range scores {
+			if !math.IsInf(scores[i][c], 0) {
+				caseScores = append(caseScores, scores[i][c])
+			}
+		}
+		epsilons[c] = medianAbsoluteDeviation(caseScores)
+	}
+	return lexicaseSelect(population, scores, epsilons), nil
+}
+
+func lexicaseSelect(population []*Individual, scores [][]float64, epsilons []float64) []*Individual {
+	selected := make([]*Individual, len(population))
+	candidates := make([]int, 0, len(population))
+	for i := range selected {
+		candidates = candidates[:0]
+		for j := range population {
+			candidates = append(candidates, j)
+		}
+
+		for _, c := range rand.Perm(len(epsilons)) {
+			best := math.Inf(1)
+			for _, candidate := range candidates {
+				best = math.Min(best, scores[candidate][c])
+			}
+			survivors := candidates[:0]
+			for _, candidate := range candidates {
+				if scores[candidate][c] <= best+epsilons[c] {
+					survivors = append(survivors, candidate)
+				}
+			}
+			candidates = survivors
+			if len(candidates) == 1 {
+				break
+			}
+		}
+		selected[i] = population[candidates[rand.Intn(len(candidates))]]
+	}
+	return selected
+}
+
+// caseScores averages the CaseDeltas of every individual over its competitions, lower scores are better.
+// Protagonists score their error on each case, antagonists the negated error as they try to move away from the spec.
+// Invalid cases follow the convention of Deltas, they count as MaxInt16 for protagonists and 0 for antagonists.
+// Individuals that have not competed score +Inf on every case.
+func caseScores(population []*Individual) ([][]float64, error) {
+	if len(population) < 1 {
+		return nil, fmt.Errorf("caseScores | population cannot be empty")
+	}
+
+	caseCount := -1
+	for _, individual := range population {
+		for _, caseDeltas := range individual.CaseDeltas {
+			if caseCount == -1 {
+				caseCount = len(caseDeltas)
+			}
+			if len(caseDeltas) != caseCount {
+				return nil, fmt.Errorf("caseScores | individual %s has %d case deltas, expected %d", individual.Id,
+					len(caseDeltas), caseCount)
+			}
+		}
+	}
+	if caseCount < 1 {
+		return nil, fmt.Errorf("caseScores | population has no case deltas, lexicase selection requires the" +
+			" individuals to have competed")
+	}
+
+	scores := make([][]float64, len(population))
+	for i, individual := range population {
+		scores[i] = make([]float64, caseCount)
+		if len(individual.CaseDeltas) < 1 {
+			for c := range scores[i] {
+				scores[i][c] = math.Inf(1)
+			}
+			continue
+		}
+
+		for _, caseDeltas := range individual.CaseDeltas {
+			for c, delta := range caseDeltas {
+				switch {
+				case math.IsNaN(delta) && individual.Kind == IndividualProtagonist:
+					delta = math.MaxInt16
+				case math.IsNaN(delta):
+					delta = 0
+				}
+				scores[i][c] += delta
+			}
+		}
+		for c := range scores[i] {
+			scores[i][c] = scores[i][c] / float64(len(individual.CaseDeltas))
+			if individual.Kind == IndividualAntagonist {
+				scores[i][c] = -scores[i][c]
+			}
+		}
+	}
+	return scores, nil
+}
+
+// medianAbsoluteDeviation returns the median of the absolute deviations of values from their median.
+func medianAbsoluteDeviation(values []float64) float64 {
+	if len(values) < 1 {
+		return 0
+	}
+	med := median(values)
+	deviations := make([]float64, len(values))
+	for i := range values {
+		deviations[i] = math.Abs(values[i] - med)
+	}
+	return median(deviations)
+}
+
+func median(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
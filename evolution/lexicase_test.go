@@ -0,0 +1,196 @@
+package evolution
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// lexicaseTestPopulation returns protagonists that each competed once with the given case deltas.
+func lexicaseTestPopulation(caseDeltas ...[]float64) []*Individual {
+	population := make([]*Individual, len(caseDeltas))
+	for i := range caseDeltas {
+		population[i] = &Individual{
+			Id:         string(rune('a' + i)),
+			Kind:       IndividualProtagonist,
+			CaseDeltas: [][]float64{caseDeltas[i]},
+		}
+	}
+	return population
+}
+
+func TestProgramCaseDeltas(t *testing.T) {
+	tests := []struct {
+		name              string
+		expression        string
+		divByZeroStrategy string
+		want              []float64
+	}{
+		{"perfect", "x*x", DivByZeroSteadyPenalize, []float64{0, 0, 0, 0}},
+		{"x", "x", DivByZeroSteadyPenalize, []float64{0, 2, 6, 12}},
+		{"offset", "x*x-3", DivByZeroSteadyPenalize, []float64{3, 3, 3, 3}},
+		{"invalid", "x/(x-1)", DivByZeroPenalize, []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProgramCaseDeltas(fitnessCacheTestSpec(), fitnessCacheTestProgram(t, tt.expression),
+				tt.divByZeroStrategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ProgramCaseDeltas() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] && !(math.IsNaN(got[i]) && math.IsNaN(tt.want[i])) {
+					t.Errorf("ProgramCaseDeltas() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, err := ProgramCaseDeltas(SpecMulti{}, fitnessCacheTestProgram(t, "x"), DivByZeroPenalize); err == nil {
+		t.Errorf("ProgramCaseDeltas() with an empty spec should return an error")
+	}
+}
+
+func TestEvolutionParams_EvaluateCaseDeltas(t *testing.T) {
+	params := EvolutionParams{Spec: fitnessCacheTestSpec(), fitnessCache: NewFitnessCache(10)}
+	params.SpecParam.DivideByZeroStrategy = DivByZeroSteadyPenalize
+
+	got, err := params.EvaluateCaseDeltas(fitnessCacheTestProgram(t, "x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got[0] = 100
+
+	got, err = params.EvaluateCaseDeltas(fitnessCacheTestProgram(t, "x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0] != 0 {
+		t.Errorf("EvaluateCaseDeltas() returned a cached slice that was modified by the caller")
+	}
+	if hits, misses := params.fitnessCache.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("EvaluateCaseDeltas() cache hits = %d misses = %d, want 1 and 1", hits, misses)
+	}
+}
+
+func Test_caseScores(t *testing.T) {
+	protagonist := &Individual{Id: "p", Kind: IndividualProtagonist,
+		CaseDeltas: [][]float64{{1, 2, math.NaN()}, {3, 4, math.NaN()}}}
+	antagonist := &Individual{Id: "a", Kind: IndividualAntagonist,
+		CaseDeltas: [][]float64{{1, 2, math.NaN()}, {3, 4, 6}}}
+	idle := &Individual{Id: "i", Kind: IndividualProtagonist}
+
+	got, err := caseScores([]*Individual{protagonist, antagonist, idle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]float64{{2, 3, math.MaxInt16}, {-2, -3, -3}, {math.Inf(1), math.Inf(1), math.Inf(1)}}
+	for i := range want {
+		for c := range want[i] {
+			if got[i][c] != want[i][c] {
+				t.Errorf("caseScores() = %v, want %v", got, want)
+			}
+		}
+	}
+
+	tests := []struct {
+		name       string
+		population []*Individual
+	}{
+		{"empty", nil},
+		{"no-competitions", []*Individual{idle}},
+		{"mismatched", lexicaseTestPopulation([]float64{1, 2}, []float64{1, 2, 3})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := caseScores(tt.population); err == nil {
+				t.Errorf("caseScores() should return an error")
+			}
+		})
+	}
+}
+
+func TestLexicaseSelection(t *testing.T) {
+	rand.Seed(1)
+	// a and b are specialists, c has the best aggregated error but is never the best on any case
+	population := lexicaseTestPopulation([]float64{0, 10}, []float64{10, 0}, []float64{4, 4})
+	counts := map[string]int{}
+	for i := 0; i < 200; i++ {
+		selected, err := LexicaseSelection(population)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(selected) != len(population) {
+			t.Fatalf("LexicaseSelection() returned %d individuals, want %d", len(selected), len(population))
+		}
+		for id, count := range selectionCounts(selected) {
+			counts[id] += count
+		}
+	}
+	if counts["c"] != 0 {
+		t.Errorf("LexicaseSelection() selected c %d times, want 0", counts["c"])
+	}
+	if math.Abs(float64(counts["a"]-counts["b"]))/float64(counts["a"]+counts["b"]) > 0.1 {
+		t.Errorf("LexicaseSelection() selected a %d and b %d times, want roughly equal", counts["a"], counts["b"])
+	}
+}
+
+func TestEpsilonLexicaseSelection(t *testing.T) {
+	rand.Seed(1)
+	// b is never the best on a case so lexicase never selects it, within an epsilon of 1 it ties with a on the first
+	// case and beats it on the second so a is never selected instead.
+	population := lexicaseTestPopulation([]float64{0, 10}, []float64{1, 8}, []float64{10, 0})
+	tests := []struct {
+		name      string
+		selection func([]*Individual) ([]*Individual, error)
+		never     string
+	}{
+		{"lexicase", LexicaseSelection, "b"},
+		{"epsilon=1", func(p []*Individual) ([]*Individual, error) { return EpsilonLexicaseSelection(p, 1) }, "a"},
+		{"epsilon=0.5", func(p []*Individual) ([]*Individual, error) { return EpsilonLexicaseSelection(p, 0.5) }, "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts := map[string]int{}
+			for i := 0; i < 200; i++ {
+				selected, err := tt.selection(population)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for id, count := range selectionCounts(selected) {
+					counts[id] += count
+				}
+			}
+			if counts[tt.never] != 0 {
+				t.Errorf("selected %s %d times, want 0, counts %v", tt.never, counts[tt.never], counts)
+			}
+		})
+	}
+
+	if _, err := EpsilonLexicaseSelection(population, -1); err == nil {
+		t.Errorf("EpsilonLexicaseSelection() with a negative epsilon should return an error")
+	}
+}
+
+func Test_medianAbsoluteDeviation(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"constant", []float64{3, 3, 3}, 0},
+		{"odd", []float64{1, 2, 3, 4, 100}, 1},
+		{"even", []float64{1, 2, 4, 8}, 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := medianAbsoluteDeviation(tt.values); got != tt.want {
+				t.Errorf("medianAbsoluteDeviation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,53 +2,285 @@ package evolution
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"sort"
 )
 
+const (
+	MutationSubstitute     = "MutationSubstitute"     // point mutation, replaces a random gene with an available strategy
+	MutationInsert         = "MutationInsert"         // inserts a random available strategy at a random position
+	MutationDelete         = "MutationDelete"         // removes a random gene
+	MutationDuplicate      = "MutationDuplicate"      // repeats a random segment of genes directly after itself
+	MutationInvert         = "MutationInvert"         // reverses the order of a random segment of genes
+	MutationSwap           = "MutationSwap"           // exchanges two random genes
+	MutationScramble       = "MutationScramble"       // shuffles the genes within a random segment
+	MutationSegmentShuffle = "MutationSegmentShuffle" // cuts the genome into random segments and shuffles their order
+)
+
+// AllMutationOperators lists every mutation operator that can be used in Reproduction.MutationOperators.
+var AllMutationOperators = []string{MutationSubstitute, MutationInsert, MutationDelete, MutationDuplicate,
+	MutationInvert, MutationSwap, MutationScramble, MutationSegmentShuffle}
+
+// Mutate mutates each of the outgoing parents and children with probability Reproduction.ProbabilityOfMutation,
+// or with their own MutationRate when using MutationRateSelfAdaptive. Children are expected to be bred in pairs from
+// consecutive parents as in ApplyReproduction, a mutated child must beat the fitter of its parents for the mutation
+// to count as a success in the one-fifth success rule.
 func Mutate(outgoingParents []*Individual, children []*Individual, kind int,
 	opts EvolutionParams) (parents []*Individual, childs []*Individual, err error) {
-	if kind == IndividualAntagonist {
-		for i := 0; i < (len(outgoingParents)); i++ {
-			probabilityOfMutation := rand.Float64()
-			if probabilityOfMutation < opts.Reproduction.ProbabilityOfMutation {
-				err := outgoingParents[i].Mutate(opts.Strategies.AntagonistAvailableStrategies)
-				if err != nil {
-					return nil, nil, err
-				}
-			}
-		}
-		// childs
-		for i := 0; i < (len(children)); i++ {
-			probabilityOfMutation := rand.Float64()
-			if probabilityOfMutation < opts.Reproduction.ProbabilityOfMutation {
-				err := children[i].Mutate(opts.Strategies.AntagonistAvailableStrategies)
-				if err != nil {
-					return nil, nil, err
-				}
-			}
-		}
-	} else if kind == IndividualProtagonist {
-		for i := 0; i < (len(outgoingParents)); i++ {
-			probabilityOfMutation := rand.Float64()
-			if probabilityOfMutation < opts.Reproduction.ProbabilityOfMutation {
-				err := outgoingParents[i].Mutate(opts.Strategies.ProtagonistAvailableStrategies)
-				if err != nil {
-					return nil, nil, err
-				}
-			}
-		}
-		// childs
-		for i := 0; i < (len(children)); i++ {
-			probabilityOfMutation := rand.Float64()
-			if probabilityOfMutation < opts.Reproduction.ProbabilityOfMutation {
-				err := children[i].Mutate(opts.Strategies.ProtagonistAvailableStrategies)
-				if err != nil {
-					return nil, nil, err
-				}
-			}
-		}
-	} else {
+	if kind != IndividualAntagonist && kind != IndividualProtagonist {
 		return nil, nil, fmt.Errorf("Judgement Day | Invalid kind")
 	}
+	availableStrategies, strategyWeights := opts.Strategies.AvailableStrategies(kind)
+
+	for i := 0; i < (len(outgoingParents)); i++ {
+		err := maybeMutate(outgoingParents[i], outgoingParents[i].AverageFitness, availableStrategies,
+			strategyWeights, kind, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	// childs
+	for i := 0; i < (len(children)); i++ {
+		err := maybeMutate(children[i], parentFitness(outgoingParents, i), availableStrategies, strategyWeights,
+			kind, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	return outgoingParents, children, nil
 }
+
+// maybeMutate mutates the individual with the current mutation rate and records the fitness the mutation has to
+// beat to count as a success.
+func maybeMutate(individual *Individual, baseline float64, availableStrategies []Strategy,
+	strategyWeights []float64, kind int, opts EvolutionParams) error {
+	individual.isMutated = false
+
+	rate := opts.Reproduction.ProbabilityOfMutation
+	mutationRate := opts.Reproduction.MutationRate
+	if mutationRate.Type == MutationRateSelfAdaptive {
+		if individual.MutationRate > 0 {
+			rate = individual.MutationRate
+		}
+		rate = mutationRate.clamp(SelfAdaptMutationRate(rate, mutationRate.learningRate()))
+		individual.MutationRate = rate
+	}
+
+	probabilityOfMutation := rand.Float64()
+	if probabilityOfMutation >= rate {
+		return nil
+	}
+	individual.isMutated = true
+	individual.mutationBaseline = baseline
+	// Traces of the strategies the individual had before it was mutated no longer explain it
+	individual.StrategyTraces = nil
+	return mutateIndividual(individual, availableStrategies, strategyWeights, kind, opts)
+}
+
+// parentFitness returns the AverageFitness of the fitter of the two parents the child at index i was bred from.
+func parentFitness(parents []*Individual, i int) float64 {
+	first := i - i%2
+	if first >= len(parents) {
+		return math.Inf(-1)
+	}
+	fitness := parents[first].AverageFitness
+	if first+1 < len(parents) && parents[first+1].AverageFitness > fitness {
+		fitness = parents[first+1].AverageFitness
+	}
+	return fitness
+}
+
+// mutateIndividual mutates the individual with one of the Reproduction.MutationOperators, chosen at random according
+// to the Reproduction.MutationOperatorWeights. New genes are sampled according to the strategyWeights.
+func mutateIndividual(individual *Individual, availableStrategies []Strategy, strategyWeights []float64, kind int,
+	opts EvolutionParams) error {
+	operators := opts.Reproduction.MutationOperators
+	if len(operators) < 1 {
+		return individual.MutateWeighted(availableStrategies, strategyWeights)
+	}
+	operator := operators[rand.Intn(len(operators))]
+	if weights := opts.Reproduction.MutationOperatorWeights; len(weights) > 0 {
+		total := 0.0
+		for i := range weights {
+			total += weights[i]
+		}
+		operator = operators[spinWheel(weights, total*rand.Float64())]
+	}
+	minLength, maxLength := opts.Strategies.StrategyCountBounds(kind)
+	return individual.MutateGenome(operator, availableStrategies, strategyWeights, minLength, maxLength)
+}
+
+// MutateGenome applies the mutation operator to the individual's Strategy genome. Insertion, deletion and
+// duplication change the length of the genome, if the result would fall outside [minLength, maxLength] a single
+// gene is substituted instead. Operators that reorder genes also fall back to substitution on single gene genomes.
+// The genome is replaced rather than modified in place as it may be shared with the individual's parent.
+func (individual *Individual) MutateGenome(operator string, availableStrategies []Strategy,
+	strategyWeights []float64, minLength, maxLength int) error {
+	if len(availableStrategies) < 1 {
+		return fmt.Errorf("MutateGenome | availableStrategies param cannot be empty")
+	}
+	if len(individual.Strategy) < 1 {
+		return fmt.Errorf("MutateGenome | individual's strategies cannot be empty")
+	}
+
+	genome := individual.Strategy
+	var mutated []Strategy
+	switch operator {
+	case MutationSubstitute:
+	case MutationInsert:
+		if len(genome) >= maxLength {
+			break
+		}
+		position := rand.Intn(len(genome) + 1)
+		mutated = make([]Strategy, 0, len(genome)+1)
+		mutated = append(mutated, genome[:position]...)
+		mutated = append(mutated, RandomStrategy(availableStrategies, strategyWeights))
+		mutated = append(mutated, genome[position:]...)
+	case MutationDelete:
+		if len(genome) <= minLength {
+			break
+		}
+		position := rand.Intn(len(genome))
+		mutated = make([]Strategy, 0, len(genome)-1)
+		mutated = append(mutated, genome[:position]...)
+		mutated = append(mutated, genome[position+1:]...)
+	case MutationDuplicate:
+		room := maxLength - len(genome)
+		if room < 1 {
+			break
+		}
+		start := rand.Intn(len(genome))
+		segmentLength := len(genome) - start
+		if segmentLength > room {
+			segmentLength = room
+		}
+		end := start + 1 + rand.Intn(segmentLength)
+		mutated = make([]Strategy, 0, len(genome)+end-start)
+		mutated = append(mutated, genome[:end]...)
+		mutated = append(mutated, genome[start:end]...)
+		mutated = append(mutated, genome[end:]...)
+	case MutationInvert:
+		if len(genome) < 2 {
+			break
+		}
+		start := rand.Intn(len(genome) - 1)
+		end := start + 2 + rand.Intn(len(genome)-start-1)
+		mutated = make([]Strategy, len(genome))
+		copy(mutated, genome)
+		for i, j := start, end-1; i < j; i, j = i+1, j-1 {
+			mutated[i], mutated[j] = mutated[j], mutated[i]
+		}
+	case MutationSwap:
+		if len(genome) < 2 {
+			break
+		}
+		positions := rand.Perm(len(genome))
+		mutated = make([]Strategy, len(genome))
+		copy(mutated, genome)
+		mutated[positions[0]], mutated[positions[1]] = mutated[positions[1]], mutated[positions[0]]
+	case MutationScramble:
+		if len(genome) < 2 {
+			break
+		}
+		start := rand.Intn(len(genome) - 1)
+		end := start + 2 + rand.Intn(len(genome)-start-1)
+		mutated = make([]Strategy, len(genome))
+		copy(mutated, genome)
+		segment := mutated[start:end]
+		rand.Shuffle(len(segment), func(i, j int) {
+			segment[i], segment[j] = segment[j], segment[i]
+		})
+	case MutationSegmentShuffle:
+		if len(genome) < 2 {
+			break
+		}
+		// cut at between 1 and len(genome)-1 distinct points
+		cuts := rand.Perm(len(genome) - 1)[:1+rand.Intn(len(genome)-1)]
+		for i := range cuts {
+			cuts[i]++
+		}
+		sort.Ints(cuts)
+		segments := make([][]Strategy, 0, len(cuts)+1)
+		previous := 0
+		for _, cut := range append(cuts, len(genome)) {
+			segments = append(segments, genome[previous:cut])
+			previous = cut
+		}
+		rand.Shuffle(len(segments), func(i, j int) {
+			segments[i], segments[j] = segments[j], segments[i]
+		})
+		mutated = make([]Strategy, 0, len(genome))
+		for _, segment := range segments {
+			mutated = append(mutated, segment...)
+		}
+	default:
+		return fmt.Errorf("MutateGenome | unknown mutation operator %q", operator)
+	}
+
+	if mutated == nil {
+		mutated = make([]Strategy, len(genome))
+		copy(mutated, genome)
+		mutated[rand.Intn(len(mutated))] = RandomStrategy(availableStrategies, strategyWeights)
+	}
+	individual.Strategy = mutated
+	return nil
+}
+
+// StrategyCountBounds returns the minimum and maximum Strategy genome length of the given kind of individual.
+func (s Strategies) StrategyCountBounds(kind int) (minLength, maxLength int) {
+	minLength, maxLength, count := s.ProtagonistMinStrategyCount, s.ProtagonistMaxStrategyCount,
+		s.ProtagonistStrategyCount
+	if kind == IndividualAntagonist {
+		minLength, maxLength, count = s.AntagonistMinStrategyCount, s.AntagonistMaxStrategyCount,
+			s.AntagonistStrategyCount
+	}
+	if minLength < 1 {
+		minLength = 1
+	}
+	if maxLength < 1 {
+		maxLength = count
+	}
+	return minLength, maxLength
+}
+
+// validateGenome checks that the genome length bounds of both kinds contain their StrategyCount, that every
+// mutation operator is known and that the mutation operator and strategy weights are usable.
+func validateGenome(strategies Strategies, reproduction Reproduction) error {
+	for _, kind := range []int{IndividualAntagonist, IndividualProtagonist} {
+		count := strategies.ProtagonistStrategyCount
+		if kind == IndividualAntagonist {
+			count = strategies.AntagonistStrategyCount
+		}
+		minLength, maxLength := strategies.StrategyCountBounds(kind)
+		if count < minLength || count > maxLength {
+			return fmt.Errorf("%s strategy count %d must lie between the min and max strategy counts %d and %d",
+				KindToString(kind), count, minLength, maxLength)
+		}
+	}
+	err := validateWeights("MutationOperatorWeights", reproduction.MutationOperatorWeights,
+		len(reproduction.MutationOperators))
+	if err != nil {
+		return err
+	}
+	err = validateWeights("AntagonistStrategyWeights", strategies.AntagonistStrategyWeights,
+		len(strategies.AntagonistAvailableStrategies))
+	if err != nil {
+		return err
+	}
+	err = validateWeights("ProtagonistStrategyWeights", strategies.ProtagonistStrategyWeights,
+		len(strategies.ProtagonistAvailableStrategies))
+	if err != nil {
+		return err
+	}
+	for _, operator := range reproduction.MutationOperators {
+		known := false
+		for _, mutationOperator := range AllMutationOperators {
+			known = known || operator == mutationOperator
+		}
+		if !known {
+			return fmt.Errorf("unknown mutation operator %q, use one of %v", operator, AllMutationOperators)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package evolution
+
+import (
+	"testing"
+)
+
+func TestIndividual_MutateGenome(t *testing.T) {
+	genome := []Strategy{StrategyDeleteMalicious, StrategyMutateTerminal, StrategyAddRandomSubTree,
+		StrategyMutateNonTerminal}
+	available := []Strategy{StrategyReplaceBranch}
+	tests := []struct {
+		name                 string
+		operator             string
+		strategy             []Strategy
+		available            []Strategy
+		minLength, maxLength int
+		wantLength           []int
+		wantErr              bool
+	}{
+		{"empty available", MutationInsert, genome, []Strategy{}, 1, 8, nil, true},
+		{"empty strategy", MutationInsert, []Strategy{}, available, 1, 8, nil, true},
+		{"unknown operator", "MutationUnknown", genome, available, 1, 8, nil, true},
+		{"substitute", MutationSubstitute, genome, available, 1, 8, []int{4}, false},
+		{"insert", MutationInsert, genome, available, 1, 8, []int{5}, false},
+		{"insert at max", MutationInsert, genome, available, 1, 4, []int{4}, false},
+		{"delete", MutationDelete, genome, available, 1, 8, []int{3}, false},
+		{"delete at min", MutationDelete, genome, available, 4, 8, []int{4}, false},
+		{"duplicate", MutationDuplicate, genome, available, 1, 8, []int{5, 6, 7, 8}, false},
+		{"duplicate near max", MutationDuplicate, genome, available, 1, 5, []int{5}, false},
+		{"duplicate at max", MutationDuplicate, genome, available, 1, 4, []int{4}, false},
+		{"invert", MutationInvert, genome, available, 1, 8, []int{4}, false},
+		{"invert single gene", MutationInvert, []Strategy{StrategyDeleteMalicious}, available, 1, 8, []int{1}, false},
+		{"swap", MutationSwap, genome, available, 1, 4, []int{4}, false},
+		{"scramble", MutationScramble, genome, available, 1, 4, []int{4}, false},
+		{"segment shuffle", MutationSegmentShuffle, genome, available, 1, 4, []int{4}, false},
+		{"swap single gene", MutationSwap, []Strategy{StrategyDeleteMalicious}, available, 1, 8, []int{1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				strategy := append([]Strategy(nil), tt.strategy...)
+				individual := &Individual{Strategy: strategy}
+				err := individual.MutateGenome(tt.operator, tt.available, nil, tt.minLength, tt.maxLength)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("MutateGenome() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr {
+					return
+				}
+				for j := range strategy {
+					if strategy[j] != tt.strategy[j] {
+						t.Fatalf("MutateGenome() modified the original genome in place")
+					}
+				}
+				found := false
+				for _, length := range tt.wantLength {
+					found = found || len(individual.Strategy) == length
+				}
+				if !found {
+					t.Fatalf("MutateGenome() genome has %d genes, want one of %v", len(individual.Strategy),
+						tt.wantLength)
+				}
+			}
+		})
+	}
+}
+
+func TestIndividual_MutateGenome_Invert(t *testing.T) {
+	genome := []Strategy{StrategyDeleteMalicious, StrategyMutateTerminal, StrategyAddRandomSubTree}
+	for i := 0; i < 50; i++ {
+		individual := &Individual{Strategy: genome}
+		if err := individual.MutateGenome(MutationInvert, []Strategy{StrategyReplaceBranch}, nil, 1, 3); err != nil {
+			t.Fatal(err)
+		}
+		counts := map[Strategy]int{}
+		changed := false
+		for j, strategy := range individual.Strategy {
+			counts[strategy]++
+			changed = changed || strategy != genome[j]
+		}
+		if len(counts) != len(genome) || counts[StrategyReplaceBranch] != 0 {
+			t.Fatalf("MutateGenome(MutationInvert) = %v, want a permutation of %v", individual.Strategy, genome)
+		}
+		if !changed {
+			t.Fatalf("MutateGenome(MutationInvert) = %v, want a reversed segment", individual.Strategy)
+		}
+	}
+}
+
+func TestIndividual_MutateGenome_Reorder(t *testing.T) {
+	genome := []Strategy{StrategyDeleteMalicious, StrategyMutateTerminal, StrategyAddRandomSubTree,
+		StrategyMutateNonTerminal, StrategyReplaceBranch}
+	for _, operator := range []string{MutationInvert, MutationSwap, MutationScramble, MutationSegmentShuffle} {
+		t.Run(operator, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				individual := &Individual{Strategy: genome}
+				err := individual.MutateGenome(operator, []Strategy{StrategySkip}, nil, 1, 5)
+				if err != nil {
+					t.Fatal(err)
+				}
+				counts := map[Strategy]int{}
+				for _, strategy := range individual.Strategy {
+					counts[strategy]++
+				}
+				if len(individual.Strategy) != len(genome) || len(counts) != len(genome) ||
+					counts[StrategySkip] != 0 {
+					t.Fatalf("MutateGenome(%s) = %v, want a permutation of %v", operator, individual.Strategy,
+						genome)
+				}
+			}
+		})
+	}
+}
+
+func Test_mutateIndividual_Weights(t *testing.T) {
+	params := EvolutionParams{}
+	params.Strategies.ProtagonistStrategyCount = 4
+	params.Strategies.ProtagonistMaxStrategyCount = 100
+	params.Reproduction.MutationOperators = []string{MutationDelete, MutationInsert, MutationSwap}
+	params.Reproduction.MutationOperatorWeights = []float64{0, 1, 0}
+
+	individual := &Individual{Strategy: []Strategy{StrategyDeleteMalicious, StrategyMutateTerminal,
+		StrategyAddRandomSubTree, StrategyMutateNonTerminal}}
+	for i := 0; i < 20; i++ {
+		err := mutateIndividual(individual, []Strategy{StrategyReplaceBranch}, nil, IndividualProtagonist, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(individual.Strategy) != 24 {
+		t.Errorf("mutateIndividual() genome has %d genes, want 24 as only MutationInsert has weight",
+			len(individual.Strategy))
+	}
+}
+
+func TestMutate_Baseline(t *testing.T) {
+	params := EvolutionParams{}
+	params.Strategies.ProtagonistAvailableStrategies = []Strategy{StrategyReplaceBranch}
+	params.Reproduction.ProbabilityOfMutation = 1
+
+	parents := []*Individual{
+		{AverageFitness: 0.2, Strategy: []Strategy{StrategyDeleteMalicious}},
+		{AverageFitness: 0.6, Strategy: []Strategy{StrategyDeleteMalicious}},
+		{AverageFitness: 0.4, Strategy: []Strategy{StrategyDeleteMalicious}},
+	}
+	children := []*Individual{
+		{Strategy: []Strategy{StrategyDeleteMalicious}},
+		{Strategy: []Strategy{StrategyDeleteMalicious}},
+		{Strategy: []Strategy{StrategyDeleteMalicious}},
+	}
+	if _, _, err := Mutate(parents, children, IndividualProtagonist, params); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []float64{0.2, 0.6, 0.4} {
+		if !parents[i].isMutated || parents[i].mutationBaseline != want {
+			t.Errorf("parent %d baseline = %v %v, want mutated with %v", i, parents[i].isMutated,
+				parents[i].mutationBaseline, want)
+		}
+	}
+	for i, want := range []float64{0.6, 0.6, 0.4} {
+		if !children[i].isMutated || children[i].mutationBaseline != want {
+			t.Errorf("child %d baseline = %v %v, want mutated with %v", i, children[i].isMutated,
+				children[i].mutationBaseline, want)
+		}
+	}
+
+	params.Reproduction.ProbabilityOfMutation = 0
+	if _, _, err := Mutate(parents, children, IndividualProtagonist, params); err != nil {
+		t.Fatal(err)
+	}
+	if parents[0].isMutated || children[0].isMutated {
+		t.Errorf("Mutate() should clear the mutation of individuals that are not mutated again")
+	}
+
+	params.Reproduction.MutationRate = MutationRate{Type: MutationRateSelfAdaptive, MinRate: 0.1, MaxRate: 0.3}
+	if _, _, err := Mutate(parents, children, IndividualProtagonist, params); err != nil {
+		t.Fatal(err)
+	}
+	for _, individual := range append(parents, children...) {
+		if individual.MutationRate < 0.1 || individual.MutationRate > 0.3 {
+			t.Errorf("Mutate() self-adapted rate = %v, want within [0.1, 0.3]", individual.MutationRate)
+		}
+	}
+}
+
+func TestStrategies_StrategyCountBounds(t *testing.T) {
+	strategies := Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 6, ProtagonistMinStrategyCount: 2,
+		ProtagonistMaxStrategyCount: 10}
+	tests := []struct {
+		name                     string
+		kind                     int
+		wantMinimum, wantMaximum int
+	}{
+		{"defaults", IndividualAntagonist, 1, 4},
+		{"explicit", IndividualProtagonist, 2, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMinimum, gotMaximum := strategies.StrategyCountBounds(tt.kind)
+			if gotMinimum != tt.wantMinimum || gotMaximum != tt.wantMaximum {
+				t.Errorf("StrategyCountBounds() = %d %d, want %d %d", gotMinimum, gotMaximum, tt.wantMinimum,
+					tt.wantMaximum)
+			}
+		})
+	}
+}
+
+func Test_validateGenome(t *testing.T) {
+	tests := []struct {
+		name         string
+		strategies   Strategies
+		reproduction Reproduction
+		wantErr      bool
+	}{
+		{"defaults", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4}, Reproduction{}, false},
+		{"bounds", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4, AntagonistMinStrategyCount: 2,
+			AntagonistMaxStrategyCount: 8}, Reproduction{}, false},
+		{"count below min", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4,
+			ProtagonistMinStrategyCount: 5, ProtagonistMaxStrategyCount: 8}, Reproduction{}, true},
+		{"count above max", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4,
+			AntagonistMaxStrategyCount: 3}, Reproduction{}, true},
+		{"operators", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4},
+			Reproduction{MutationOperators: AllMutationOperators}, false},
+		{"unknown operator", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4},
+			Reproduction{MutationOperators: []string{MutationInsert, "MutationUnknown"}}, true},
+		{"weights", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4},
+			Reproduction{MutationOperators: []string{MutationInsert, MutationSwap},
+				MutationOperatorWeights: []float64{0, 2}}, false},
+		{"missing weight", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4},
+			Reproduction{MutationOperators: []string{MutationInsert, MutationSwap},
+				MutationOperatorWeights: []float64{1}}, true},
+		{"negative weight", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4},
+			Reproduction{MutationOperators: []string{MutationInsert, MutationSwap},
+				MutationOperatorWeights: []float64{-1, 2}}, true},
+		{"zero weights", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4},
+			Reproduction{MutationOperators: []string{MutationInsert, MutationSwap},
+				MutationOperatorWeights: []float64{0, 0}}, true},
+		{"strategy weights", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4,
+			AntagonistAvailableStrategies: []Strategy{StrategyFellTree, StrategySkip},
+			AntagonistStrategyWeights:     []float64{0.1, 1}}, Reproduction{}, false},
+		{"missing strategy weight", Strategies{AntagonistStrategyCount: 4, ProtagonistStrategyCount: 4,
+			ProtagonistAvailableStrategies: []Strategy{StrategyFellTree, StrategySkip},
+			ProtagonistStrategyWeights:     []float64{0.1}}, Reproduction{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateGenome(tt.strategies, tt.reproduction); (err != nil) != tt.wantErr {
+				t.Errorf("validateGenome() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
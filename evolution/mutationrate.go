@@ -0,0 +1,183 @@
+package evolution
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+const (
+	// MutationRateFixed mutates with Reproduction.ProbabilityOfMutation throughout evolution.
+	MutationRateFixed = "MutationRateFixed"
+	// MutationRateSchedule moves the rate linearly from Reproduction.ProbabilityOfMutation in the first generation to
+	// MutationRate.FinalRate in the final generation.
+	MutationRateSchedule = "MutationRateSchedule"
+	// MutationRateOneFifth raises the rate of a population whenever more than a fifth of its mutations were
+	// successful in the previous generation and lowers it whenever fewer were, see OneFifthSuccessRule.
+	MutationRateOneFifth = "MutationRateOneFifth"
+	// MutationRateSelfAdaptive encodes a mutation rate in every individual, which is perturbed before the individual
+	// is mutated and inherited by its children, see SelfAdaptMutationRate.
+	MutationRateSelfAdaptive = "MutationRateSelfAdaptive"
+)
+
+const (
+	DefaultMinMutationRate              = 0.001
+	DefaultMutationRateAdaptationFactor = 0.85
+	DefaultMutationRateLearningRate     = 0.2
+	// oneFifth is the success ratio that keeps the rate unchanged under the one-fifth success rule.
+	oneFifth = 0.2
+)
+
+// ScheduledMutationRate interpolates linearly between the initialRate in generation 0 and the finalRate in the last
+// of the given number of generations.
+func ScheduledMutationRate(initialRate, finalRate float64, generation, generations int) float64 {
+	if generations < 2 || generation >= generations-1 {
+		return finalRate
+	}
+	progress := float64(generation) / float64(generations-1)
+	return initialRate + (finalRate-initialRate)*progress
+}
+
+// OneFifthSuccessRule divides the rate by the factor if more than a fifth of mutations were successful and multiplies
+// it by the factor if fewer were. Many successes suggest the population can afford to explore further,
+// while few suggest it is close to an optimum that mutation keeps disrupting.
+func OneFifthSuccessRule(rate, successRatio, factor float64) float64 {
+	if successRatio > oneFifth {
+		return rate / factor
+	}
+	if successRatio < oneFifth {
+		return rate * factor
+	}
+	return rate
+}
+
+// SelfAdaptMutationRate perturbs the rate with log-normal noise of the given learning rate, the rate therefore
+// stays positive and is as likely to halve as it is to double.
+func SelfAdaptMutationRate(rate, learningRate float64) float64 {
+	return rate * math.Exp(learningRate*rand.NormFloat64())
+}
+
+// MutationSuccessRatio returns the fraction of the mutated individuals in the population that are fitter than the
+// individual or parents they were mutated from, and how many were mutated.
+func MutationSuccessRatio(population []*Individual) (successRatio float64, mutated int) {
+	successes := 0
+	for _, individual := range population {
+		if !individual.isMutated {
+			continue
+		}
+		mutated++
+		if individual.AverageFitness > individual.mutationBaseline {
+			successes++
+		}
+	}
+	if mutated == 0 {
+		return 0, 0
+	}
+	return float64(successes) / float64(mutated), mutated
+}
+
+// IsAdaptive returns true if the mutation rate changes during evolution.
+func (m MutationRate) IsAdaptive() bool {
+	return m.Type != "" && m.Type != MutationRateFixed
+}
+
+func (m MutationRate) clamp(rate float64) float64 {
+	minRate, maxRate := m.MinRate, m.MaxRate
+	if minRate <= 0 {
+		minRate = DefaultMinMutationRate
+	}
+	if maxRate <= 0 {
+		maxRate = 1
+	}
+	return math.Max(minRate, math.Min(maxRate, rate))
+}
+
+func (m MutationRate) adaptationFactor() float64 {
+	if m.AdaptationFactor <= 0 {
+		return DefaultMutationRateAdaptationFactor
+	}
+	return m.AdaptationFactor
+}
+
+func (m MutationRate) learningRate() float64 {
+	if m.LearningRate <= 0 {
+		return DefaultMutationRateLearningRate
+	}
+	return m.LearningRate
+}
+
+// validate checks that the parameters of the selected mutation rate are usable.
+func (m MutationRate) validate() error {
+	switch m.Type {
+	case "", MutationRateFixed, MutationRateSchedule, MutationRateOneFifth, MutationRateSelfAdaptive:
+	default:
+		return fmt.Errorf("unknown mutation rate type %q", m.Type)
+	}
+	if m.MinRate < 0 || m.MaxRate < 0 || m.MaxRate > 1 || (m.MaxRate > 0 && m.MinRate > m.MaxRate) {
+		return fmt.Errorf("MinRate and MaxRate must satisfy 0 <= MinRate <= MaxRate <= 1")
+	}
+	if m.Type == MutationRateSchedule && (m.FinalRate < 0 || m.FinalRate > 1) {
+		return fmt.Errorf("FinalRate must be between 0 and 1 when using MutationRateSchedule")
+	}
+	if m.AdaptationFactor < 0 || m.AdaptationFactor >= 1 {
+		return fmt.Errorf("AdaptationFactor must be between 0 and 1")
+	}
+	if m.LearningRate < 0 {
+		return fmt.Errorf("LearningRate cannot be negative")
+	}
+	return nil
+}
+
+// adaptMutationRate sets the rate the population of the given kind is mutated with in this generation and records
+// it as the generation's AntagonistMutationRate or ProtagonistMutationRate. Under MutationRateSelfAdaptive the
+// recorded rate is the mean of the individuals' own rates.
+func (g *Generation) adaptMutationRate(population []*Individual, kind int) {
+	params := g.engine.Parameters
+	mutationRate := params.Reproduction.MutationRate
+	rate := params.Reproduction.ProbabilityOfMutation
+
+	switch mutationRate.Type {
+	case MutationRateSchedule:
+		rate = mutationRate.clamp(ScheduledMutationRate(rate, mutationRate.FinalRate, g.count,
+			CalculateGenerationSize(params)))
+	case MutationRateOneFifth:
+		if previous := g.engine.mutationRates[kind]; previous > 0 {
+			rate = previous
+		}
+		if successRatio, mutated := MutationSuccessRatio(population); mutated > 0 {
+			rate = mutationRate.clamp(OneFifthSuccessRule(rate, successRatio, mutationRate.adaptationFactor()))
+		}
+		g.engine.mutationRates[kind] = rate
+	case MutationRateSelfAdaptive:
+		if len(population) > 0 {
+			total := 0.0
+			for _, individual := range population {
+				if individual.MutationRate > 0 {
+					total += individual.MutationRate
+				} else {
+					total += rate
+				}
+			}
+			rate = total / float64(len(population))
+		}
+	}
+
+	if kind == IndividualAntagonist {
+		g.AntagonistMutationRate = rate
+	} else {
+		g.ProtagonistMutationRate = rate
+	}
+}
+
+// mutationRate returns the rate set by adaptMutationRate, or Reproduction.ProbabilityOfMutation if it has not been
+// set for this generation.
+func (g *Generation) mutationRate(kind int) float64 {
+	rate := g.ProtagonistMutationRate
+	if kind == IndividualAntagonist {
+		rate = g.AntagonistMutationRate
+	}
+	if rate <= 0 {
+		return g.engine.Parameters.Reproduction.ProbabilityOfMutation
+	}
+	return rate
+}
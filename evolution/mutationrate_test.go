@@ -0,0 +1,157 @@
+package evolution
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScheduledMutationRate(t *testing.T) {
+	tests := []struct {
+		name        string
+		generation  int
+		generations int
+		want        float64
+	}{
+		{"first", 0, 5, 0.5},
+		{"middle", 2, 5, 0.3},
+		{"last", 4, 5, 0.1},
+		{"past last", 7, 5, 0.1},
+		{"single generation", 0, 1, 0.1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScheduledMutationRate(0.5, 0.1, tt.generation, tt.generations); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ScheduledMutationRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOneFifthSuccessRule(t *testing.T) {
+	tests := []struct {
+		name         string
+		successRatio float64
+		want         float64
+	}{
+		{"more successes", 0.5, 0.4},
+		{"fewer successes", 0.1, 0.1},
+		{"one fifth", 0.2, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OneFifthSuccessRule(0.2, tt.successRatio, 0.5); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("OneFifthSuccessRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMutationSuccessRatio(t *testing.T) {
+	population := []*Individual{
+		{AverageFitness: 0.5, isMutated: true, mutationBaseline: 0.2},
+		{AverageFitness: 0.1, isMutated: true, mutationBaseline: 0.2},
+		{AverageFitness: 0.2, isMutated: true, mutationBaseline: 0.2},
+		{AverageFitness: 0.9, isMutated: true, mutationBaseline: 0.2},
+		{AverageFitness: 0.9},
+	}
+	successRatio, mutated := MutationSuccessRatio(population)
+	if successRatio != 0.5 || mutated != 4 {
+		t.Errorf("MutationSuccessRatio() = %v %d, want 0.5 4", successRatio, mutated)
+	}
+	if successRatio, mutated := MutationSuccessRatio(population[4:]); successRatio != 0 || mutated != 0 {
+		t.Errorf("MutationSuccessRatio() = %v %d, want 0 0 when nothing was mutated", successRatio, mutated)
+	}
+}
+
+func TestSelfAdaptMutationRate(t *testing.T) {
+	mutationRate := MutationRate{Type: MutationRateSelfAdaptive, MinRate: 0.05, MaxRate: 0.5}
+	changed := false
+	for i := 0; i < 100; i++ {
+		got := mutationRate.clamp(SelfAdaptMutationRate(0.2, 1))
+		if got < 0.05 || got > 0.5 {
+			t.Fatalf("SelfAdaptMutationRate() = %v, want within [0.05, 0.5]", got)
+		}
+		changed = changed || got != 0.2
+	}
+	if !changed {
+		t.Errorf("SelfAdaptMutationRate() never changed the rate")
+	}
+	if got := SelfAdaptMutationRate(0.2, 0); got != 0.2 {
+		t.Errorf("SelfAdaptMutationRate() = %v, want 0.2 with a learning rate of 0", got)
+	}
+}
+
+func TestMutationRate_validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		mutationRate MutationRate
+		wantErr      bool
+	}{
+		{"default", MutationRate{}, false},
+		{"unknown", MutationRate{Type: "MutationRateUnknown"}, true},
+		{"schedule", MutationRate{Type: MutationRateSchedule, FinalRate: 0.05}, false},
+		{"schedule final > 1", MutationRate{Type: MutationRateSchedule, FinalRate: 2}, true},
+		{"one fifth", MutationRate{Type: MutationRateOneFifth, AdaptationFactor: 0.9}, false},
+		{"one fifth factor >= 1", MutationRate{Type: MutationRateOneFifth, AdaptationFactor: 1}, true},
+		{"min > max", MutationRate{Type: MutationRateSelfAdaptive, MinRate: 0.5, MaxRate: 0.2}, true},
+		{"max > 1", MutationRate{Type: MutationRateSelfAdaptive, MaxRate: 2}, true},
+		{"negative learning rate", MutationRate{Type: MutationRateSelfAdaptive, LearningRate: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.mutationRate.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("MutationRate.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeneration_adaptMutationRate(t *testing.T) {
+	successful := []*Individual{
+		{AverageFitness: 1, isMutated: true, mutationBaseline: 0},
+		{AverageFitness: 1, isMutated: true, mutationBaseline: 0},
+	}
+	unsuccessful := []*Individual{
+		{AverageFitness: 0, isMutated: true, mutationBaseline: 1},
+		{AverageFitness: 0, isMutated: true, mutationBaseline: 1},
+	}
+	tests := []struct {
+		name         string
+		mutationRate MutationRate
+		count        int
+		populations  [][]*Individual
+		want         float64
+	}{
+		{"fixed", MutationRate{}, 3, [][]*Individual{successful}, 0.2},
+		{"schedule", MutationRate{Type: MutationRateSchedule, FinalRate: 0.1}, 2, [][]*Individual{successful},
+			0.15},
+		{"one fifth successful", MutationRate{Type: MutationRateOneFifth, AdaptationFactor: 0.5}, 0,
+			[][]*Individual{successful, successful}, 0.8},
+		{"one fifth unsuccessful", MutationRate{Type: MutationRateOneFifth, AdaptationFactor: 0.5}, 0,
+			[][]*Individual{unsuccessful, unsuccessful}, 0.05},
+		{"one fifth clamped", MutationRate{Type: MutationRateOneFifth, AdaptationFactor: 0.5, MaxRate: 0.3}, 0,
+			[][]*Individual{successful, successful}, 0.3},
+		{"self adaptive", MutationRate{Type: MutationRateSelfAdaptive}, 0,
+			[][]*Individual{{{MutationRate: 0.4}, {MutationRate: 0.1}, {}, {}}}, 0.225},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := EvolutionParams{GenerationsCount: 5}
+			params.Reproduction.ProbabilityOfMutation = 0.2
+			params.Reproduction.MutationRate = tt.mutationRate
+			engine := &EvolutionEngine{Parameters: params}
+			var got float64
+			for _, population := range tt.populations {
+				g := &Generation{engine: engine, count: tt.count}
+				g.adaptMutationRate(population, IndividualProtagonist)
+				got = g.mutationRate(IndividualProtagonist)
+				if g.AntagonistMutationRate != 0 {
+					t.Fatalf("adaptMutationRate() set the antagonist rate when adapting protagonists")
+				}
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("adaptMutationRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
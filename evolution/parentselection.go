@@ -2,7 +2,10 @@ package evolution
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"sort"
+	"strings"
 )
 
 /**
@@ -23,9 +26,27 @@ Selection is the stage of a genetic algorithm in which individual genomes are ch
 */
 
 const (
-	ParentSelectionTournament           = "ParentSelectionTournament" // ID for Tournament Selection
-	ParentSelectionElitism              = "ParentSelectionElitism"    //ID for elitism
-	ParentSelectionFitnessProportionate = 2
+	ParentSelectionTournament           = "ParentSelectionTournament"           // ID for Tournament Selection
+	ParentSelectionElitism              = "ParentSelectionElitism"              //ID for elitism
+	ParentSelectionFitnessProportionate = "ParentSelectionFitnessProportionate" // ID for roulette wheel selection
+	// ParentSelectionStochasticUniversalSampling is the ID for stochastic universal sampling
+	ParentSelectionStochasticUniversalSampling = "ParentSelectionStochasticUniversalSampling"
+	ParentSelectionLinearRank                  = "ParentSelectionLinearRank"      // ID for linear rank selection
+	ParentSelectionExponentialRank             = "ParentSelectionExponentialRank" // ID for exponential rank selection
+	ParentSelectionBoltzmann                   = "ParentSelectionBoltzmann"       // ID for Boltzmann selection
+	ParentSelectionTruncation                  = "ParentSelectionTruncation"      // ID for truncation selection
+	ParentSelectionLexicase                    = "ParentSelectionLexicase"        // ID for lexicase selection
+	ParentSelectionEpsilonLexicase             = "ParentSelectionEpsilonLexicase" // ID for epsilon-lexicase selection
+
+	// DefaultSelectionPressure is used by rank selection if ParentSelection.SelectionPressure is not set
+	DefaultSelectionPressure = 1.5
+	// DefaultBoltzmannTemperature is used by Boltzmann selection if ParentSelection.Temperature is not set
+	DefaultBoltzmannTemperature = 1.0
+	// DefaultTruncationRatio is used by truncation selection if ParentSelection.TruncationRatio is not set
+	DefaultTruncationRatio = 0.5
+
+	// minimumBoltzmannTemperature stops a cooling schedule from reaching 0
+	minimumBoltzmannTemperature = 1e-6
 )
 
 // TournamentSelection is a process whereby a random set of individuals from the population are selected,
@@ -84,15 +105,364 @@ func tournamentSelect(selectedIndividuals []*Individual) (*Individual, error) {
 
 // Elitism is an evolutionary process where only the top (
 // n) individuals based on eliteCount are selected based on their Fitness.
-// In essence it ranks the individuals based on Fitness, then returns the top (n)
-func Elitism(population []*Individual, isMoreFitnessBetter bool) ([]*Individual, error) {
-	return nil, nil
+// In essence it ranks the individuals based on Fitness, then returns the top (n) repeatedly in order of rank until
+// the returned parents are as many as the population. Repeated elites are clones so they can be mutated
+// independently.
+func Elitism(population []*Individual, eliteCount int, isMoreFitnessBetter bool) ([]*Individual, error) {
+	if population == nil {
+		return nil, fmt.Errorf("Elitism | population cannot be nil")
+	}
+	if len(population) < 1 {
+		return nil, fmt.Errorf("Elitism | population cannot be empty")
+	}
+	if eliteCount < 1 || eliteCount > len(population) {
+		return nil, fmt.Errorf("Elitism | eliteCount must be between 1 and the population size %d, got %d",
+			len(population), eliteCount)
+	}
+
+	ranked := make([]*Individual, len(population))
+	copy(ranked, population)
+	ranked, err := SortIndividuals(ranked, isMoreFitnessBetter)
+	if err != nil {
+		return nil, err
+	}
+
+	newPop := make([]*Individual, len(population))
+	for i := range newPop {
+		if i < eliteCount {
+			newPop[i] = ranked[i]
+			continue
+		}
+		elite, err := ranked[i%eliteCount].Clone()
+		if err != nil {
+			return nil, err
+		}
+		newPop[i] = &elite
+	}
+	return newPop, nil
 }
 
 // Fitness Proportionate Selection is one of the most popular ways of parent selection.
 // In this every individual can become a parent with a probability which is proportional to its Fitness.
 // Therefore, fitter individuals have a higher chance of mating and propagating their features to the next Generation.
 // Therefore, such a selection Strategy applies a selection pressure to the more fit individuals in the population, evolving better individuals over time.
+// Each parent is chosen by an independent spin of a roulette wheel, see shiftedFitness for how negative fitness is
+// handled.
 func FitnessProportionateSelection(population []*Individual) ([]*Individual, error) {
-	return nil, nil
+	weights, total, err := shiftedFitness(population)
+	if err != nil {
+		return nil, err
+	}
+
+	newPop := make([]*Individual, len(population))
+	for i := range newPop {
+		newPop[i] = population[spinWheel(weights, total*rand.Float64())]
+	}
+	return newPop, nil
+}
+
+// StochasticUniversalSampling selects all parents with a single spin of a roulette wheel that has as many evenly spaced
+// pointers as the population. Unlike FitnessProportionateSelection,
+// an individual is selected close to its expected number of times, which reduces the bias of repeated spins.
+// The selected parents are shuffled so that crossover does not always pair neighbouring individuals.
+func StochasticUniversalSampling(population []*Individual) ([]*Individual, error) {
+	weights, total, err := shiftedFitness(population)
+	if err != nil {
+		return nil, err
+	}
+
+	distance := total / float64(len(population))
+	start := distance * rand.Float64()
+	newPop := make([]*Individual, len(population))
+	for i := range newPop {
+		newPop[i] = population[spinWheel(weights, start+float64(i)*distance)]
+	}
+	rand.Shuffle(len(newPop), func(i, j int) {
+		newPop[i], newPop[j] = newPop[j], newPop[i]
+	})
+	return newPop, nil
+}
+
+// shiftedFitness returns the selection weight of each individual and their sum.
+// Fitness in this engine ranges from the DivideByZeroPenalty up to 1 so the fitness values are shifted by the worst
+// fitness in the population, the worst individual has a weight of 0. NaN fitness is treated as the worst.
+// If all individuals are equally fit they are given equal weight.
+func shiftedFitness(population []*Individual) (weights []float64, total float64, err error) {
+	if population == nil {
+		return nil, 0, fmt.Errorf("shiftedFitness | population cannot be nil")
+	}
+	if len(population) < 1 {
+		return nil, 0, fmt.Errorf("shiftedFitness | population cannot be empty")
+	}
+
+	worst := math.Inf(1)
+	for i := range population {
+		if !math.IsNaN(population[i].AverageFitness) {
+			worst = math.Min(worst, population[i].AverageFitness)
+		}
+	}
+
+	weights = make([]float64, len(population))
+	for i := range population {
+		if !math.IsNaN(population[i].AverageFitness) {
+			weights[i] = population[i].AverageFitness - worst
+		}
+		total += weights[i]
+	}
+	if total <= 0 || math.IsInf(total, 0) || math.IsNaN(total) {
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = float64(len(weights))
+	}
+	return weights, total, nil
+}
+
+// spinWheel returns the index of the individual whose slice of the wheel contains the pointer.
+func spinWheel(weights []float64, pointer float64) int {
+	cumulative := 0.0
+	for i := range weights {
+		cumulative += weights[i]
+		if pointer < cumulative {
+			return i
+		}
+	}
+	// Rounding may leave the pointer just past the end of the wheel
+	for i := len(weights) - 1; i > 0; i-- {
+		if weights[i] > 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// LinearRankSelection selects parents with a probability that depends linearly on their rank rather than their fitness,
+// so the selection pressure does not collapse when the fitness values are close together.
+// The selectionPressure lies in [1, 2] and is the expected number of times the fittest individual is selected,
+// the least fit individual is expected to be selected 2 - selectionPressure times.
+func LinearRankSelection(population []*Individual, selectionPressure float64) ([]*Individual, error) {
+	if selectionPressure < 1 || selectionPressure > 2 {
+		return nil, fmt.Errorf("LinearRankSelection | selectionPressure must be between 1 and 2, got %.2f",
+			selectionPressure)
+	}
+	ranks, err := rankIndividuals(population)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]float64, len(population))
+	for i := range population {
+		weights[i] = 2 - selectionPressure
+		if len(population) > 1 {
+			weights[i] += 2 * (selectionPressure - 1) * float64(ranks[i]) / float64(len(population)-1)
+		}
+	}
+	return selectByWeights(population, weights), nil
+}
+
+// ExponentialRankSelection selects parents with a probability that grows exponentially with their rank.
+// The selectionPressure must be at least 1 and is how many times more likely the fittest individual is to be
+// selected than the least fit.
+func ExponentialRankSelection(population []*Individual, selectionPressure float64) ([]*Individual, error) {
+	if selectionPressure < 1 {
+		return nil, fmt.Errorf("ExponentialRankSelection | selectionPressure must be at least 1, got %.2f",
+			selectionPressure)
+	}
+	ranks, err := rankIndividuals(population)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]float64, len(population))
+	for i := range population {
+		weights[i] = 1
+		if len(population) > 1 {
+			weights[i] = math.Pow(selectionPressure, float64(ranks[i])/float64(len(population)-1))
+		}
+	}
+	return selectByWeights(population, weights), nil
+}
+
+// BoltzmannSelection selects parents with a probability proportional to exp(fitness / temperature).
+// A high temperature selects almost uniformly, as it cools the fittest individuals dominate.
+// See BoltzmannTemperature for the temperature schedule.
+func BoltzmannSelection(population []*Individual, temperature float64) ([]*Individual, error) {
+	if population == nil {
+		return nil, fmt.Errorf("BoltzmannSelection | population cannot be nil")
+	}
+	if len(population) < 1 {
+		return nil, fmt.Errorf("BoltzmannSelection | population cannot be empty")
+	}
+	if temperature <= 0 {
+		return nil, fmt.Errorf("BoltzmannSelection | temperature must be greater than 0, got %.2f", temperature)
+	}
+
+	// Fitness is offset by the best fitness so the exponent never overflows
+	best := math.Inf(-1)
+	for i := range population {
+		if !math.IsNaN(population[i].AverageFitness) {
+			best = math.Max(best, population[i].AverageFitness)
+		}
+	}
+	weights := make([]float64, len(population))
+	for i := range population {
+		if !math.IsNaN(population[i].AverageFitness) {
+			weights[i] = math.Exp((population[i].AverageFitness - best) / temperature)
+		}
+	}
+	return selectByWeights(population, weights), nil
+}
+
+// BoltzmannTemperature returns the temperature of the given generation. The temperature starts at
+// ParentSelection.Temperature and cools by ParentSelection.TemperatureDecay every generation.
+func BoltzmannTemperature(parentSelection ParentSelection, generation int) float64 {
+	temperature := parentSelection.Temperature
+	if temperature <= 0 {
+		temperature = DefaultBoltzmannTemperature
+	}
+	temperature = temperature * math.Pow(1-parentSelection.TemperatureDecay, float64(generation))
+	return math.Max(temperature, minimumBoltzmannTemperature)
+}
+
+// TruncationSelection selects parents uniformly at random from the fittest truncationRatio of the population.
+// The rest of the population does not reproduce.
+func TruncationSelection(population []*Individual, truncationRatio float64) ([]*Individual, error) {
+	if truncationRatio <= 0 || truncationRatio > 1 {
+		return nil, fmt.Errorf("TruncationSelection | truncationRatio must be in (0, 1], got %.2f", truncationRatio)
+	}
+	if population == nil {
+		return nil, fmt.Errorf("TruncationSelection | population cannot be nil")
+	}
+	if len(population) < 1 {
+		return nil, fmt.Errorf("TruncationSelection | population cannot be empty")
+	}
+
+	ranked := make([]*Individual, len(population))
+	copy(ranked, population)
+	ranked, err := SortIndividuals(ranked, true)
+	if err != nil {
+		return nil, err
+	}
+
+	truncated := int(math.Ceil(truncationRatio * float64(len(population))))
+	newPop := make([]*Individual, len(population))
+	for i := range newPop {
+		newPop[i] = ranked[rand.Intn(truncated)]
+	}
+	return newPop, nil
+}
+
+// rankIndividuals returns the rank of each individual by fitness,
+// 0 is the least fit and len(population) - 1 the fittest. NaN fitness ranks lowest.
+func rankIndividuals(population []*Individual) ([]int, error) {
+	if population == nil {
+		return nil, fmt.Errorf("rankIndividuals | population cannot be nil")
+	}
+	if len(population) < 1 {
+		return nil, fmt.Errorf("rankIndividuals | population cannot be empty")
+	}
+
+	order := make([]int, len(population))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := population[order[i]].AverageFitness, population[order[j]].AverageFitness
+		if math.IsNaN(a) || math.IsNaN(b) {
+			return math.IsNaN(a) && !math.IsNaN(b)
+		}
+		return a < b
+	})
+
+	ranks := make([]int, len(population))
+	for rank, index := range order {
+		ranks[index] = rank
+	}
+	return ranks, nil
+}
+
+// selectByWeights spins a roulette wheel with the given weights once for every parent.
+func selectByWeights(population []*Individual, weights []float64) []*Individual {
+	total := 0.0
+	for i := range weights {
+		total += weights[i]
+	}
+
+	newPop := make([]*Individual, len(population))
+	for i := range newPop {
+		newPop[i] = population[spinWheel(weights, total*rand.Float64())]
+	}
+	return newPop
+}
+
+// validate checks that the parameters of the selected parent selection are usable with the population size.
+func (p ParentSelection) validate(eachPopulationSize int) error {
+	switch p.Type {
+	case ParentSelectionElitism:
+		if p.EliteCount < 1 || p.EliteCount > eachPopulationSize {
+			return fmt.Errorf("EliteCount must be between 1 and the population size when using elitism")
+		}
+	case ParentSelectionLinearRank:
+		if p.SelectionPressure != 0 && (p.SelectionPressure < 1 || p.SelectionPressure > 2) {
+			return fmt.Errorf("SelectionPressure must be between 1 and 2 when using linear rank selection")
+		}
+	case ParentSelectionExponentialRank:
+		if p.SelectionPressure != 0 && p.SelectionPressure < 1 {
+			return fmt.Errorf("SelectionPressure must be at least 1 when using exponential rank selection")
+		}
+	case ParentSelectionBoltzmann:
+		if p.Temperature < 0 {
+			return fmt.Errorf("Temperature cannot be negative when using Boltzmann selection")
+		}
+		if p.TemperatureDecay < 0 || p.TemperatureDecay >= 1 {
+			return fmt.Errorf("TemperatureDecay must be in [0, 1) when using Boltzmann selection")
+		}
+	case ParentSelectionTruncation:
+		if p.TruncationRatio < 0 || p.TruncationRatio > 1 {
+			return fmt.Errorf("TruncationRatio must be in (0, 1] when using truncation selection")
+		}
+	case ParentSelectionEpsilonLexicase:
+		if p.Epsilon < 0 {
+			return fmt.Errorf("Epsilon cannot be negative when using epsilon-lexicase selection")
+		}
+	}
+	return nil
+}
+
+// selectionPressure returns the configured selection pressure or DefaultSelectionPressure.
+func (p ParentSelection) selectionPressure() float64 {
+	if p.SelectionPressure == 0 {
+		return DefaultSelectionPressure
+	}
+	return p.SelectionPressure
+}
+
+// truncationRatio returns the configured truncation ratio or DefaultTruncationRatio.
+func (p ParentSelection) truncationRatio() float64 {
+	if p.TruncationRatio == 0 {
+		return DefaultTruncationRatio
+	}
+	return p.TruncationRatio
+}
+
+// toString names the parent selection type and the parameters it uses for EvolutionParams.ToString, so runs that
+// differ in any of them are written to different folders. Tournament selection is described by TournamentSize alone
+// and returns an empty string.
+func (p ParentSelection) toString() string {
+	name := strings.TrimPrefix(p.Type, "ParentSelection")
+	switch p.Type {
+	case ParentSelectionTournament:
+		return ""
+	case ParentSelectionElitism:
+		name += fmt.Sprintf("%d", p.EliteCount)
+	case ParentSelectionLinearRank, ParentSelectionExponentialRank:
+		name += fmt.Sprintf("Sp%.2f", p.selectionPressure())
+	case ParentSelectionBoltzmann:
+		name += fmt.Sprintf("T%.2fD%.2f", BoltzmannTemperature(p, 0), p.TemperatureDecay)
+	case ParentSelectionTruncation:
+		name += fmt.Sprintf("R%.2f", p.truncationRatio())
+	case ParentSelectionEpsilonLexicase:
+		name += fmt.Sprintf("E%.2f", p.Epsilon)
+	}
+	return strings.ReplaceAll(name, ".", "")
 }
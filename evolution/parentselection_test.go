@@ -1,6 +1,8 @@
 package evolution
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -65,3 +67,388 @@ func TestTournamentSelection(t *testing.T) {
 		})
 	}
 }
+
+func selectionTestPopulation(fitness ...float64) []*Individual {
+	population := make([]*Individual, len(fitness))
+	for i := range fitness {
+		population[i] = &Individual{Id: string(rune('a' + i)), AverageFitness: fitness[i]}
+	}
+	return population
+}
+
+func selectionCounts(selected []*Individual) map[string]int {
+	counts := map[string]int{}
+	for _, individual := range selected {
+		counts[individual.Id]++
+	}
+	return counts
+}
+
+func TestElitism(t *testing.T) {
+	tests := []struct {
+		name       string
+		population []*Individual
+		eliteCount int
+		want       map[string]int
+		wantErr    bool
+	}{
+		{"nil-population", nil, 1, nil, true},
+		{"empty-population", []*Individual{}, 1, nil, true},
+		{"eliteCount=0", selectionTestPopulation(0.1, 0.2), 0, nil, true},
+		{"eliteCount>population", selectionTestPopulation(0.1, 0.2), 3, nil, true},
+		{"eliteCount=1", selectionTestPopulation(-1, 0.5, 0.2, -0.3), 1, map[string]int{"b": 4}, false},
+		{"eliteCount=2", selectionTestPopulation(-1, 0.5, 0.2, -0.3), 2, map[string]int{"b": 2, "c": 2}, false},
+		{"eliteCount=3", selectionTestPopulation(-1, 0.5, 0.2, -0.3), 3, map[string]int{"b": 2, "c": 1, "d": 1},
+			false},
+		{"eliteCount=population", selectionTestPopulation(-1, 0.5, 0.2, -0.3), 4,
+			map[string]int{"a": 1, "b": 1, "c": 1, "d": 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var order []string
+			for _, individual := range tt.population {
+				order = append(order, individual.Id)
+			}
+
+			got, err := Elitism(tt.population, tt.eliteCount, true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Elitism() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.population) {
+				t.Fatalf("Elitism() returned %d individuals, want %d", len(got), len(tt.population))
+			}
+			counts := selectionCounts(got)
+			for id, want := range tt.want {
+				if counts[id] != want {
+					t.Errorf("Elitism() selected %s %d times, want %d", id, counts[id], want)
+				}
+			}
+			for i := range tt.population {
+				if tt.population[i].Id != order[i] {
+					t.Errorf("Elitism() reordered the population")
+				}
+			}
+			for i := range got {
+				for j := i + 1; j < len(got); j++ {
+					if got[i] == got[j] {
+						t.Errorf("Elitism() returned the same individual at %d and %d, want clones", i, j)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_shiftedFitness(t *testing.T) {
+	tests := []struct {
+		name        string
+		population  []*Individual
+		wantWeights []float64
+		wantErr     bool
+	}{
+		{"nil-population", nil, nil, true},
+		{"empty-population", []*Individual{}, nil, true},
+		{"negative", selectionTestPopulation(-1, -0.5, 0.5), []float64{0, 0.5, 1.5}, false},
+		{"positive", selectionTestPopulation(0.25, 1), []float64{0, 0.75}, false},
+		{"equal", selectionTestPopulation(-1, -1, -1), []float64{1, 1, 1}, false},
+		{"nan", selectionTestPopulation(math.NaN(), -1, 1), []float64{0, 0, 2}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights, total, err := shiftedFitness(tt.population)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("shiftedFitness() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			wantTotal := 0.0
+			for i := range tt.wantWeights {
+				wantTotal += tt.wantWeights[i]
+				if weights[i] != tt.wantWeights[i] {
+					t.Errorf("shiftedFitness() weights = %v, want %v", weights, tt.wantWeights)
+					break
+				}
+			}
+			if total != wantTotal {
+				t.Errorf("shiftedFitness() total = %v, want %v", total, wantTotal)
+			}
+		})
+	}
+}
+
+func TestFitnessProportionateSelection(t *testing.T) {
+	// The selection is not seeded so enough individuals are selected for the ratio to be well within its bounds.
+	population := selectionTestPopulation(-1, 0, 1)
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		selected, err := FitnessProportionateSelection(population)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(selected) != len(population) {
+			t.Fatalf("FitnessProportionateSelection() returned %d individuals, want %d", len(selected),
+				len(population))
+		}
+		for id, count := range selectionCounts(selected) {
+			counts[id] += count
+		}
+	}
+
+	// The shifted weights are 0, 1 and 2 so c should be selected twice as often as b and a never.
+	if counts["a"] != 0 {
+		t.Errorf("FitnessProportionateSelection() selected the worst individual %d times", counts["a"])
+	}
+	if ratio := float64(counts["c"]) / float64(counts["b"]); ratio < 1.8 || ratio > 2.2 {
+		t.Errorf("FitnessProportionateSelection() selected c %.2f times as often as b, want 2", ratio)
+	}
+}
+
+func TestStochasticUniversalSampling(t *testing.T) {
+	rand.Seed(1)
+	population := selectionTestPopulation(-1, -0.5, 0, 0.5)
+	for i := 0; i < 100; i++ {
+		selected, err := StochasticUniversalSampling(population)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The shifted weights are 0, 0.5, 1 and 1.5, so the expected counts are 0, 2/3, 4/3 and 2.
+		counts := selectionCounts(selected)
+		if len(selected) != 4 || counts["a"] != 0 || counts["b"] > 1 || counts["c"] < 1 || counts["c"] > 2 ||
+			counts["d"] != 2 {
+			t.Fatalf("StochasticUniversalSampling() selected %v", counts)
+		}
+	}
+
+	if _, err := StochasticUniversalSampling(nil); err == nil {
+		t.Errorf("StochasticUniversalSampling() on a nil population should return an error")
+	}
+}
+
+func Test_rankIndividuals(t *testing.T) {
+	ranks, err := rankIndividuals(selectionTestPopulation(0.5, math.NaN(), -1, 0.7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{2, 0, 1, 3}
+	for i := range want {
+		if ranks[i] != want[i] {
+			t.Fatalf("rankIndividuals() = %v, want %v", ranks, want)
+		}
+	}
+	if _, err := rankIndividuals(nil); err == nil {
+		t.Errorf("rankIndividuals() on a nil population should return an error")
+	}
+}
+
+// selectionFrequencies runs selection repeatedly and returns how often each individual was selected relative to
+// the least fit individual a. The selection is not seeded so it runs often enough for every frequency to be within a
+// few percent of its expected value.
+func selectionFrequencies(t *testing.T, population []*Individual,
+	selection func([]*Individual) ([]*Individual, error)) map[string]float64 {
+	counts := map[string]int{}
+	for i := 0; i < 20000; i++ {
+		selected, err := selection(population)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(selected) != len(population) {
+			t.Fatalf("selection returned %d individuals, want %d", len(selected), len(population))
+		}
+		for id, count := range selectionCounts(selected) {
+			counts[id] += count
+		}
+	}
+
+	frequencies := map[string]float64{}
+	for id := range counts {
+		frequencies[id] = float64(counts[id]) / float64(counts["a"])
+	}
+	return frequencies
+}
+
+func TestRankSelection(t *testing.T) {
+	// The fitness values are far apart but rank selection only depends on their order
+	population := selectionTestPopulation(-1, -0.99, 0.9, 1)
+	tests := []struct {
+		name      string
+		selection func([]*Individual) ([]*Individual, error)
+		want      map[string]float64
+	}{
+		{"linear-1.5", func(p []*Individual) ([]*Individual, error) { return LinearRankSelection(p, 1.5) },
+			map[string]float64{"b": 1 + 2.0/3, "c": 1 + 4.0/3, "d": 3}},
+		{"linear-1", func(p []*Individual) ([]*Individual, error) { return LinearRankSelection(p, 1) },
+			map[string]float64{"b": 1, "c": 1, "d": 1}},
+		{"exponential-8", func(p []*Individual) ([]*Individual, error) { return ExponentialRankSelection(p, 8) },
+			map[string]float64{"b": 2, "c": 4, "d": 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectionFrequencies(t, population, tt.selection)
+			for id, want := range tt.want {
+				if math.Abs(got[id]-want)/want > 0.15 {
+					t.Errorf("%s selected %.2f times as often as a, want %.2f", id, got[id], want)
+				}
+			}
+		})
+	}
+
+	if _, err := LinearRankSelection(population, 2.5); err == nil {
+		t.Errorf("LinearRankSelection() with a selection pressure above 2 should return an error")
+	}
+	if _, err := ExponentialRankSelection(population, 0.5); err == nil {
+		t.Errorf("ExponentialRankSelection() with a selection pressure below 1 should return an error")
+	}
+}
+
+func TestBoltzmannSelection(t *testing.T) {
+	population := selectionTestPopulation(-1, 0, 0.5, 1)
+	hot := selectionFrequencies(t, population, func(p []*Individual) ([]*Individual, error) {
+		return BoltzmannSelection(p, 100)
+	})
+	if math.Abs(hot["d"]-1) > 0.15 {
+		t.Errorf("BoltzmannSelection() at a high temperature selected d %.2f times as often as a, want 1", hot["d"])
+	}
+	cold := selectionFrequencies(t, population, func(p []*Individual) ([]*Individual, error) {
+		return BoltzmannSelection(p, 1)
+	})
+	if want := math.Exp(2); math.Abs(cold["d"]-want)/want > 0.15 {
+		t.Errorf("BoltzmannSelection() at temperature 1 selected d %.2f times as often as a, want %.2f", cold["d"],
+			want)
+	}
+
+	if _, err := BoltzmannSelection(population, 0); err == nil {
+		t.Errorf("BoltzmannSelection() with a temperature of 0 should return an error")
+	}
+}
+
+func TestBoltzmannTemperature(t *testing.T) {
+	tests := []struct {
+		name            string
+		parentSelection ParentSelection
+		generation      int
+		want            float64
+	}{
+		{"default", ParentSelection{}, 10, DefaultBoltzmannTemperature},
+		{"constant", ParentSelection{Temperature: 4}, 10, 4},
+		{"first-generation", ParentSelection{Temperature: 4, TemperatureDecay: 0.5}, 0, 4},
+		{"cooling", ParentSelection{Temperature: 4, TemperatureDecay: 0.5}, 2, 1},
+		{"minimum", ParentSelection{Temperature: 4, TemperatureDecay: 0.5}, 1000, minimumBoltzmannTemperature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BoltzmannTemperature(tt.parentSelection, tt.generation); got != tt.want {
+				t.Errorf("BoltzmannTemperature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncationSelection(t *testing.T) {
+	rand.Seed(1)
+	population := selectionTestPopulation(-1, 0.5, 0.2, -0.3, 0.9, 0.1, 0, -0.5)
+	tests := []struct {
+		name            string
+		truncationRatio float64
+		want            []string
+		wantErr         bool
+	}{
+		{"ratio=0", 0, nil, true},
+		{"ratio>1", 1.5, nil, true},
+		{"ratio=0.25", 0.25, []string{"e", "b"}, false},
+		{"ratio=0.3", 0.3, []string{"e", "b", "c"}, false},
+		{"ratio=1", 1, []string{"a", "b", "c", "d", "e", "f", "g", "h"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TruncationSelection(population, tt.truncationRatio)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TruncationSelection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(population) {
+				t.Fatalf("TruncationSelection() returned %d individuals, want %d", len(got), len(population))
+			}
+			allowed := map[string]bool{}
+			for _, id := range tt.want {
+				allowed[id] = true
+			}
+			for _, individual := range got {
+				if !allowed[individual.Id] {
+					t.Errorf("TruncationSelection() selected %s, want one of %v", individual.Id, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParentSelection_validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		parentSelection ParentSelection
+		wantErr         bool
+	}{
+		{"tournament", ParentSelection{Type: ParentSelectionTournament, TournamentSize: 3}, false},
+		{"elitism", ParentSelection{Type: ParentSelectionElitism, EliteCount: 4}, false},
+		{"elitism-no-elites", ParentSelection{Type: ParentSelectionElitism}, true},
+		{"elitism-too-many-elites", ParentSelection{Type: ParentSelectionElitism, EliteCount: 9}, true},
+		{"linear-default", ParentSelection{Type: ParentSelectionLinearRank}, false},
+		{"linear-pressure", ParentSelection{Type: ParentSelectionLinearRank, SelectionPressure: 3}, true},
+		{"exponential", ParentSelection{Type: ParentSelectionExponentialRank, SelectionPressure: 3}, false},
+		{"exponential-pressure", ParentSelection{Type: ParentSelectionExponentialRank, SelectionPressure: 0.5}, true},
+		{"boltzmann", ParentSelection{Type: ParentSelectionBoltzmann, Temperature: 2, TemperatureDecay: 0.1}, false},
+		{"boltzmann-decay", ParentSelection{Type: ParentSelectionBoltzmann, TemperatureDecay: 1}, true},
+		{"truncation", ParentSelection{Type: ParentSelectionTruncation, TruncationRatio: 0.5}, false},
+		{"truncation-ratio", ParentSelection{Type: ParentSelectionTruncation, TruncationRatio: 2}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.parentSelection.validate(8); (err != nil) != tt.wantErr {
+				t.Errorf("ParentSelection.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParentSelection_toString(t *testing.T) {
+	tests := []struct {
+		name            string
+		parentSelection ParentSelection
+		want            string
+	}{
+		{"tournament", ParentSelection{Type: ParentSelectionTournament, TournamentSize: 3}, ""},
+		{"elitism", ParentSelection{Type: ParentSelectionElitism, EliteCount: 4}, "Elitism4"},
+		{"roulette", ParentSelection{Type: ParentSelectionFitnessProportionate}, "FitnessProportionate"},
+		{"linear-default", ParentSelection{Type: ParentSelectionLinearRank}, "LinearRankSp150"},
+		{"exponential", ParentSelection{Type: ParentSelectionExponentialRank, SelectionPressure: 2},
+			"ExponentialRankSp200"},
+		{"boltzmann", ParentSelection{Type: ParentSelectionBoltzmann, Temperature: 4, TemperatureDecay: 0.05},
+			"BoltzmannT400D005"},
+		{"truncation", ParentSelection{Type: ParentSelectionTruncation, TruncationRatio: 0.25}, "TruncationR025"},
+		{"epsilon-lexicase", ParentSelection{Type: ParentSelectionEpsilonLexicase, Epsilon: 0.1},
+			"EpsilonLexicaseE010"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.parentSelection.toString(); got != tt.want {
+				t.Errorf("ParentSelection.toString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	params := EvolutionParams{
+		FitnessStrategy: FitnessStrategy{Type: FitnessDualThresholdedRatio},
+		Selection: Selection{
+			Parent:   ParentSelection{Type: ParentSelectionBoltzmann, TemperatureDecay: 0},
+			Survivor: SurvivorSelection{Type: SurvivorSelectionFitnessBased},
+		},
+	}
+	cooling := params
+	cooling.Selection.Parent.TemperatureDecay = 0.05
+	if params.ToString() == cooling.ToString() {
+		t.Errorf("EvolutionParams.ToString() should differ for different parent selection parameters. got: %s",
+			params.ToString())
+	}
+}
@@ -3,6 +3,7 @@ package evolution
 import (
 	"fmt"
 	"github.com/martinomburajr/masters-go/eval"
+	"strconv"
 )
 
 const DeletionTypeMalicious = 1
@@ -28,133 +29,41 @@ func GenerateProgramID(count int) string {
 // to appearance.
 func (p *Program) ApplyStrategy(strategy Strategy, terminals []SymbolicExpression,
 	nonTerminals []SymbolicExpression, depth int) (err error) {
+	strategable, err := StrategyByName(strategy)
+	if err != nil {
+		return err
+	}
+	return strategable.Apply(p.T, StrategyContext{
+		Terminals:    terminals,
+		NonTerminals: nonTerminals,
+		Depth:        depth,
+		Rand:         sharedRand,
+	})
+}
+
+// attachVariable makes a new root from the operator with the current tree on its left and the variable on its right
+// e.g. MultXD on a tree T results in T*x
+func attachVariable(t *DualTree, operator string, variable SymbolicExpression) error {
+	rootExpr := SymbolicExpression{arity: 2, value: operator, kind: 1}
+	root := rootExpr.ToDualTreeNode(RandString(2))
+	right := variable.ToDualTreeNode(RandString(2))
+	tree := &DualTree{root: root}
+	tree.root.right = right
 
-	switch strategy {
-	case StrategyDeleteNonTerminal: // CHANGE TO DeleteNonTerminal
-		err = p.T.DeleteNonTerminal()
-		break
-
-	case StrategyDeleteMalicious:
-		err = p.T.DeleteMalicious()
-		break
-
-	case StrategyDeleteTerminal:
-		err = p.T.DeleteTerminal()
-		break
-
-	case StrategyMutateNonTerminal:
-		err = p.T.MutateNonTerminal(nonTerminals)
-		break
-
-	case StrategyMutateTerminal:
-		err = p.T.MutateTerminal(terminals)
-		break
-
-	case StrategyReplaceBranch:
-		var tree *DualTree
-		tree, err = GenerateRandomTree(depth, terminals, nonTerminals)
-		err = p.T.ReplaceBranch(*tree)
-		break
-
-	case StrategyReplaceBranchX:
-		var tree *DualTree
-		tree, err = GenerateRandomTreeEnforceIndependentVariable(depth, terminals[0], terminals, nonTerminals)
-		err = p.T.ReplaceBranch(*tree)
-		break
-	case StrategyAddRandomSubTree:
-		var tree *DualTree
-		tree, err = GenerateRandomTree(depth, terminals, nonTerminals)
-		err = p.T.AddSubTree(tree)
-		break
-
-	case StrategyAddToLeafX:
-		var tree *DualTree
-		tree, err = GenerateRandomTreeEnforceIndependentVariable(depth, terminals[0], terminals, nonTerminals)
-		err = p.T.AddToLeaf(*tree)
-		break
-
-	case StrategyAddToLeaf:
-		var tree *DualTree
-		tree, err = GenerateRandomTree(depth, terminals, nonTerminals)
-		err = p.T.AddToLeaf(*tree)
-		break
-
-	case StrategyAddTreeWithMult:
-		var tree *DualTree
-		tree, err = GenerateRandomTree(depth, terminals, []SymbolicExpression{{arity: 2, value: "*", kind: 1}})
-		err = p.T.AddToLeaf(*tree)
-		break
-	case StrategyAddTreeWithDiv:
-		var tree *DualTree
-		tree, err = GenerateRandomTree(depth, terminals, []SymbolicExpression{{arity: 2, value: "/", kind: 1}})
-		err = p.T.AddToLeaf(*tree)
-		break
-	case StrategyAddTreeWithSub:
-		var tree *DualTree
-		tree, err = GenerateRandomTree(depth, terminals,
-			[]SymbolicExpression{{arity: 2, value: "-", kind: 1}})
-		err = p.T.AddToLeaf(*tree)
-		break
-
-	case StrategyAddTreeWithAdd:
-		var tree *DualTree
-		tree, err = GenerateRandomTree(depth, terminals,
-			[]SymbolicExpression{{arity: 2, value: "+", kind: 1}})
-		err = p.T.AddToLeaf(*tree)
-		break
-
-	// DETERMINISTIC STRATEGIES
-	case StrategySkip:
-		// Do nothing
-		break
-	case StrategyFellTree:
-		err = p.T.FellTree()
-		break
-	case StrategyMultXD:
-		rootExpr := SymbolicExpression{arity: 2, value: "*", kind: 1}
-		rightExpr := SymbolicExpression{arity: 0, value: "x", kind: 0}
-		root := rootExpr.ToDualTreeNode(RandString(2))
-		right := rightExpr.ToDualTreeNode(RandString(2))
-		tree := &DualTree{root: root}
-		tree.root.right = right
-
-		err = p.T.AttachSubTree(tree)
-		break
-	case StrategyAddXD:
-		rootExpr := SymbolicExpression{arity: 2, value: "+", kind: 1}
-		rightExpr := SymbolicExpression{arity: 0, value: "x", kind: 0}
-		root := rootExpr.ToDualTreeNode(RandString(2))
-		right := rightExpr.ToDualTreeNode(RandString(2))
-		tree := &DualTree{root: root}
-		tree.root.right = right
-
-		err = p.T.AttachSubTree(tree)
-		break
-	case StrategySubXD:
-		rootExpr := SymbolicExpression{arity: 2, value: "-", kind: 1}
-		rightExpr := SymbolicExpression{arity: 0, value: "x", kind: 0}
-		root := rootExpr.ToDualTreeNode(RandString(2))
-		right := rightExpr.ToDualTreeNode(RandString(2))
-		tree := &DualTree{root: root}
-		tree.root.right = right
-
-		err = p.T.AttachSubTree(tree)
-		break
-	case StrategyDivXD:
-		rootExpr := SymbolicExpression{arity: 2, value: "/", kind: 1}
-		rightExpr := SymbolicExpression{arity: 0, value: "x", kind: 0}
-		root := rootExpr.ToDualTreeNode(RandString(2))
-		right := rightExpr.ToDualTreeNode(RandString(2))
-		tree := &DualTree{root: root}
-		tree.root.right = right
-
-		err = p.T.AttachSubTree(tree)
-		break
-
-	default:
-		break
+	return t.AttachSubTree(tree)
+}
+
+// independentVariable selects the variable used by the X strategies e.g. MultXD or AddToLeafX.
+// It is the first variable in the terminals so the deterministic strategies stay deterministic in a multivariate
+// spec. Use an AttachStrategy with an explicit terminal e.g. Attach(*,y,root) to attach another variable.
+// If the terminals contain no variables, x is used.
+func independentVariable(terminals []SymbolicExpression) SymbolicExpression {
+	for i := range terminals {
+		if _, err := strconv.ParseFloat(terminals[i].value, 64); err != nil {
+			return terminals[i]
+		}
 	}
-	return err
+	return SymbolicExpression{arity: 0, value: "x", kind: 0}
 }
 
 // Eval is a simple helper function that takes in an independent variable,
@@ -168,6 +77,16 @@ func (p *Program) EvalMulti(independentVariables IndependentVariableMap, express
 	return EvaluateMathematicalExpression(expressionString, independentVariables)
 }
 
+// Compile builds an evaluator from the programs tree. The evaluator should be built once and reused for every point
+// in the spec, see DualTree.Compile
+func (p *Program) Compile() (eval.Evaluator, error) {
+	if p.T == nil {
+		return nil, fmt.Errorf("program: %v -> treeNode is nil", p.ID)
+	}
+
+	return p.T.Compile()
+}
+
 const (
 	MathErrorGeneral = 0
 	MathErrorInvalid = 1
@@ -75,6 +75,75 @@ func TestEvaluateMathematicalExpression(t *testing.T) {
 	}
 }
 
+func TestProgram_ApplyStrategyXD(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  Strategy
+		terminals []SymbolicExpression
+		operator  string
+		want      []string
+	}{
+		{"MultXD-x", StrategyMultXD, []SymbolicExpression{X1, Const4}, "*", []string{"x"}},
+		{"AddXD-y", StrategyAddXD, []SymbolicExpression{Const4, Y1}, "+", []string{"y"}},
+		{"SubXD-no-variables", StrategySubXD, []SymbolicExpression{Const4}, "-", []string{"x"}},
+		{"DivXD-xyz", StrategyDivXD, []SymbolicExpression{X1, Y1, Z1, Const4}, "/", []string{"x"}},
+		{"MultXD-zxy", StrategyMultXD, []SymbolicExpression{Const4, Z1, X1, Y1}, "*", []string{"z"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				p := &Program{T: TreeT_NT_T_0()}
+				err := p.ApplyStrategy(tt.strategy, tt.terminals, []SymbolicExpression{Add}, 1)
+				if err != nil {
+					t.Fatalf("Program.ApplyStrategy() error = %v", err)
+				}
+				if p.T.root.value != tt.operator {
+					t.Errorf("Program.ApplyStrategy() root = %v, want %v", p.T.root.value, tt.operator)
+				}
+				found := false
+				for _, want := range tt.want {
+					if p.T.root.right.value == want {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Program.ApplyStrategy() variable = %v, want one of %v", p.T.root.right.value, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestProgram_ApplyStrategyDeterministicMultivariate(t *testing.T) {
+	terminals := []SymbolicExpression{Const4, Y1, X1, Z1}
+	tests := []struct {
+		strategy Strategy
+		want     string
+	}{
+		{StrategyMultXD, "(((x)*(4))*(y))"},
+		{StrategyAddXD, "(((x)*(4))+(y))"},
+		{StrategySubXD, "(((x)*(4))-(y))"},
+		{StrategyDivXD, "(((x)*(4))/(y))"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				p := &Program{T: TreeT_NT_T_0()}
+				if err := p.ApplyStrategy(tt.strategy, terminals, []SymbolicExpression{Add}, 1); err != nil {
+					t.Fatal(err)
+				}
+				got, err := p.T.ToMathematicalString()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.want {
+					t.Fatalf("Program.ApplyStrategy(%s) got = %s, want %s", tt.strategy, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
 var mathematicalExpression float64
 
 func BenchmarkEvaluateMathematicalExpression(b *testing.B) {
@@ -0,0 +1,97 @@
+package evolution
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// registry holds the values registered under unique names e.g. the fitness functions, strategies and topologies. It
+// is safe for concurrent use. Its errors are prefixed with the exported function that wraps it, e.g.
+// RegisterStrategy or StrategyByName.
+type registry[K ~string, V any] struct {
+	sync.RWMutex
+	values map[K]V
+	// kind describes a registered value in errors e.g. "strategy".
+	kind string
+	// registerFunc and byNameFunc prefix the errors of register and byName.
+	registerFunc string
+	byNameFunc   string
+}
+
+func newRegistry[K ~string, V any](kind, registerFunc, byNameFunc string) *registry[K, V] {
+	return &registry[K, V]{values: map[K]V{}, kind: kind, registerFunc: registerFunc, byNameFunc: byNameFunc}
+}
+
+// register adds the value under the given name. It returns an error if the name is empty, the value is nil or the
+// name is already taken.
+func (r *registry[K, V]) register(name K, value V) error {
+	if name == "" {
+		return fmt.Errorf("%s | name cannot be empty", r.registerFunc)
+	}
+	if isNil(value) {
+		return fmt.Errorf("%s | %s %q cannot be nil", r.registerFunc, r.kind, name)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.values[name]; ok {
+		return fmt.Errorf("%s | %s %q is already registered", r.registerFunc, r.kind, name)
+	}
+	r.values[name] = value
+	return nil
+}
+
+// registerBuiltIn registers values that ship with the package. It panics if any of them cannot be registered.
+func (r *registry[K, V]) registerBuiltIn(values map[K]V) {
+	for name, value := range values {
+		if err := r.register(name, value); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// lookup returns the value registered under the given name and whether there is one.
+func (r *registry[K, V]) lookup(name K) (V, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	value, ok := r.values[name]
+	return value, ok
+}
+
+// byName returns the value registered under the given name or an error if there is none.
+func (r *registry[K, V]) byName(name K) (V, error) {
+	value, ok := r.lookup(name)
+	if !ok {
+		return value, fmt.Errorf("%s | unknown %s %q", r.byNameFunc, r.kind, name)
+	}
+	return value, nil
+}
+
+// names returns the sorted names of all registered values.
+func (r *registry[K, V]) names() []K {
+	r.RLock()
+	defer r.RUnlock()
+	names := make([]K, 0, len(r.values))
+	for name := range r.values {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// isNil reports whether the value is nil, including nil functions and pointers held by an interface.
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
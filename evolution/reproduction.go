@@ -13,6 +13,9 @@ const (
 	CrossoverFixedPoint  = "CrossoverFixedPoint"
 	CrossoverKPoint      = "CrossoverKPoint"
 	CrossoverUniform     = "CrossoverUniform"
+	// CrossoverCutAndSplice cuts each parent at an independent point and swaps the tails, so the children's genome
+	// lengths may differ from their parents'.
+	CrossoverCutAndSplice = "CrossoverCutAndSplice"
 )
 
 // CrossoverSinglePoint performs a single-point crossover that is dictated by the crossover percentage float.
@@ -43,6 +46,8 @@ func SinglePointCrossover(parentA, parentB *Individual) (childA Individual,
 	childA.FitnessStdDev = 0
 	childA.FitnessVariance = 0
 	childA.Deltas = nil
+	childA.CaseDeltas = nil
+	childA.StrategyTraces = nil
 	childB, _ = parentB.Clone()
 	childB.Id += "c1"
 	childB.Fitness = nil
@@ -51,24 +56,20 @@ func SinglePointCrossover(parentA, parentB *Individual) (childA Individual,
 	childB.FitnessStdDev = 0
 	childB.FitnessVariance = 0
 	childB.Deltas = nil
+	childB.CaseDeltas = nil
+	childB.StrategyTraces = nil
 
 	mut := sync.Mutex{}
 	mut.Lock()
 	if len(parentA.Strategy) >= len(parentB.Strategy) {
-		prob := 0
-		for prob == 0 {
-			prob = rand.Intn(len(parentB.Strategy))
-		}
+		prob := crossoverPoint(len(parentB.Strategy))
 
 		for i := 0; i < prob; i++ {
 			childA.Strategy[i] = parentB.Strategy[i]
 			childB.Strategy[i] = parentA.Strategy[i]
 		}
 	} else {
-		prob := 0
-		for prob == 0 {
-			prob = rand.Intn(len(parentA.Strategy))
-		}
+		prob := crossoverPoint(len(parentA.Strategy))
 		for i := 0; i < prob; i++ {
 			childA.Strategy[i] = parentB.Strategy[i]
 			childB.Strategy[i] = parentA.Strategy[i]
@@ -79,6 +80,94 @@ func SinglePointCrossover(parentA, parentB *Individual) (childA Individual,
 	return childA, childB, nil
 }
 
+// crossoverPoint returns a random point in [1, length) to split a chromosome of the given length at. Chromosomes
+// with a single gene are split at 1, leaving nothing to swap.
+func crossoverPoint(length int) int {
+	if length < 2 {
+		return 1
+	}
+	return 1 + rand.Intn(length-1)
+}
+
+// CutAndSpliceCrossover cuts parentA and parentB at independently chosen points and swaps their tails. childA is
+// the head of parentA followed by the tail of parentB and childB the head of parentB followed by the tail of
+// parentA. Only cut points that keep both children within [minLength, maxLength] genes are chosen. If no such pair
+// exists the children are unchanged copies of their parents.
+func CutAndSpliceCrossover(parentA, parentB *Individual, minLength, maxLength int) (childA Individual,
+	childB Individual, err error) {
+	// Require
+	if parentA.Strategy == nil {
+		return Individual{}, Individual{}, fmt.Errorf("CutAndSpliceCrossover | parentA strategy cannot be nil")
+	}
+	if len(parentA.Strategy) < 1 {
+		return Individual{}, Individual{}, fmt.Errorf("CutAndSpliceCrossover | parentA strategy cannot be empty")
+	}
+	if parentB.Strategy == nil {
+		return Individual{}, Individual{}, fmt.Errorf("CutAndSpliceCrossover | parentB strategy cannot be nil")
+	}
+	if len(parentB.Strategy) < 1 {
+		return Individual{}, Individual{}, fmt.Errorf("CutAndSpliceCrossover | parentB strategy cannot be empty")
+	}
+	if minLength < 1 || maxLength < minLength {
+		return Individual{}, Individual{}, fmt.Errorf("CutAndSpliceCrossover | invalid genome length bounds [%d, %d]",
+			minLength, maxLength)
+	}
+
+	// DO
+	childA, _ = parentA.Clone()
+	childA.Id += "c1"
+	childA.Fitness = nil
+	childA.Program = nil
+	childA.AverageFitness = 0
+	childA.FitnessStdDev = 0
+	childA.FitnessVariance = 0
+	childA.Deltas = nil
+	childA.CaseDeltas = nil
+	childA.StrategyTraces = nil
+	childB, _ = parentB.Clone()
+	childB.Id += "c1"
+	childB.Fitness = nil
+	childB.Program = nil
+	childB.AverageFitness = 0
+	childB.FitnessStdDev = 0
+	childB.FitnessVariance = 0
+	childB.Deltas = nil
+	childB.CaseDeltas = nil
+	childB.StrategyTraces = nil
+
+	lenA, lenB := len(parentA.Strategy), len(parentB.Strategy)
+	for _, cutA := range rand.Perm(lenA + 1) {
+		// childA has cutA+lenB-cutB genes and childB has cutB+lenA-cutA, both must lie within the bounds.
+		lo, hi := 0, lenB
+		for _, bound := range []int{cutA + lenB - maxLength, minLength - lenA + cutA} {
+			if bound > lo {
+				lo = bound
+			}
+		}
+		for _, bound := range []int{cutA + lenB - minLength, maxLength - lenA + cutA} {
+			if bound < hi {
+				hi = bound
+			}
+		}
+		if lo > hi {
+			continue
+		}
+		cutB := lo + rand.Intn(hi-lo+1)
+
+		strategyA := make([]Strategy, 0, cutA+lenB-cutB)
+		strategyA = append(strategyA, parentA.Strategy[:cutA]...)
+		childA.Strategy = append(strategyA, parentB.Strategy[cutB:]...)
+		strategyB := make([]Strategy, 0, cutB+lenA-cutA)
+		strategyB = append(strategyB, parentB.Strategy[:cutB]...)
+		childB.Strategy = append(strategyB, parentA.Strategy[cutA:]...)
+		return childA, childB, nil
+	}
+
+	childA.Strategy = append([]Strategy(nil), parentA.Strategy...)
+	childB.Strategy = append([]Strategy(nil), parentB.Strategy...)
+	return childA, childB, nil
+}
+
 // CrossoverSinglePoint performs a single-point crossover that is dictated by the crossover percentage float.
 // Both parent chromosomes are split at the percentage section specified by crossoverPercentage
 func KPointCrossover(parentA, parentB *Individual, kPoint int) (childA Individual, childB Individual, err error) {
@@ -106,6 +195,8 @@ func KPointCrossover(parentA, parentB *Individual, kPoint int) (childA Individua
 	childA.FitnessStdDev = 0
 	childA.FitnessVariance = 0
 	childA.Deltas = nil
+	childA.CaseDeltas = nil
+	childA.StrategyTraces = nil
 	childB, _ = parentB.Clone()
 	childB.Id += "c1"
 	childB.Fitness = nil
@@ -114,6 +205,8 @@ func KPointCrossover(parentA, parentB *Individual, kPoint int) (childA Individua
 	childB.FitnessStdDev = 0
 	childB.FitnessVariance = 0
 	childB.Deltas = nil
+	childB.CaseDeltas = nil
+	childB.StrategyTraces = nil
 
 	mut := sync.Mutex{}
 	mut.Lock()
@@ -215,6 +308,8 @@ func UniformCrossover(parentA, parentB *Individual) (childA Individual,
 	childA.FitnessStdDev = 0
 	childA.FitnessVariance = 0
 	childA.Deltas = nil
+	childA.CaseDeltas = nil
+	childA.StrategyTraces = nil
 	childB, _ = parentB.Clone()
 	childB.Id += "c1"
 	childB.Fitness = nil
@@ -223,6 +318,8 @@ func UniformCrossover(parentA, parentB *Individual) (childA Individual,
 	childB.FitnessStdDev = 0
 	childB.FitnessVariance = 0
 	childB.Deltas = nil
+	childB.CaseDeltas = nil
+	childB.StrategyTraces = nil
 
 	mut := sync.Mutex{}
 	mut.Lock()
@@ -309,11 +406,13 @@ func FixedPointCrossover(individual Individual, individual2 Individual, params E
 	if err != nil {
 		return Individual{}, Individual{}, err
 	}
+	child1.StrategyTraces = nil
 	child2, err := individual2.Clone()
 	child2.Id = child2.Id + "c2"
 	if err != nil {
 		return Individual{}, Individual{}, err
 	}
+	child2.StrategyTraces = nil
 
 	crossoverPercentage := params.Reproduction.CrossoverPercentage
 	if crossoverPercentage == 0 {
@@ -481,6 +580,12 @@ func StrategySwapperIgnorant(a []Strategy, b []Strategy, swapLength int, startIn
 
 // Mutate will mutate the Strategy in a given individual
 func (individual *Individual) Mutate(availableStrategies []Strategy) error {
+	return individual.MutateWeighted(availableStrategies, nil)
+}
+
+// MutateWeighted replaces a random gene of the individual's Strategy with one of the availableStrategies,
+// sampled in proportion to its weight, see RandomStrategy.
+func (individual *Individual) MutateWeighted(availableStrategies []Strategy, weights []float64) error {
 	if availableStrategies == nil {
 		return fmt.Errorf("Mutate | availableStrategies param cannot be nil")
 	}
@@ -493,8 +598,7 @@ func (individual *Individual) Mutate(availableStrategies []Strategy) error {
 
 	randIndexToMutate := rand.Intn(len(individual.Strategy))
 
-	randIndexForStrategies := rand.Intn(len(availableStrategies))
-	individual.Strategy[randIndexToMutate] = availableStrategies[randIndexForStrategies]
+	individual.Strategy[randIndexToMutate] = RandomStrategy(availableStrategies, weights)
 	return nil
 }
 
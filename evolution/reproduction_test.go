@@ -126,3 +126,115 @@ func Test_depthPenaltyIgnore(t *testing.T) {
 		})
 	}
 }
+
+func TestCutAndSpliceCrossover(t *testing.T) {
+	// genomes of a single strategy each, so every child must be a run of the first followed by a run of the second
+	genome := func(strategy Strategy, length int) *Individual {
+		individual := &Individual{Id: string(strategy), Strategy: make([]Strategy, length)}
+		for i := range individual.Strategy {
+			individual.Strategy[i] = strategy
+		}
+		return individual
+	}
+	isSpliced := func(strategies []Strategy, head, tail Strategy) bool {
+		for i := 1; i < len(strategies); i++ {
+			if strategies[i-1] == tail && strategies[i] == head {
+				return false
+			}
+		}
+		return true
+	}
+	tests := []struct {
+		name                 string
+		parentA, parentB     *Individual
+		minLength, maxLength int
+		wantErr              bool
+	}{
+		{"empty parentA", &Individual{Strategy: []Strategy{}}, genome(StrategyMutateTerminal, 2), 1, 7, true},
+		{"nil parentB", genome(StrategyDeleteMalicious, 5), &Individual{}, 1, 7, true},
+		{"min < 1", genome(StrategyDeleteMalicious, 5), genome(StrategyMutateTerminal, 2), 0, 7, true},
+		{"max < min", genome(StrategyDeleteMalicious, 5), genome(StrategyMutateTerminal, 2), 3, 2, true},
+		{"unbounded", genome(StrategyDeleteMalicious, 5), genome(StrategyMutateTerminal, 2), 1, 7, false},
+		{"tight", genome(StrategyDeleteMalicious, 5), genome(StrategyMutateTerminal, 2), 3, 4, false},
+		{"parent lengths", genome(StrategyDeleteMalicious, 5), genome(StrategyMutateTerminal, 2), 2, 5, false},
+		{"single genes", genome(StrategyDeleteMalicious, 1), genome(StrategyMutateTerminal, 1), 1, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				childA, childB, err := CutAndSpliceCrossover(tt.parentA, tt.parentB, tt.minLength, tt.maxLength)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("CutAndSpliceCrossover() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr {
+					return
+				}
+				lenA, lenB := len(tt.parentA.Strategy), len(tt.parentB.Strategy)
+				if len(childA.Strategy)+len(childB.Strategy) != lenA+lenB {
+					t.Fatalf("children have %d genes, want %d", len(childA.Strategy)+len(childB.Strategy),
+						lenA+lenB)
+				}
+				for _, child := range []Individual{childA, childB} {
+					if len(child.Strategy) < tt.minLength || len(child.Strategy) > tt.maxLength {
+						t.Fatalf("child has %d genes, want within [%d, %d]", len(child.Strategy), tt.minLength,
+							tt.maxLength)
+					}
+				}
+				a, b := tt.parentA.Strategy[0], tt.parentB.Strategy[0]
+				if !isSpliced(childA.Strategy, a, b) || !isSpliced(childB.Strategy, b, a) {
+					t.Fatalf("CutAndSpliceCrossover() = %v %v, want heads followed by swapped tails",
+						childA.Strategy, childB.Strategy)
+				}
+				if childA.Fitness != nil || childA.Program != nil || childB.Deltas != nil {
+					t.Fatalf("children should not inherit the fitness of their parents")
+				}
+			}
+		})
+	}
+}
+
+func TestCutAndSpliceCrossover_Infeasible(t *testing.T) {
+	parentA := &Individual{Strategy: []Strategy{StrategyDeleteMalicious, StrategyDeleteMalicious, StrategyDeleteMalicious}}
+	parentB := &Individual{Strategy: []Strategy{StrategyMutateTerminal}}
+	childA, childB, err := CutAndSpliceCrossover(parentA, parentB, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(childA.Strategy) != 3 || len(childB.Strategy) != 1 || childB.Strategy[0] != StrategyMutateTerminal {
+		t.Errorf("CutAndSpliceCrossover() = %v %v, want copies of the parents", childA.Strategy, childB.Strategy)
+	}
+	childA.Strategy[0] = StrategyMutateTerminal
+	if parentA.Strategy[0] != StrategyDeleteMalicious {
+		t.Errorf("CutAndSpliceCrossover() children share their genome with their parents")
+	}
+}
+
+func TestSinglePointCrossover_Parents(t *testing.T) {
+	// Strategies of length 2 are always split at 1
+	parentA := &Individual{Strategy: []Strategy{StrategySkip, StrategyFellTree}}
+	parentB := &Individual{Strategy: []Strategy{StrategyMultXD, StrategyAddXD}}
+	childA, childB, err := SinglePointCrossover(parentA, parentB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if childA.Strategy[0] != StrategyMultXD || childA.Strategy[1] != StrategyFellTree ||
+		childB.Strategy[0] != StrategySkip || childB.Strategy[1] != StrategyAddXD {
+		t.Errorf("SinglePointCrossover() = %v %v, want [%s %s] [%s %s]", childA.Strategy, childB.Strategy,
+			StrategyMultXD, StrategyFellTree, StrategySkip, StrategyAddXD)
+	}
+	if parentA.Strategy[0] != StrategySkip || parentB.Strategy[0] != StrategyMultXD {
+		t.Errorf("SinglePointCrossover() should not change the parents. got: %v %v", parentA.Strategy,
+			parentB.Strategy)
+	}
+}
+
+func Test_crossoverPoint(t *testing.T) {
+	for _, length := range []int{1, 2, 3, 10} {
+		for i := 0; i < 50; i++ {
+			got := crossoverPoint(length)
+			if got < 1 || (length > 1 && got >= length) {
+				t.Fatalf("crossoverPoint(%d) = %d, want within [1, %d)", length, got, length)
+			}
+		}
+	}
+}
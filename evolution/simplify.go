@@ -0,0 +1,271 @@
+package evolution
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Simplify returns an algebraically simplified copy of the tree, the tree itself is left untouched.
+// Constant sub-trees are folded, identities and annihilators such as x*1, x+0, x-0, e/1, 0*e and x-x are removed and
+// like terms in sums are collected e.g. x+2*x+1+1 becomes (3*x)+2. Sub-trees that cannot be folded safely,
+// such as a division by zero or a function outside of its domain, are kept as is so the simplified tree evaluates
+// exactly like the original one wherever the original is defined.
+func (d *DualTree) Simplify() (DualTree, error) {
+	if d.root == nil {
+		return DualTree{}, fmt.Errorf("Simplify | treeNode root is nil cannot simplify")
+	}
+	if err := d.Validate(); err != nil {
+		return DualTree{}, err
+	}
+
+	return DualTree{root: simplifyNode(d.root.cloneSubTree())}, nil
+}
+
+// SimplifiedMathematicalString is a convenience that returns the mathematical representation of the simplified tree.
+func (d *DualTree) SimplifiedMathematicalString() (string, error) {
+	simplified, err := d.Simplify()
+	if err != nil {
+		return "", err
+	}
+	return simplified.ToMathematicalString()
+}
+
+func simplifyNode(node *DualTreeNode) *DualTreeNode {
+	if node == nil || (node.left == nil && node.right == nil) {
+		return node
+	}
+
+	node.left = simplifyNode(node.left)
+	node.right = simplifyNode(node.right)
+
+	if folded, ok := foldConstant(node); ok {
+		return folded
+	}
+	if node.left == nil || node.right == nil {
+		return node
+	}
+
+	if reduced := simplifyIdentity(node); reduced != node {
+		return reduced
+	}
+	if node.value == "+" || node.value == "-" {
+		return collectLikeTerms(node)
+	}
+	return node
+}
+
+// foldConstant evaluates a node whose children are all constants. It fails if the result is undefined.
+func foldConstant(node *DualTreeNode) (*DualTreeNode, bool) {
+	if _, ok := constantValue(node.left); !ok {
+		return nil, false
+	}
+	if node.right != nil {
+		if _, ok := constantValue(node.right); !ok {
+			return nil, false
+		}
+	}
+
+	evaluator, err := compileNode(node)
+	if err != nil {
+		return nil, false
+	}
+	value, ok := evaluator(nil)
+	if !ok || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, false
+	}
+	return newConstantNode(value), true
+}
+
+// simplifyIdentity removes identity and annihilator elements. It returns the node itself if nothing applies.
+func simplifyIdentity(node *DualTreeNode) *DualTreeNode {
+	left, leftIsConstant := constantValue(node.left)
+	right, rightIsConstant := constantValue(node.right)
+
+	switch node.value {
+	case "+":
+		if leftIsConstant && left == 0 {
+			return node.right
+		}
+		if rightIsConstant && right == 0 {
+			return node.left
+		}
+	case "-":
+		if rightIsConstant && right == 0 {
+			return node.left
+		}
+		if subTreeString(node.left) == subTreeString(node.right) {
+			return newConstantNode(0)
+		}
+	case "*":
+		if (leftIsConstant && left == 0) || (rightIsConstant && right == 0) {
+			return newConstantNode(0)
+		}
+		if leftIsConstant && left == 1 {
+			return node.right
+		}
+		if rightIsConstant && right == 1 {
+			return node.left
+		}
+	case "/":
+		if rightIsConstant && right == 1 {
+			return node.left
+		}
+	case "pow":
+		if rightIsConstant && right == 1 {
+			return node.left
+		}
+		if rightIsConstant && right == 0 {
+			return newConstantNode(1)
+		}
+	}
+	return node
+}
+
+// linearTerm is a term of a sum in the form coefficient * node.
+type linearTerm struct {
+	coefficient float64
+	node        *DualTreeNode
+	key         string
+}
+
+// collectLikeTerms flattens a sum of terms, adds up the coefficients of structurally identical terms as well as all
+// constants, and rebuilds the sum. The rebuilt sum is only used if it is smaller than the original,
+// or the same size with fewer terms e.g. x+x becomes 2*x.
+func collectLikeTerms(node *DualTreeNode) *DualTreeNode {
+	terms := make([]linearTerm, 0)
+	constant := 0.0
+	flattenSum(node, 1, &terms, &constant)
+
+	collected := make([]linearTerm, 0, len(terms))
+	indexes := map[string]int{}
+	for _, term := range terms {
+		if i, ok := indexes[term.key]; ok {
+			collected[i].coefficient += term.coefficient
+			continue
+		}
+		indexes[term.key] = len(collected)
+		collected = append(collected, term)
+	}
+
+	rebuilt := rebuildSum(collected, constant)
+	rebuiltSize, size := subTreeSize(rebuilt), subTreeSize(node)
+	if rebuiltSize < size || (rebuiltSize == size && len(collected) < len(terms)) {
+		return rebuilt
+	}
+	return node
+}
+
+func flattenSum(node *DualTreeNode, sign float64, terms *[]linearTerm, constant *float64) {
+	if value, ok := constantValue(node); ok {
+		*constant += sign * value
+		return
+	}
+	switch node.value {
+	case "+":
+		if node.left != nil && node.right != nil {
+			flattenSum(node.left, sign, terms, constant)
+			flattenSum(node.right, sign, terms, constant)
+			return
+		}
+	case "-":
+		if node.left != nil && node.right != nil {
+			flattenSum(node.left, sign, terms, constant)
+			flattenSum(node.right, -sign, terms, constant)
+			return
+		}
+	case "*":
+		if node.left == nil || node.right == nil {
+			break
+		}
+		if coefficient, ok := constantValue(node.left); ok {
+			*terms = append(*terms, linearTerm{sign * coefficient, node.right, subTreeString(node.right)})
+			return
+		}
+		if coefficient, ok := constantValue(node.right); ok {
+			*terms = append(*terms, linearTerm{sign * coefficient, node.left, subTreeString(node.left)})
+			return
+		}
+	}
+	*terms = append(*terms, linearTerm{sign, node, subTreeString(node)})
+}
+
+// rebuildSum adds the positive terms first and subtracts the negative ones afterwards so coefficients of -1 never
+// need to be written out. The constant is added last.
+func rebuildSum(terms []linearTerm, constant float64) *DualTreeNode {
+	var sum *DualTreeNode
+	for _, term := range terms {
+		if term.coefficient <= 0 {
+			continue
+		}
+		sum = joinTerm(sum, "+", scaleTerm(term.coefficient, term.node))
+	}
+	for _, term := range terms {
+		if term.coefficient >= 0 {
+			continue
+		}
+		if sum == nil {
+			sum = scaleTerm(term.coefficient, term.node)
+			continue
+		}
+		sum = joinTerm(sum, "-", scaleTerm(-term.coefficient, term.node))
+	}
+
+	switch {
+	case sum == nil:
+		return newConstantNode(constant)
+	case constant > 0:
+		return joinTerm(sum, "+", newConstantNode(constant))
+	case constant < 0:
+		return joinTerm(sum, "-", newConstantNode(-constant))
+	}
+	return sum
+}
+
+func joinTerm(sum *DualTreeNode, operator string, term *DualTreeNode) *DualTreeNode {
+	if sum == nil {
+		return term
+	}
+	return newParsedNonTerminal(operator, sum, term)
+}
+
+func scaleTerm(coefficient float64, node *DualTreeNode) *DualTreeNode {
+	if coefficient == 1 {
+		return node
+	}
+	return newParsedNonTerminal("*", newConstantNode(coefficient), node)
+}
+
+// constantValue returns the numeric value of a terminal if it is a constant.
+func constantValue(node *DualTreeNode) (float64, bool) {
+	if node == nil || node.left != nil || node.right != nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(node.value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+func newConstantNode(value float64) *DualTreeNode {
+	if value == 0 {
+		// avoids writing out -0
+		value = 0
+	}
+	return newParsedTerminal(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func subTreeString(node *DualTreeNode) string {
+	sb := &strings.Builder{}
+	MathPreorder(node, sb)
+	return sb.String()
+}
+
+func subTreeSize(node *DualTreeNode) int {
+	if node == nil {
+		return 0
+	}
+	return 1 + subTreeSize(node.left) + subTreeSize(node.right)
+}
@@ -0,0 +1,131 @@
+package evolution
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDualTree_Simplify(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"terminal", "x", "(x)"},
+		{"constant fold", "2*3+4", "(10)"},
+		{"constant fold decimal", "1/4", "(0.25)"},
+		{"mult identity", "x*1", "(x)"},
+		{"mult identity left", "1*x", "(x)"},
+		{"add identity", "x+0", "(x)"},
+		{"add identity left", "0+x", "(x)"},
+		{"sub identity", "x-0", "(x)"},
+		{"div identity", "x/1", "(x)"},
+		{"mult annihilator", "0*(x+y)", "(0)"},
+		{"sub self", "x*y-x*y", "(0)"},
+		{"bloated", "x*1+0-0*x", "(x)"},
+		{"nested identities", "(x+0)*(1*x)", "((x)*(x))"},
+		{"like terms", "x+x", "((2)*(x))"},
+		{"like terms with coefficients", "2*x+x*3", "((5)*(x))"},
+		{"like terms cancel", "x+y-x", "(y)"},
+		{"constants collected", "x+1+x+1", "(((2)*(x))+(2))"},
+		{"negative remainder", "y-x-x", "((y)-((2)*(x)))"},
+		{"only negative terms", "0-x-x", "((-2)*(x))"},
+		{"non-linear terms kept", "x*x+2", "(((x)*(x))+(2))"},
+		{"function fold", "sin(0)+x", "(x)"},
+		{"function kept", "sin(x+0)", "(sin(x))"},
+		{"pow identity", "pow(x, 1)", "(x)"},
+		{"pow zero", "pow(x, 0)", "(1)"},
+		{"division by zero kept", "x+1/0", "((x)+((1)/(0)))"},
+		{"domain error kept", "log(0)*x", "((log(0))*(x))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := ParseExpression(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			original, err := tree.ToMathematicalString()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			simplified, err := tree.Simplify()
+			if err != nil {
+				t.Fatalf("DualTree.Simplify() error = %v", err)
+			}
+			if err := simplified.Validate(); err != nil {
+				t.Fatalf("DualTree.Simplify() produced an invalid tree: %v", err)
+			}
+			got, err := simplified.ToMathematicalString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("DualTree.Simplify() = %v, want %v", got, tt.want)
+			}
+
+			unchanged, _ := tree.ToMathematicalString()
+			if unchanged != original {
+				t.Errorf("DualTree.Simplify() modified the original tree, got %v, want %v", unchanged, original)
+			}
+		})
+	}
+}
+
+func TestDualTree_SimplifyPreservesValue(t *testing.T) {
+	inputs := []string{
+		"x*1+0-0*x",
+		"x+x*2-3+x/1",
+		"(x+1)*(x+1)-x*x",
+		"2*x-(x+x)+4*y",
+		"sin(x)+sin(x)*3-y",
+	}
+	points := []IndependentVariableMap{
+		{"x": -2, "y": 1},
+		{"x": 0.5, "y": -3},
+		{"x": 3, "y": 7},
+	}
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			tree, err := ParseExpression(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			simplified, err := tree.Simplify()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if simplified.Size() > tree.Size() {
+				t.Errorf("DualTree.Simplify() grew the tree from %d to %d nodes", tree.Size(), simplified.Size())
+			}
+
+			want, err := tree.Compile()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := simplified.Compile()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, point := range points {
+				wantValue, err := want.EvaluateWithVar(point)
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotValue, err := got.EvaluateWithVar(point)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if math.Abs(gotValue-wantValue) > 1e-9 {
+					t.Errorf("DualTree.Simplify() at %v = %v, want %v", point, gotValue, wantValue)
+				}
+			}
+		})
+	}
+}
+
+func TestDualTree_SimplifyNil(t *testing.T) {
+	if _, err := TreeNil().Simplify(); err == nil {
+		t.Errorf("DualTree.Simplify() on a nil tree should return an error")
+	}
+}
@@ -1,7 +1,17 @@
 package evolution
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"github.com/martinomburajr/masters-go/eval"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -20,10 +30,23 @@ type EquationPairing struct {
 	ProtagonistPenalization float64
 
 	DivideByZeroPenalty float64
+	// ErrorMetric is the FitnessStrategy.ErrorMetric used to aggregate deltas and thresholds over the spec.
+	ErrorMetric string
 }
 
 type IndependentVariableMap map[string]float64
 
+// HasZero returns true if any of the independent variables is 0. A divide by zero at such a point may be legitimate
+// e.g. 1/x at x = 0
+func (m IndependentVariableMap) HasZero() bool {
+	for key := range m {
+		if m[key] == 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *EquationPairing) ToString() string {
 	return fmt.Sprintf("  %#v  \t  %.2f  \n", e.Independents, e.Dependent)
 }
@@ -31,40 +54,377 @@ func (e *EquationPairing) ToString() string {
 // SpecMulti is the underlying data structre that contains the spec as well as threshold information
 type SpecMulti []EquationPairing
 
-// GenerateSpecSimple assumes a single independent variable x with an unlimited count.
+// GenerateSpecSimple samples the expression over the independent variables defined in specParam.Variables.
+// If no variables are defined, it assumes a single independent variable x over specParam.Range starting at
+// specParam.Seed.
 func GenerateSpecSimple(specParam SpecParam, fitnessStrategy FitnessStrategy) (SpecMulti,
 	error) {
 
 	if specParam.Expression == "" {
 		return nil, fmt.Errorf("GenerateSpec | cannot containe empty mathematical expression")
 	}
-	if specParam.Range < 1 {
+	if len(specParam.Variables) < 1 && specParam.Range < 1 {
 		return nil, fmt.Errorf("GenerateSpec | specParam.Range cannot be less than 0")
 	}
-	if fitnessStrategy.AntagonistThresholdMultiplier < 1 {
-		fitnessStrategy.AntagonistThresholdMultiplier = 1
+
+	points, err := GenerateSpecPoints(specParam)
+	if err != nil {
+		return nil, err
 	}
-	if fitnessStrategy.ProtagonistThresholdMultiplier < 1 {
-		fitnessStrategy.ProtagonistThresholdMultiplier = 1
+
+	spec, err := evaluateSpec(specParam.Expression, points)
+	if err != nil {
+		return nil, err
+	}
+	spec.setThresholds(specParam, fitnessStrategy)
+	return spec, nil
+}
+
+// gridPoints returns every combination of the given values of each variable, with the first variable changing the
+// fastest
+func gridPoints(variables []VariableParam, values [][]float64) []IndependentVariableMap {
+	count := 1
+	for i := range values {
+		count *= len(values[i])
 	}
+	points := make([]IndependentVariableMap, count)
+	for p := range points {
+		points[p] = make(IndependentVariableMap, len(variables))
+		index := p
+		for i := range variables {
+			points[p][variables[i].Name] = values[i][index%len(values[i])]
+			index /= len(values[i])
+		}
+	}
+	return points
+}
+
+// SplitSpec divides the spec into a training set used for fitness and a held out test set according to
+// specParam.TestSplit. Interpolation and extrapolation generate new points from the expression,
+// so they leave the training set untouched. The random split moves points from the spec into the test set.
+func SplitSpec(specParam SpecParam, fitnessStrategy FitnessStrategy, spec SpecMulti) (train SpecMulti,
+	test SpecMulti, err error) {
 
-	spec := make([]EquationPairing, specParam.Range)
+	switch specParam.TestSplit {
+	case "":
+		return spec, SpecMulti{}, nil
+	case SpecTestSplitRandom:
+		ratio := specParam.TestRatio
+		if ratio <= 0 {
+			ratio = 0.2
+		}
+		if ratio >= 1 {
+			return nil, nil, fmt.Errorf("SplitSpec | testRatio must be less than 1")
+		}
+		testCount := int(math.Round(ratio * float64(len(spec))))
+		if testCount < 1 || testCount >= len(spec) {
+			return nil, nil, fmt.Errorf("SplitSpec | spec of size %d is too small to hold out %.2f of it",
+				len(spec), ratio)
+		}
+		isTest := make([]bool, len(spec))
+		for _, index := range rand.Perm(len(spec))[:testCount] {
+			isTest[index] = true
+		}
+		train = make(SpecMulti, 0, len(spec)-testCount)
+		test = make(SpecMulti, 0, testCount)
+		for i := range spec {
+			if isTest[i] {
+				test = append(test, spec[i])
+			} else {
+				train = append(train, spec[i])
+			}
+		}
+		return train, test, nil
+	case SpecTestSplitInterpolation, SpecTestSplitExtrapolation:
+		if specParam.DatasetPath != "" {
+			return nil, nil, fmt.Errorf("SplitSpec | %s requires an expression, use %s for datasets",
+				specParam.TestSplit, SpecTestSplitRandom)
+		}
+		var points []IndependentVariableMap
+		if specParam.TestSplit == SpecTestSplitInterpolation {
+			points, err = interpolationPoints(specParam.SpecVariables())
+		} else {
+			points, err = extrapolationPoints(specParam.SpecVariables(), specParam.TestRange)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		test, err = evaluateSpec(specParam.Expression, points)
+		if err != nil {
+			return nil, nil, err
+		}
+		test.setThresholds(specParam, fitnessStrategy)
+		return spec, test, nil
+	default:
+		return nil, nil, fmt.Errorf("SplitSpec | unknown test split %s", specParam.TestSplit)
+	}
+}
+
+// interpolationPoints returns the points halfway between consecutive values of each variable
+func interpolationPoints(variables []VariableParam) ([]IndependentVariableMap, error) {
+	values := make([][]float64, len(variables))
+	for i := range variables {
+		if variables[i].Range < 2 {
+			return nil, fmt.Errorf("interpolationPoints | range of variable %s must be at least 2",
+				variables[i].Name)
+		}
+		values[i] = make([]float64, variables[i].Range-1)
+		for j := range values[i] {
+			values[i][j] = float64(j+variables[i].Seed) + 0.5
+		}
+	}
+	return gridPoints(variables, values), nil
+}
+
+// extrapolationPoints returns the points within testRange of the spec where at least one variable lies outside of its
+// range
+func extrapolationPoints(variables []VariableParam, testRange int) ([]IndependentVariableMap, error) {
+	if testRange < 1 {
+		testRange = 2
+	}
+	values := make([][]float64, len(variables))
+	for i := range variables {
+		values[i] = make([]float64, variables[i].Range+2*testRange)
+		for j := range values[i] {
+			values[i][j] = float64(j + variables[i].Seed - testRange)
+		}
+	}
+
+	points := make([]IndependentVariableMap, 0)
+	for _, point := range gridPoints(variables, values) {
+		for i := range variables {
+			value := point[variables[i].Name]
+			if value < float64(variables[i].Seed) || value >= float64(variables[i].Seed+variables[i].Range) {
+				points = append(points, point)
+				break
+			}
+		}
+	}
+	return points, nil
+}
+
+// evaluateSpec computes the dependent value of the expression at each of the points
+func evaluateSpec(expression string, points []IndependentVariableMap) (SpecMulti, error) {
+	specEvaluator, err := eval.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	spec := make(SpecMulti, len(points))
 	for i := range spec {
-		spec[i].Independents = map[string]float64{}
-		spec[i].Independents["x"] = float64(i + specParam.Seed)
-		dependentVariable, err := EvaluateMathematicalExpression(specParam.Expression,
-			spec[i].Independents)
+		spec[i].Independents = points[i]
+		dependentVariable, err := specEvaluator.EvaluateWithVar(spec[i].Independents)
 		if err != nil {
 			return nil, err
 		}
 		spec[i].Dependent = dependentVariable
-		spec[i].AntagonistThreshold = dependentVariable * fitnessStrategy.AntagonistThresholdMultiplier
-		spec[i].ProtagonistThreshold = dependentVariable * fitnessStrategy.ProtagonistThresholdMultiplier
+	}
+	return spec, nil
+}
+
+// setThresholds computes the antagonist and protagonist thresholds of each point from its dependent value
+func (spec SpecMulti) setThresholds(specParam SpecParam, fitnessStrategy FitnessStrategy) {
+	if fitnessStrategy.AntagonistThresholdMultiplier < 1 {
+		fitnessStrategy.AntagonistThresholdMultiplier = 1
+	}
+	if fitnessStrategy.ProtagonistThresholdMultiplier < 1 {
+		fitnessStrategy.ProtagonistThresholdMultiplier = 1
+	}
+	for i := range spec {
+		spec[i].AntagonistThreshold = spec[i].Dependent * fitnessStrategy.AntagonistThresholdMultiplier
+		spec[i].ProtagonistThreshold = spec[i].Dependent * fitnessStrategy.ProtagonistThresholdMultiplier
 		spec[i].DivideByZeroPenalty = specParam.DivideByZeroPenalty
+		spec[i].ErrorMetric = fitnessStrategy.ErrorMetric
+	}
+}
+
+// LoadSpecDataset reads the spec from the observed data points in specParam.DatasetPath instead of generating it from
+// an expression. The format is chosen from the file extension, see ReadSpecCSV and ReadSpecJSON.
+// Thresholds are computed from the dependent values in the same way as GenerateSpecSimple.
+func LoadSpecDataset(specParam SpecParam, fitnessStrategy FitnessStrategy) (SpecMulti, error) {
+	if specParam.DatasetPath == "" {
+		return nil, fmt.Errorf("LoadSpecDataset | datasetPath cannot be empty")
+	}
+
+	file, err := os.Open(specParam.DatasetPath)
+	if err != nil {
+		return nil, fmt.Errorf("LoadSpecDataset | %s", err.Error())
+	}
+	defer file.Close()
+
+	var spec SpecMulti
+	switch strings.ToLower(filepath.Ext(specParam.DatasetPath)) {
+	case ".csv":
+		spec, err = ReadSpecCSV(file, specParam.DatasetDependent)
+	case ".json":
+		spec, err = ReadSpecJSON(file)
+	default:
+		return nil, fmt.Errorf("LoadSpecDataset | unsupported dataset format %s, use .csv or .json",
+			specParam.DatasetPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	spec.setThresholds(specParam, fitnessStrategy)
+	return spec, nil
+}
+
+// ReadSpecCSV reads data points from a CSV with a header row. Every column other than the dependent column is an
+// independent variable e.g.
+//
+//	x,y,f
+//	1,2,3
+//
+// If dependent is empty, the last column is the dependent column.
+func ReadSpecCSV(r io.Reader, dependent string) (SpecMulti, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("ReadSpecCSV | %s", err.Error())
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("ReadSpecCSV | dataset must contain a header and at least one row")
+	}
+
+	header := records[0]
+	if len(header) < 2 {
+		return nil, fmt.Errorf("ReadSpecCSV | dataset must contain at least one independent and one dependent column")
+	}
+	dependentIndex := len(header) - 1
+	if dependent != "" {
+		dependentIndex = -1
+		for i := range header {
+			if strings.TrimSpace(header[i]) == dependent {
+				dependentIndex = i
+			}
+		}
+		if dependentIndex < 0 {
+			return nil, fmt.Errorf("ReadSpecCSV | dependent column %s not found in header", dependent)
+		}
+	}
+
+	spec := make(SpecMulti, len(records)-1)
+	for row := 1; row < len(records); row++ {
+		spec[row-1].Independents = make(IndependentVariableMap, len(header)-1)
+		for column := range header {
+			value, err := strconv.ParseFloat(strings.TrimSpace(records[row][column]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("ReadSpecCSV | row %d column %s | %s", row, header[column], err.Error())
+			}
+			if column == dependentIndex {
+				spec[row-1].Dependent = value
+			} else {
+				spec[row-1].Independents[strings.TrimSpace(header[column])] = value
+			}
+		}
+	}
+	return spec, nil
+}
+
+// ReadSpecJSON reads data points from a JSON array of equation pairings e.g.
+//
+//	[{"independents": {"x": 1, "y": 2}, "dependent": 3}]
+//
+// Every data point must contain the same independent variables.
+func ReadSpecJSON(r io.Reader) (SpecMulti, error) {
+	var spec SpecMulti
+	err := json.NewDecoder(r).Decode(&spec)
+	if err != nil {
+		return nil, fmt.Errorf("ReadSpecJSON | %s", err.Error())
+	}
+	if len(spec) < 1 {
+		return nil, fmt.Errorf("ReadSpecJSON | dataset must contain at least one data point")
+	}
+
+	variables := spec.Variables()
+	if len(variables) < 1 {
+		return nil, fmt.Errorf("ReadSpecJSON | data points must contain at least one independent variable")
+	}
+	for i := range spec {
+		if len(spec[i].Independents) != len(variables) {
+			return nil, fmt.Errorf("ReadSpecJSON | data point %d does not contain the variables %v", i, variables)
+		}
+		for _, variable := range variables {
+			if _, ok := spec[i].Independents[variable]; !ok {
+				return nil, fmt.Errorf("ReadSpecJSON | data point %d does not contain the variables %v", i, variables)
+			}
+		}
 	}
 	return spec, nil
 }
 
+// Variables returns the sorted names of the independent variables in the first point of the spec
+func (spec SpecMulti) Variables() []string {
+	if len(spec) < 1 {
+		return []string{}
+	}
+	variables := make([]string, 0, len(spec[0].Independents))
+	for key := range spec[0].Independents {
+		variables = append(variables, key)
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+// SpecVariables returns the independent variables of the spec. If none are defined,
+// a single variable x using the Range and Seed of the spec is returned.
+func (s SpecParam) SpecVariables() []VariableParam {
+	if len(s.Variables) > 0 {
+		return s.Variables
+	}
+	return []VariableParam{{Name: "x", Range: s.Range, Seed: s.Seed}}
+}
+
+// GenerateSpecPoints returns the points in the variable space at which the spec is evaluated.
+// Grid sampling returns every combination of the variables, with the first variable changing the fastest.
+// Random sampling returns SampleCount points drawn uniformly from [Seed, Seed+Range) of each variable.
+func GenerateSpecPoints(specParam SpecParam) ([]IndependentVariableMap, error) {
+	variables := specParam.SpecVariables()
+	names := map[string]bool{}
+	for i := range variables {
+		if variables[i].Name == "" {
+			return nil, fmt.Errorf("GenerateSpecPoints | variable name cannot be empty")
+		}
+		if names[variables[i].Name] {
+			return nil, fmt.Errorf("GenerateSpecPoints | duplicate variable %s", variables[i].Name)
+		}
+		if variables[i].Range < 1 {
+			return nil, fmt.Errorf("GenerateSpecPoints | range of variable %s cannot be less than 1",
+				variables[i].Name)
+		}
+		names[variables[i].Name] = true
+	}
+
+	switch specParam.Sampling {
+	case "", SpecSamplingGrid:
+		values := make([][]float64, len(variables))
+		for i := range variables {
+			values[i] = make([]float64, variables[i].Range)
+			for j := range values[i] {
+				values[i][j] = float64(j + variables[i].Seed)
+			}
+		}
+		return gridPoints(variables, values), nil
+	case SpecSamplingRandom:
+		count := specParam.SampleCount
+		if count < 1 {
+			count = specParam.Range
+		}
+		if count < 1 {
+			return nil, fmt.Errorf("GenerateSpecPoints | sampleCount cannot be less than 1")
+		}
+		points := make([]IndependentVariableMap, count)
+		for p := range points {
+			points[p] = make(IndependentVariableMap, len(variables))
+			for i := range variables {
+				points[p][variables[i].Name] = float64(variables[i].Seed) + rand.Float64()*float64(variables[i].Range)
+			}
+		}
+		return points, nil
+	default:
+		return nil, fmt.Errorf("GenerateSpecPoints | unknown sampling %s", specParam.Sampling)
+	}
+}
+
 func (spec SpecMulti) ToString() string {
 	sb := strings.Builder{}
 	if spec == nil {
@@ -1,6 +1,11 @@
 package evolution
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -203,3 +208,265 @@ func TestGenerateSpec(t *testing.T) {
 //		})
 //	}
 //}
+
+func TestGenerateSpecSimple(t *testing.T) {
+	tests := []struct {
+		name      string
+		specParam SpecParam
+		want      SpecMulti
+		wantErr   bool
+	}{
+		{"empty", SpecParam{Range: 1}, nil, true},
+		{"range 0", SpecParam{Expression: "x"}, nil, true},
+		{"x", SpecParam{Expression: "x*x", Range: 3, Seed: -1}, SpecMulti{
+			{Independents: IndependentVariableMap{"x": -1}, Dependent: 1, AntagonistThreshold: 1, ProtagonistThreshold: 1},
+			{Independents: IndependentVariableMap{"x": 0}, Dependent: 0},
+			{Independents: IndependentVariableMap{"x": 1}, Dependent: 1, AntagonistThreshold: 1, ProtagonistThreshold: 1},
+		}, false},
+		{"x*y+z grid", SpecParam{Expression: "x*y+z", Variables: []VariableParam{
+			{Name: "x", Range: 2, Seed: 0},
+			{Name: "y", Range: 2, Seed: 1},
+			{Name: "z", Range: 1, Seed: 10},
+		}}, SpecMulti{
+			{Independents: IndependentVariableMap{"x": 0, "y": 1, "z": 10}, Dependent: 10, AntagonistThreshold: 10,
+				ProtagonistThreshold: 10},
+			{Independents: IndependentVariableMap{"x": 1, "y": 1, "z": 10}, Dependent: 11, AntagonistThreshold: 11,
+				ProtagonistThreshold: 11},
+			{Independents: IndependentVariableMap{"x": 0, "y": 2, "z": 10}, Dependent: 10, AntagonistThreshold: 10,
+				ProtagonistThreshold: 10},
+			{Independents: IndependentVariableMap{"x": 1, "y": 2, "z": 10}, Dependent: 12, AntagonistThreshold: 12,
+				ProtagonistThreshold: 12},
+		}, false},
+		{"x1 and x", SpecParam{Expression: "x1-x", Variables: []VariableParam{
+			{Name: "x", Range: 1, Seed: 2},
+			{Name: "x1", Range: 1, Seed: 5},
+		}}, SpecMulti{
+			{Independents: IndependentVariableMap{"x": 2, "x1": 5}, Dependent: 3, AntagonistThreshold: 3,
+				ProtagonistThreshold: 3},
+		}, false},
+		{"duplicate variable", SpecParam{Expression: "x", Variables: []VariableParam{
+			{Name: "x", Range: 1}, {Name: "x", Range: 1}}}, nil, true},
+		{"variable range 0", SpecParam{Expression: "x", Variables: []VariableParam{{Name: "x"}}}, nil, true},
+		{"unknown sampling", SpecParam{Expression: "x", Range: 1, Sampling: "bad"}, nil, true},
+		{"unbound variable", SpecParam{Expression: "x*y", Range: 2}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateSpecSimple(tt.specParam, FitnessStrategy{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateSpecSimple() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateSpecSimple() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSpecPoints_Random(t *testing.T) {
+	specParam := SpecParam{
+		Sampling:    SpecSamplingRandom,
+		SampleCount: 50,
+		Variables: []VariableParam{
+			{Name: "x", Range: 4, Seed: -2},
+			{Name: "y", Range: 10, Seed: 5},
+		},
+	}
+	points, err := GenerateSpecPoints(specParam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != specParam.SampleCount {
+		t.Fatalf("GenerateSpecPoints() len = %d, want %d", len(points), specParam.SampleCount)
+	}
+	for i := range points {
+		if points[i]["x"] < -2 || points[i]["x"] >= 2 {
+			t.Errorf("GenerateSpecPoints() x = %v out of range", points[i]["x"])
+		}
+		if points[i]["y"] < 5 || points[i]["y"] >= 15 {
+			t.Errorf("GenerateSpecPoints() y = %v out of range", points[i]["y"])
+		}
+	}
+}
+
+func TestReadSpecCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		dependent string
+		want      SpecMulti
+		wantErr   bool
+	}{
+		{"empty", "", "", nil, true},
+		{"header only", "x,f\n", "", nil, true},
+		{"single column", "x\n1\n", "", nil, true},
+		{"x,f", "x,f\n1,2\n3,4\n", "", SpecMulti{
+			{Independents: IndependentVariableMap{"x": 1}, Dependent: 2},
+			{Independents: IndependentVariableMap{"x": 3}, Dependent: 4},
+		}, false},
+		{"dependent first", "f, x, y\n10, 1, 2\n", "f", SpecMulti{
+			{Independents: IndependentVariableMap{"x": 1, "y": 2}, Dependent: 10},
+		}, false},
+		{"unknown dependent", "x,f\n1,2\n", "g", nil, true},
+		{"bad value", "x,f\n1,a\n", "", nil, true},
+		{"ragged", "x,f\n1\n", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadSpecCSV(strings.NewReader(tt.data), tt.dependent)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadSpecCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadSpecCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSpecJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    SpecMulti
+		wantErr bool
+	}{
+		{"empty", "", nil, true},
+		{"empty array", "[]", nil, true},
+		{"no variables", `[{"dependent": 1}]`, nil, true},
+		{"x", `[{"independents": {"x": 1}, "dependent": 2}]`, SpecMulti{
+			{Independents: IndependentVariableMap{"x": 1}, Dependent: 2},
+		}, false},
+		{"x,y", `[{"independents": {"x": 1, "y": 2}, "dependent": 3}, {"independents": {"x": 0, "y": 0}, "dependent": 0}]`,
+			SpecMulti{
+				{Independents: IndependentVariableMap{"x": 1, "y": 2}, Dependent: 3},
+				{Independents: IndependentVariableMap{"x": 0, "y": 0}, Dependent: 0},
+			}, false},
+		{"mismatched variables", `[{"independents": {"x": 1}, "dependent": 2}, {"independents": {"y": 1}, "dependent": 2}]`,
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadSpecJSON(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadSpecJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadSpecJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSpecDataset(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"data.csv":  "x,f\n1,2\n",
+		"data.json": `[{"independents": {"x": 1}, "dependent": 2}]`,
+		"data.txt":  "x,f\n1,2\n",
+	}
+	for name, data := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := SpecMulti{{Independents: IndependentVariableMap{"x": 1}, Dependent: 2, AntagonistThreshold: 8,
+		ProtagonistThreshold: 2, DivideByZeroPenalty: -1}}
+	tests := []struct {
+		name    string
+		path    string
+		want    SpecMulti
+		wantErr bool
+	}{
+		{"empty path", "", nil, true},
+		{"missing", filepath.Join(dir, "missing.csv"), nil, true},
+		{"unsupported", filepath.Join(dir, "data.txt"), nil, true},
+		{"csv", filepath.Join(dir, "data.csv"), want, false},
+		{"json", filepath.Join(dir, "data.json"), want, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specParam := SpecParam{DatasetPath: tt.path, DivideByZeroPenalty: -1}
+			fitnessStrategy := FitnessStrategy{AntagonistThresholdMultiplier: 4, ProtagonistThresholdMultiplier: 1}
+			got, err := LoadSpecDataset(specParam, fitnessStrategy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadSpecDataset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadSpecDataset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitSpec(t *testing.T) {
+	specParam := SpecParam{Expression: "x*x", Range: 4, Seed: 0}
+	spec, err := GenerateSpecSimple(specParam, FitnessStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		specParam SpecParam
+		wantTrain int
+		wantTest  []float64
+		wantErr   bool
+	}{
+		{"none", specParam, 4, []float64{}, false},
+		{"unknown", SpecParam{Expression: "x*x", Range: 4, TestSplit: "bad"}, 0, nil, true},
+		{"interpolation", SpecParam{Expression: "x*x", Range: 4, TestSplit: SpecTestSplitInterpolation}, 4,
+			[]float64{0.5, 1.5, 2.5}, false},
+		{"extrapolation", SpecParam{Expression: "x*x", Range: 4, TestSplit: SpecTestSplitExtrapolation,
+			TestRange: 1}, 4, []float64{-1, 4}, false},
+		{"random", SpecParam{Expression: "x*x", Range: 4, TestSplit: SpecTestSplitRandom, TestRatio: 0.25}, 3,
+			nil, false},
+		{"random ratio too small", SpecParam{Expression: "x*x", Range: 4, TestSplit: SpecTestSplitRandom,
+			TestRatio: 0.01}, 0, nil, true},
+		{"random ratio 1", SpecParam{Expression: "x*x", Range: 4, TestSplit: SpecTestSplitRandom,
+			TestRatio: 1}, 0, nil, true},
+		{"interpolation dataset", SpecParam{DatasetPath: "data.csv", TestSplit: SpecTestSplitInterpolation}, 0, nil,
+			true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			train, test, err := SplitSpec(tt.specParam, FitnessStrategy{}, spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SplitSpec() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if len(train) != tt.wantTrain {
+				t.Errorf("SplitSpec() train len = %d, want %d", len(train), tt.wantTrain)
+			}
+			if tt.wantTest != nil {
+				got := make([]float64, len(test))
+				for i := range test {
+					got[i] = test[i].Independents["x"]
+					if test[i].Dependent != got[i]*got[i] {
+						t.Errorf("SplitSpec() dependent = %v, want %v", test[i].Dependent, got[i]*got[i])
+					}
+				}
+				if !reflect.DeepEqual(got, tt.wantTest) {
+					t.Errorf("SplitSpec() test = %v, want %v", got, tt.wantTest)
+				}
+			}
+			if len(train)+len(test) < len(spec) {
+				t.Errorf("SplitSpec() lost points from the spec")
+			}
+		})
+	}
+}
@@ -5,7 +5,11 @@ package evolution
 Worst case being a single terminal with value 0.
 */
 
-type Strategable interface{ Apply(t *DualTree) }
+// Strategable transforms a tree, it is what a Strategy names. New strategies are made available with
+// RegisterStrategy.
+type Strategable interface {
+	Apply(t *DualTree, ctx StrategyContext) error
+}
 
 type Strategy string
 
@@ -52,16 +56,36 @@ const (
 	// StrategyAddTreeWithMult will add a subTree with a root of add to a given leaf node
 	StrategyAddTreeWithAdd = "AddTreeWithAdd"
 	StrategyAddTreeWithDiv = "AddTreeWithDiv"
+	// StrategyHoist replaces the tree with one of its randomly selected subtrees.
+	StrategyHoist = "HoistR"
+	// StrategyShrink replaces a randomly selected non-terminal and everything below it with a terminal.
+	StrategyShrink = "ShrinkR"
+	// StrategyDuplicateSubTree copies a randomly selected subtree over a randomly selected terminal.
+	StrategyDuplicateSubTree = "DuplicateSubTreeR"
+	// StrategySwapChildren swaps the left and right children of a randomly selected binary non-terminal e.g. x-4
+	// becomes 4-x.
+	StrategySwapChildren = "SwapChildrenR"
+	// StrategyPerturbConstant adds or subtracts DefaultConstantPerturbation from a randomly selected numeric
+	// terminal.
+	StrategyPerturbConstant = "PerturbConstantR"
 
 	// ####################################################### DETERMINISTIC STRATEGIES #############################
 	// StrategySkip performs no operations on the given subtree.
 	StrategySkip = "SkipD"
 	// StrategyFellTree destroys the tree and sets its root to 0 and kills it all.
 	StrategyFellTree = "FellTreeD"
-	StrategyMultXD   = "MultXD"
-	StrategyAddXD    = "AddXD"
-	StrategySubXD    = "SubXD"
-	StrategyDivXD    = "DivXD"
+	// StrategyMultXD, StrategyAddXD, StrategySubXD and StrategyDivXD attach an independent variable to the root of
+	// the tree with the given operator e.g. T*x. In a multivariate spec the first variable of the terminals is
+	// attached, use Attach(*,y,root) to attach another. They are the same as Attach(*,,root), Attach(+,,root),
+	// Attach(-,,root) and Attach(/,,root), other terminals and positions can be attached in the same way e.g.
+	// Attach(*,2,root) or Attach(-,1,rightmost), see AttachStrategy.
+	StrategyMultXD = "MultXD"
+	StrategyAddXD  = "AddXD"
+	StrategySubXD  = "SubXD"
+	StrategyDivXD  = "DivXD"
 
 	//Strategy
 )
+
+// DefaultConstantPerturbation is the amount StrategyPerturbConstant adds to or subtracts from a constant.
+const DefaultConstantPerturbation = 1.0
@@ -0,0 +1,163 @@
+package evolution
+
+import (
+	"fmt"
+	"github.com/martinomburajr/masters-go/eval"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// AttachRoot makes a new root from the operator with the tree on its left and the terminal on its right e.g. T*2
+	AttachRoot = "root"
+	// AttachLeftmostLeaf replaces the leftmost leaf L of the tree with L<op>terminal
+	AttachLeftmostLeaf = "leftmost"
+	// AttachRightmostLeaf replaces the rightmost leaf L of the tree with L<op>terminal
+	AttachRightmostLeaf = "rightmost"
+
+	attachPrefix = "Attach("
+)
+
+// AttachStrategy is a deterministic strategy that attaches a terminal to the tree with a binary operator at a given
+// position. It is named in the available strategies as Attach(op,terminal,position) e.g. Attach(*,2,root),
+// Attach(+,x,rightmost) or Attach(-,1,leftmost), so new attachments do not need a new Strategy constant.
+// If the terminal is left empty e.g. Attach(*,,root) the independent variable is attached,
+// see StrategyMultXD.
+type AttachStrategy struct {
+	Operator string
+	Terminal string
+	Position string
+}
+
+// Name returns the Strategy the AttachStrategy is known by in the available strategies.
+func (a AttachStrategy) Name() Strategy {
+	return Strategy(fmt.Sprintf("%s%s,%s,%s)", attachPrefix, a.Operator, a.Terminal, a.Position))
+}
+
+// Apply attaches the terminal to the tree.
+func (a AttachStrategy) Apply(t *DualTree, ctx StrategyContext) error {
+	if t == nil || t.root == nil {
+		return fmt.Errorf("AttachStrategy | treeNode you are attaching to has nil root")
+	}
+	if err := a.validate(); err != nil {
+		return err
+	}
+	if err := a.validateTerminal(ctx.Terminals); err != nil {
+		return err
+	}
+
+	terminal := SymbolicExpression{arity: 0, value: a.Terminal, kind: 0}
+	if a.Terminal == "" {
+		terminal = independentVariable(ctx.Terminals)
+	}
+	if a.Position == AttachRoot {
+		return attachVariable(t, a.Operator, terminal)
+	}
+
+	leaf := t.root
+	for leaf.left != nil || leaf.right != nil {
+		if (a.Position == AttachRightmostLeaf && leaf.right != nil) || leaf.left == nil {
+			leaf = leaf.right
+		} else {
+			leaf = leaf.left
+		}
+	}
+
+	// The leaf is changed in place so its parent does not need to be found
+	left := leaf.Clone()
+	leaf.value = a.Operator
+	leaf.arity = 2
+	leaf.left = &left
+	leaf.right = terminal.ToDualTreeNode(RandString(5))
+	return nil
+}
+
+func (a AttachStrategy) validate() error {
+	if len(a.Operator) != 1 || !strings.Contains("+-*/", a.Operator) {
+		if arity, ok := eval.FunctionArity(a.Operator); !ok || arity != 2 {
+			return fmt.Errorf("AttachStrategy | operator %q is not a binary operator", a.Operator)
+		}
+	}
+	if !isNumeric(a.Terminal) && !isVariableName(a.Terminal) {
+		return fmt.Errorf("AttachStrategy | terminal %q should be a numeric constant or a variable", a.Terminal)
+	}
+	switch a.Position {
+	case AttachRoot, AttachLeftmostLeaf, AttachRightmostLeaf:
+	default:
+		return fmt.Errorf("AttachStrategy | unknown position %q, use one of %s, %s or %s", a.Position, AttachRoot,
+			AttachLeftmostLeaf, AttachRightmostLeaf)
+	}
+	return nil
+}
+
+// validateTerminal checks that a variable terminal is one of the given terminals. Numeric constants and the empty
+// terminal, which attaches the independent variable, are always valid.
+func (a AttachStrategy) validateTerminal(terminals []SymbolicExpression) error {
+	if a.Terminal == "" || isNumeric(a.Terminal) {
+		return nil
+	}
+	for i := range terminals {
+		if terminals[i].value == a.Terminal {
+			return nil
+		}
+	}
+	return fmt.Errorf("AttachStrategy | variable %q is not one of the available terminals", a.Terminal)
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// isVariableName reports whether s is empty or a name made of letters, digits and underscores that does not start
+// with a digit.
+func isVariableName(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseAttachStrategy parses a strategy of the form Attach(op,terminal,position). Spaces around each argument are
+// ignored.
+func ParseAttachStrategy(name Strategy) (AttachStrategy, error) {
+	s := string(name)
+	if !strings.HasPrefix(s, attachPrefix) || !strings.HasSuffix(s, ")") {
+		return AttachStrategy{}, fmt.Errorf("ParseAttachStrategy | %q is not of the form Attach(op,terminal,"+
+			"position)", name)
+	}
+
+	args := strings.Split(strings.TrimSuffix(strings.TrimPrefix(s, attachPrefix), ")"), ",")
+	if len(args) != 3 {
+		return AttachStrategy{}, fmt.Errorf("ParseAttachStrategy | %q should have 3 arguments | got: %d", name,
+			len(args))
+	}
+	attach := AttachStrategy{
+		Operator: strings.TrimSpace(args[0]),
+		Terminal: strings.TrimSpace(args[1]),
+		Position: strings.TrimSpace(args[2]),
+	}
+	if err := attach.validate(); err != nil {
+		return AttachStrategy{}, err
+	}
+	return attach, nil
+}
+
+// AttachStrategies returns the name of an AttachStrategy for every combination of the operators, terminals and
+// positions. It allows parameter sweeps to enumerate attachments e.g.
+// AttachStrategies([]string{"*", "+"}, []string{"x", "2"}, []string{AttachRoot}).
+func AttachStrategies(operators, terminals, positions []string) []Strategy {
+	strategies := make([]Strategy, 0, len(operators)*len(terminals)*len(positions))
+	for _, operator := range operators {
+		for _, terminal := range terminals {
+			for _, position := range positions {
+				strategies = append(strategies, AttachStrategy{Operator: operator, Terminal: terminal,
+					Position: position}.Name())
+			}
+		}
+	}
+	return strategies
+}
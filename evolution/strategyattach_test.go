@@ -0,0 +1,102 @@
+package evolution
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAttachStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy Strategy
+		want     AttachStrategy
+		wantErr  bool
+	}{
+		{"root", "Attach(*,2,root)", AttachStrategy{"*", "2", AttachRoot}, false},
+		{"spaces", "Attach( + , x , rightmost )", AttachStrategy{"+", "x", AttachRightmostLeaf}, false},
+		{"independent-variable", "Attach(-,,leftmost)", AttachStrategy{"-", "", AttachLeftmostLeaf}, false},
+		{"binary-function", "Attach(pow,2,root)", AttachStrategy{"pow", "2", AttachRoot}, false},
+		{"unary-function", "Attach(sin,2,root)", AttachStrategy{}, true},
+		{"unknown-operator", "Attach(%,2,root)", AttachStrategy{}, true},
+		{"unknown-position", "Attach(*,2,middle)", AttachStrategy{}, true},
+		{"expression-terminal", "Attach(*,x+1,root)", AttachStrategy{}, true},
+		{"numeric-variable", "Attach(*,2x,root)", AttachStrategy{}, true},
+		{"too-few-arguments", "Attach(*,2)", AttachStrategy{}, true},
+		{"too-many-arguments", "Attach(*,2,root,root)", AttachStrategy{}, true},
+		{"unclosed", "Attach(*,2,root", AttachStrategy{}, true},
+		{"not-attach", StrategyMultXD, AttachStrategy{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAttachStrategy(tt.strategy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAttachStrategy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseAttachStrategy() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachStrategy_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		tree     *DualTree
+		strategy Strategy
+		want     string
+		wantErr  bool
+	}{
+		{"nil", TreeNil(), "Attach(*,2,root)", "", true},
+		{"unknown-position", TreeT_NT_T_0(), "Attach(*,2,middle)", "", true},
+		{"T-root", TreeT_X(), "Attach(*,2,root)", "((x)*(2))", false},
+		{"T-leftmost", TreeT_X(), "Attach(+,1,leftmost)", "((x)+(1))", false},
+		{"T-NT-T-root", TreeT_NT_T_0(), "Attach(*,2,root)", "(((x)*(4))*(2))", false},
+		{"T-NT-T-leftmost", TreeT_NT_T_0(), "Attach(-,1,leftmost)", "(((x)-(1))*(4))", false},
+		{"T-NT-T-rightmost", TreeT_NT_T_0(), "Attach(+,x,rightmost)", "((x)*((4)+(x)))", false},
+		{"T-NT-T-independent-variable", TreeT_NT_T_0(), "Attach(/,,rightmost)", "((x)*((4)/(x)))", false},
+		{"Unary-rightmost", TreeVine_D3(), "Attach(*,2,rightmost)", "(sin(sin(sin((x)*(2)))))", false},
+		{"Binary-function", TreeT_X(), "Attach(pow,2,root)", "(pow((x),(2)))", false},
+		{"unknown-variable", TreeT_X(), "Attach(*,z,root)", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Program{T: tt.tree}
+			err := p.ApplyStrategy(tt.strategy, []SymbolicExpression{X1, Const4}, []SymbolicExpression{Add}, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Program.ApplyStrategy(%s) error = %v, wantErr %v", tt.strategy, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if err := p.T.Validate(); err != nil {
+				t.Errorf("tree with an attachment should be valid: %v", err)
+			}
+			got, err := p.T.ToMathematicalString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Program.ApplyStrategy(%s) got = %s, want %s", tt.strategy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachStrategies(t *testing.T) {
+	got := AttachStrategies([]string{"*", "+"}, []string{"x", "2"}, []string{AttachRoot})
+	want := []Strategy{"Attach(*,x,root)", "Attach(*,2,root)", "Attach(+,x,root)", "Attach(+,2,root)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AttachStrategies() got = %v, want %v", got, want)
+	}
+	terminals := []SymbolicExpression{X1}
+	if err := validateAvailableStrategies(Strategies{AntagonistAvailableStrategies: got}, terminals); err != nil {
+		t.Errorf("validateAvailableStrategies() error = %v", err)
+	}
+	if err := validateAvailableStrategies(Strategies{AntagonistAvailableStrategies: []Strategy{
+		"Attach(*,x,middle)"}}, terminals); err == nil {
+		t.Errorf("validateAvailableStrategies() should return an error for an unknown position")
+	}
+}
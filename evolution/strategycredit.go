@@ -0,0 +1,249 @@
+package evolution
+
+import (
+	"fmt"
+	"gonum.org/v1/gonum/stat"
+	"math"
+	"sort"
+)
+
+// DefaultStrategyAblationSamples is the number of competitions each ablated genome is averaged over if
+// StatisticsOutput.StrategyAblationSamples is not set. Most strategies are stochastic so a single competition is
+// not representative.
+const DefaultStrategyAblationSamples = 5
+
+// GeneAblation is the fitness of an individual with a single gene removed, re-evaluated against the same opponent.
+type GeneAblation struct {
+	// Gene is the index of the removed gene in the individual's Strategy.
+	Gene     int
+	Strategy Strategy
+	// Baseline and Ablated are the mean fitness of the individual with and without the gene.
+	Baseline float64
+	Ablated  float64
+	// Effect is Baseline-Ablated. A positive effect means the gene helps the individual.
+	Effect float64
+}
+
+// StrategyCredit is the credit a strategy is given for the fitness of individuals of a given kind in a run.
+type StrategyCredit struct {
+	Strategy Strategy
+	Kind     int
+	// Genes is the number of genes of the top individual in the run that hold the strategy.
+	Genes int
+	// AblationEffect is the mean GeneAblation.Effect of those genes against the top individual of the other kind,
+	// which is an approximation, see StrategyCredits. It is NaN if the top individual does not hold the strategy.
+	AblationEffect float64
+	// Presence is the fraction of individuals across all generations that hold the strategy at least once.
+	Presence float64
+	// Correlation is the correlation between holding the strategy and AverageFitness across all generations. It is
+	// NaN if every or no individual holds the strategy.
+	Correlation float64
+}
+
+// StrategyCredits assigns credit to every strategy held by the top individuals or the populations of the
+// generations. Each gene of the top antagonist is ablated against the top protagonist and vice versa,
+// see AblateStrategies, and gene presence is correlated with fitness across the populations,
+// see StrategyPresenceCorrelation. Antagonist credits are followed by protagonist credits, each sorted by strategy.
+//
+// The ablation is an approximation. The top antagonist and top protagonist may come from different generations and
+// may never have competed, and the topology may have scored them against other opponents entirely. The ablation
+// effect therefore measures how a gene fares against the best opponent of the run, not against the opponents that
+// earned the individual its fitness.
+func StrategyCredits(topAntagonist, topProtagonist *Individual, generations []*Generation,
+	params EvolutionParams) ([]StrategyCredit, error) {
+	if topAntagonist == nil || topProtagonist == nil {
+		return nil, fmt.Errorf("StrategyCredits | top individuals cannot be nil")
+	}
+	samples := params.StatisticsOutput.StrategyAblationSamples
+	if samples < 1 {
+		samples = DefaultStrategyAblationSamples
+	}
+
+	antagonists := make([]*Individual, 0)
+	protagonists := make([]*Individual, 0)
+	for _, generation := range generations {
+		if generation == nil {
+			continue
+		}
+		antagonists = append(antagonists, generation.Antagonists...)
+		protagonists = append(protagonists, generation.Protagonists...)
+	}
+
+	credits := make([]StrategyCredit, 0)
+	for _, kind := range []struct {
+		top, opponent *Individual
+		population    []*Individual
+	}{
+		{topAntagonist, topProtagonist, antagonists},
+		{topProtagonist, topAntagonist, protagonists},
+	} {
+		ablations, err := AblateStrategies(kind.top, kind.opponent, params, samples)
+		if err != nil {
+			return nil, err
+		}
+		presence, correlation := StrategyPresenceCorrelation(kind.population)
+
+		effects := map[Strategy][]float64{}
+		for _, ablation := range ablations {
+			effects[ablation.Strategy] = append(effects[ablation.Strategy], ablation.Effect)
+		}
+		strategies := make([]Strategy, 0, len(presence))
+		for strategy := range presence {
+			strategies = append(strategies, strategy)
+		}
+		for strategy := range effects {
+			if _, ok := presence[strategy]; !ok {
+				strategies = append(strategies, strategy)
+			}
+		}
+		sort.Slice(strategies, func(i, j int) bool {
+			return strategies[i] < strategies[j]
+		})
+
+		for _, strategy := range strategies {
+			credit := StrategyCredit{
+				Strategy:       strategy,
+				Kind:           kind.top.Kind,
+				Genes:          len(effects[strategy]),
+				AblationEffect: math.NaN(),
+				Presence:       presence[strategy],
+				Correlation:    math.NaN(),
+			}
+			if credit.Genes > 0 {
+				credit.AblationEffect = stat.Mean(effects[strategy], nil)
+			}
+			if c, ok := correlation[strategy]; ok {
+				credit.Correlation = c
+			}
+			credits = append(credits, credit)
+		}
+	}
+	return credits, nil
+}
+
+// AblateStrategies removes each gene of the individual in turn and compares its mean fitness over samples
+// competitions against the opponent with the mean fitness of its full Strategy. The programs are rebuilt from
+// params.StartIndividual the same way a competition builds them, so neither individual is modified.
+func AblateStrategies(individual, opponent *Individual, params EvolutionParams, samples int) ([]GeneAblation, error) {
+	if individual == nil || opponent == nil {
+		return nil, fmt.Errorf("AblateStrategies | individual and opponent cannot be nil")
+	}
+	if individual.Kind == opponent.Kind {
+		return nil, fmt.Errorf("AblateStrategies | individual and opponent cannot be of the same kind")
+	}
+	if samples < 1 {
+		return nil, fmt.Errorf("AblateStrategies | samples should be at least 1 | got: %d", samples)
+	}
+	params.Strategies.EnableTrace = false
+
+	baseline, err := strategyFitness(individual.Kind, individual.Strategy, opponent.Strategy, params, samples)
+	if err != nil {
+		return nil, err
+	}
+
+	ablations := make([]GeneAblation, len(individual.Strategy))
+	for i := range individual.Strategy {
+		genes := make([]Strategy, 0, len(individual.Strategy)-1)
+		genes = append(genes, individual.Strategy[:i]...)
+		genes = append(genes, individual.Strategy[i+1:]...)
+
+		ablated, err := strategyFitness(individual.Kind, genes, opponent.Strategy, params, samples)
+		if err != nil {
+			return nil, err
+		}
+		ablations[i] = GeneAblation{
+			Gene:     i,
+			Strategy: individual.Strategy[i],
+			Baseline: baseline,
+			Ablated:  ablated,
+			Effect:   baseline - ablated,
+		}
+	}
+	return ablations, nil
+}
+
+// strategyFitness returns the mean fitness of an individual of the given kind holding genes over samples
+// competitions against an opponent holding opponentGenes.
+func strategyFitness(kind int, genes, opponentGenes []Strategy, params EvolutionParams, samples int) (float64,
+	error) {
+	antagonistGenes, protagonistGenes := genes, opponentGenes
+	if kind == IndividualProtagonist {
+		antagonistGenes, protagonistGenes = opponentGenes, genes
+	}
+
+	total := 0.0
+	for i := 0; i < samples; i++ {
+		start, err := params.StartIndividual.Clone()
+		if err != nil {
+			return 0, err
+		}
+		antagonist := &Individual{Kind: IndividualAntagonist, Strategy: antagonistGenes, Program: &start}
+		if err := antagonist.applyStrategies(params); err != nil {
+			return 0, err
+		}
+		tree, err := antagonist.Program.T.Clone()
+		if err != nil {
+			return 0, err
+		}
+		protagonist := &Individual{Kind: IndividualProtagonist, Strategy: protagonistGenes, Program: &Program{T: &tree}}
+		if err := protagonist.applyStrategies(params); err != nil {
+			return 0, err
+		}
+
+		antagonistFitness, protagonistFitness, _, _, err := params.EvaluateFitness(antagonist.Program,
+			protagonist.Program)
+		if err != nil {
+			return 0, err
+		}
+		if kind == IndividualProtagonist {
+			total += protagonistFitness
+		} else {
+			total += antagonistFitness
+		}
+	}
+	return total / float64(samples), nil
+}
+
+// StrategyPresenceCorrelation returns, for every strategy held by the individuals, the fraction of individuals that
+// hold it and the correlation between holding it and AverageFitness. Strategies every or no individual holds have a
+// correlation of NaN.
+func StrategyPresenceCorrelation(individuals []*Individual) (presence map[Strategy]float64,
+	correlation map[Strategy]float64) {
+	presence = map[Strategy]float64{}
+	correlation = map[Strategy]float64{}
+
+	held := make([]map[Strategy]bool, 0, len(individuals))
+	fitness := make([]float64, 0, len(individuals))
+	for _, individual := range individuals {
+		if individual == nil {
+			continue
+		}
+		strategies := map[Strategy]bool{}
+		for _, strategy := range individual.Strategy {
+			strategies[strategy] = true
+		}
+		held = append(held, strategies)
+		fitness = append(fitness, individual.AverageFitness)
+	}
+	if len(held) < 1 {
+		return presence, correlation
+	}
+
+	for _, strategies := range held {
+		for strategy := range strategies {
+			presence[strategy]++
+		}
+	}
+	indicator := make([]float64, len(held))
+	for strategy, count := range presence {
+		presence[strategy] = count / float64(len(held))
+		for i := range held {
+			indicator[i] = 0
+			if held[i][strategy] {
+				indicator[i] = 1
+			}
+		}
+		correlation[strategy] = stat.Correlation(indicator, fitness, nil)
+	}
+	return presence, correlation
+}
@@ -0,0 +1,160 @@
+package evolution
+
+import (
+	"math"
+	"testing"
+)
+
+func creditParams() EvolutionParams {
+	params := traceParams(false)
+	params.StartIndividual = Program{T: TreeT_X()}
+	params.Spec = fitnessCacheTestSpec()
+	params.SpecParam.DivideByZeroStrategy = DivByZeroSteadyPenalize
+	params.FitnessStrategy.Type = FitnessRatio
+	return params
+}
+
+func TestAblateStrategies(t *testing.T) {
+	antagonist := &Individual{Kind: IndividualAntagonist, Strategy: []Strategy{"Attach(*,x,root)", StrategySkip}}
+	protagonist := &Individual{Kind: IndividualProtagonist, Strategy: []Strategy{"Attach(+,2,root)"}}
+
+	fitness := func(antagonist, protagonist string) (float64, float64) {
+		params := creditParams()
+		antagonistFitness, protagonistFitness, _, _, err := params.EvaluateFitness(
+			fitnessCacheTestProgram(t, antagonist), fitnessCacheTestProgram(t, protagonist))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return antagonistFitness, protagonistFitness
+	}
+	antagonistBaseline, protagonistBaseline := fitness("x*x", "x*x+2")
+	antagonistWithoutMult, _ := fitness("x", "x+2")
+	_, protagonistWithoutAdd := fitness("x*x", "x*x")
+
+	tests := []struct {
+		name       string
+		individual *Individual
+		opponent   *Individual
+		samples    int
+		want       []GeneAblation
+		wantErr    bool
+	}{
+		{"antagonist", antagonist, protagonist, 2, []GeneAblation{
+			{0, "Attach(*,x,root)", antagonistBaseline, antagonistWithoutMult, antagonistBaseline - antagonistWithoutMult},
+			{1, StrategySkip, antagonistBaseline, antagonistBaseline, 0},
+		}, false},
+		{"protagonist", protagonist, antagonist, 1, []GeneAblation{
+			{0, "Attach(+,2,root)", protagonistBaseline, protagonistWithoutAdd,
+				protagonistBaseline - protagonistWithoutAdd},
+		}, false},
+		{"same-kind", antagonist, antagonist, 1, nil, true},
+		{"no-samples", antagonist, protagonist, 0, nil, true},
+		{"nil-opponent", antagonist, nil, 1, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AblateStrategies(tt.individual, tt.opponent, creditParams(), tt.samples)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AblateStrategies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("AblateStrategies() got = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("AblateStrategies() gene %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+	if len(antagonist.Strategy) != 2 || antagonist.Program != nil {
+		t.Errorf("AblateStrategies() should not modify the individual")
+	}
+}
+
+func TestStrategyPresenceCorrelation(t *testing.T) {
+	individuals := []*Individual{
+		{Strategy: []Strategy{StrategySkip, StrategyMultXD, StrategyMultXD}, AverageFitness: 1},
+		{Strategy: []Strategy{StrategySkip}, AverageFitness: 0},
+		{Strategy: []Strategy{StrategyMultXD}, AverageFitness: 1},
+		nil,
+	}
+	presence, correlation := StrategyPresenceCorrelation(individuals)
+
+	tests := []struct {
+		strategy        Strategy
+		wantPresence    float64
+		wantCorrelation float64
+	}{
+		{StrategyMultXD, 2.0 / 3, 1},
+		{StrategySkip, 2.0 / 3, -0.5},
+	}
+	if len(presence) != len(tests) || len(correlation) != len(tests) {
+		t.Fatalf("StrategyPresenceCorrelation() presence = %v, correlation = %v", presence, correlation)
+	}
+	for _, tt := range tests {
+		if math.Abs(presence[tt.strategy]-tt.wantPresence) > 1e-9 {
+			t.Errorf("StrategyPresenceCorrelation() presence of %s = %v, want %v", tt.strategy,
+				presence[tt.strategy], tt.wantPresence)
+		}
+		if math.Abs(correlation[tt.strategy]-tt.wantCorrelation) > 1e-9 {
+			t.Errorf("StrategyPresenceCorrelation() correlation of %s = %v, want %v", tt.strategy,
+				correlation[tt.strategy], tt.wantCorrelation)
+		}
+	}
+
+	_, correlation = StrategyPresenceCorrelation([]*Individual{
+		{Strategy: []Strategy{StrategySkip}, AverageFitness: 1},
+		{Strategy: []Strategy{StrategySkip}, AverageFitness: 0},
+	})
+	if !math.IsNaN(correlation[StrategySkip]) {
+		t.Errorf("a strategy every individual holds should have a correlation of NaN. got: %v",
+			correlation[StrategySkip])
+	}
+}
+
+func TestStrategyCredits(t *testing.T) {
+	antagonist := &Individual{Kind: IndividualAntagonist, Strategy: []Strategy{StrategySkip}, AverageFitness: 1}
+	protagonist := &Individual{Kind: IndividualProtagonist, Strategy: []Strategy{"Attach(+,2,root)"}}
+	generations := []*Generation{
+		{
+			Antagonists: []*Individual{antagonist,
+				{Kind: IndividualAntagonist, Strategy: []Strategy{StrategyMultXD}, AverageFitness: 0}},
+			Protagonists: []*Individual{protagonist},
+		},
+	}
+
+	got, err := StrategyCredits(antagonist, protagonist, generations, creditParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []StrategyCredit{
+		{Strategy: StrategyMultXD, Kind: IndividualAntagonist, Genes: 0, AblationEffect: math.NaN(), Presence: 0.5,
+			Correlation: -1},
+		{Strategy: StrategySkip, Kind: IndividualAntagonist, Genes: 1, AblationEffect: 0, Presence: 0.5,
+			Correlation: 1},
+		{Strategy: "Attach(+,2,root)", Kind: IndividualProtagonist, Genes: 1, Presence: 1,
+			Correlation: math.NaN()},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("StrategyCredits() got = %+v, want %+v", got, want)
+	}
+	equal := func(a, b float64) bool {
+		return math.Abs(a-b) < 1e-9 || (math.IsNaN(a) && math.IsNaN(b))
+	}
+	for i := range want {
+		if got[i].Strategy != want[i].Strategy || got[i].Kind != want[i].Kind || got[i].Genes != want[i].Genes ||
+			!equal(got[i].Presence, want[i].Presence) || !equal(got[i].Correlation, want[i].Correlation) {
+			t.Errorf("StrategyCredits() credit %d = %+v, want %+v", i, got[i], want[i])
+		}
+		if i < 2 && !equal(got[i].AblationEffect, want[i].AblationEffect) {
+			t.Errorf("StrategyCredits() credit %d AblationEffect = %v, want %v", i, got[i].AblationEffect,
+				want[i].AblationEffect)
+		}
+	}
+
+	if _, err := StrategyCredits(nil, protagonist, generations, creditParams()); err == nil {
+		t.Errorf("StrategyCredits() should return an error if a top individual is nil")
+	}
+}
@@ -0,0 +1,185 @@
+package evolution
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// StrategyContext is everything a Strategable may use to transform a tree.
+type StrategyContext struct {
+	// Terminals and NonTerminals are the symbolic expressions new nodes are drawn from.
+	Terminals    []SymbolicExpression
+	NonTerminals []SymbolicExpression
+	// Depth is the maximum depth of any subtree the strategy generates.
+	Depth int
+	// Rand is the source of randomness for the strategy. ApplyStrategy sets it to a generator backed by the shared
+	// math/rand source, so seeding math/rand keeps runs reproducible.
+	Rand *rand.Rand
+}
+
+// StrategyFunc allows an ordinary function to be used as a Strategable.
+type StrategyFunc func(t *DualTree, ctx StrategyContext) error
+
+func (f StrategyFunc) Apply(t *DualTree, ctx StrategyContext) error {
+	return f(t, ctx)
+}
+
+// sharedSource is a rand.Source that draws from the shared math/rand source.
+type sharedSource struct{}
+
+func (sharedSource) Int63() int64    { return rand.Int63() }
+func (sharedSource) Seed(seed int64) { rand.Seed(seed) }
+
+var sharedRand = rand.New(sharedSource{})
+
+var strategyRegistry = newRegistry[Strategy, Strategable]("strategy", "RegisterStrategy", "StrategyByName")
+
+func init() {
+	strategyRegistry.registerBuiltIn(map[Strategy]Strategable{
+		StrategyDeleteNonTerminal: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.DeleteNonTerminal()
+		}),
+		StrategyDeleteMalicious: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.DeleteMalicious()
+		}),
+		StrategyDeleteTerminal: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.DeleteTerminal()
+		}),
+		StrategyMutateNonTerminal: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.MutateNonTerminal(ctx.NonTerminals)
+		}),
+		StrategyMutateTerminal: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.MutateTerminal(ctx.Terminals)
+		}),
+		StrategyReplaceBranch: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			tree, err := GenerateRandomTree(ctx.Depth, ctx.Terminals, ctx.NonTerminals)
+			if err != nil {
+				return err
+			}
+			return t.ReplaceBranch(*tree)
+		}),
+		StrategyReplaceBranchX: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			tree, err := GenerateRandomTreeEnforceIndependentVariable(ctx.Depth, independentVariable(ctx.Terminals),
+				ctx.Terminals, ctx.NonTerminals)
+			if err != nil {
+				return err
+			}
+			return t.ReplaceBranch(*tree)
+		}),
+		StrategyAddRandomSubTree: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			tree, err := GenerateRandomTree(ctx.Depth, ctx.Terminals, ctx.NonTerminals)
+			if err != nil {
+				return err
+			}
+			return t.AddSubTree(tree)
+		}),
+		StrategyAddToLeaf: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			tree, err := GenerateRandomTree(ctx.Depth, ctx.Terminals, ctx.NonTerminals)
+			if err != nil {
+				return err
+			}
+			return t.AddToLeaf(*tree)
+		}),
+		StrategyAddToLeafX: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			tree, err := GenerateRandomTreeEnforceIndependentVariable(ctx.Depth, independentVariable(ctx.Terminals),
+				ctx.Terminals, ctx.NonTerminals)
+			if err != nil {
+				return err
+			}
+			return t.AddToLeaf(*tree)
+		}),
+		StrategyAddTreeWithMult: addTreeWithOperator("*"),
+		StrategyAddTreeWithSub:  addTreeWithOperator("-"),
+		StrategyAddTreeWithAdd:  addTreeWithOperator("+"),
+		StrategyAddTreeWithDiv:  addTreeWithOperator("/"),
+		StrategyHoist: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.Hoist()
+		}),
+		StrategyShrink: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.Shrink(ctx.Terminals)
+		}),
+		StrategyDuplicateSubTree: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.DuplicateSubTree()
+		}),
+		StrategySwapChildren: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.SwapChildren()
+		}),
+		StrategyPerturbConstant: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.PerturbConstant(DefaultConstantPerturbation)
+		}),
+
+		// DETERMINISTIC STRATEGIES
+		StrategySkip: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return nil
+		}),
+		StrategyFellTree: StrategyFunc(func(t *DualTree, ctx StrategyContext) error {
+			return t.FellTree()
+		}),
+		StrategyMultXD: AttachStrategy{Operator: "*", Position: AttachRoot},
+		StrategyAddXD:  AttachStrategy{Operator: "+", Position: AttachRoot},
+		StrategySubXD:  AttachStrategy{Operator: "-", Position: AttachRoot},
+		StrategyDivXD:  AttachStrategy{Operator: "/", Position: AttachRoot},
+	})
+}
+
+// addTreeWithOperator adds a random subtree whose non-terminals are all the given operator to a random leaf.
+func addTreeWithOperator(operator string) StrategyFunc {
+	return func(t *DualTree, ctx StrategyContext) error {
+		tree, err := GenerateRandomTree(ctx.Depth, ctx.Terminals,
+			[]SymbolicExpression{{arity: 2, value: operator, kind: 1}})
+		if err != nil {
+			return err
+		}
+		return t.AddToLeaf(*tree)
+	}
+}
+
+// RegisterStrategy makes a strategy available to Strategies.AntagonistAvailableStrategies and
+// Strategies.ProtagonistAvailableStrategies under the given name.
+// It returns an error if the name is empty or already taken.
+func RegisterStrategy(name Strategy, strategy Strategable) error {
+	return strategyRegistry.register(name, strategy)
+}
+
+// StrategyByName returns the registered strategy with the given name. Names of the form Attach(op,terminal,position)
+// do not need to be registered, see AttachStrategy.
+func StrategyByName(name Strategy) (Strategable, error) {
+	if strategy, ok := strategyRegistry.lookup(name); ok {
+		return strategy, nil
+	}
+
+	if strings.HasPrefix(string(name), attachPrefix) {
+		attach, err := ParseAttachStrategy(name)
+		if err != nil {
+			return nil, fmt.Errorf("StrategyByName | %v", err)
+		}
+		return attach, nil
+	}
+	return strategyRegistry.byName(name)
+}
+
+// RegisteredStrategies returns the sorted names of all registered strategies.
+func RegisteredStrategies() []Strategy {
+	return strategyRegistry.names()
+}
+
+// validateAvailableStrategies checks that every available strategy of both kinds is registered and that every
+// AttachStrategy attaches a numeric constant or one of the terminals.
+func validateAvailableStrategies(s Strategies, terminals []SymbolicExpression) error {
+	for _, available := range [][]Strategy{s.AntagonistAvailableStrategies, s.ProtagonistAvailableStrategies} {
+		for _, strategy := range available {
+			strategable, err := StrategyByName(strategy)
+			if err != nil {
+				return fmt.Errorf("unknown strategy %q in the available strategies, use one of %v or "+
+					"Attach(op,terminal,position)", strategy, RegisteredStrategies())
+			}
+			if attach, ok := strategable.(AttachStrategy); ok {
+				if err := attach.validateTerminal(terminals); err != nil {
+					return fmt.Errorf("invalid strategy %q in the available strategies | %v", strategy, err)
+				}
+			}
+		}
+	}
+	return nil
+}
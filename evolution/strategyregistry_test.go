@@ -0,0 +1,98 @@
+package evolution
+
+import (
+	"testing"
+)
+
+func TestRegisterStrategy(t *testing.T) {
+	for _, name := range []Strategy{StrategyDeleteNonTerminal, StrategyDeleteMalicious, StrategyDeleteTerminal,
+		StrategyMutateNonTerminal, StrategyMutateTerminal, StrategyReplaceBranch, StrategyReplaceBranchX,
+		StrategyAddRandomSubTree, StrategyAddToLeaf, StrategyAddToLeafX, StrategyAddTreeWithMult,
+		StrategyAddTreeWithSub, StrategyAddTreeWithAdd, StrategyAddTreeWithDiv, StrategySkip, StrategyFellTree,
+		StrategyMultXD, StrategyAddXD, StrategySubXD, StrategyDivXD, StrategyHoist, StrategyShrink,
+		StrategyDuplicateSubTree, StrategySwapChildren, StrategyPerturbConstant} {
+		if _, err := StrategyByName(name); err != nil {
+			t.Errorf("StrategyByName(%s) error = %v", name, err)
+		}
+	}
+	if _, err := StrategyByName("StrategyUnknown"); err == nil {
+		t.Errorf("StrategyByName(StrategyUnknown) should return an error")
+	}
+
+	// doubleRoot replaces the tree with root*2
+	doubleRoot := StrategyFunc(func(tree *DualTree, ctx StrategyContext) error {
+		if ctx.Rand == nil {
+			t.Errorf("StrategyContext.Rand should not be nil")
+		}
+		return tree.AttachSubTree(&DualTree{root: &DualTreeNode{key: "m", value: "*", arity: 2,
+			right: &DualTreeNode{key: "c", value: "2"}}})
+	})
+	tests := []struct {
+		name     Strategy
+		strategy Strategable
+		wantErr  bool
+	}{
+		{"", doubleRoot, true},
+		{"StrategyTestDoubleRoot", nil, true},
+		{StrategyFellTree, doubleRoot, true},
+		{"StrategyTestDoubleRoot", doubleRoot, false},
+		{"StrategyTestDoubleRoot", doubleRoot, true},
+	}
+	for _, tt := range tests {
+		if err := RegisterStrategy(tt.name, tt.strategy); (err != nil) != tt.wantErr {
+			t.Errorf("RegisterStrategy(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	found := false
+	for _, name := range RegisteredStrategies() {
+		found = found || name == "StrategyTestDoubleRoot"
+	}
+	if !found {
+		t.Errorf("RegisteredStrategies() does not contain StrategyTestDoubleRoot")
+	}
+
+	p := &Program{T: TreeT_NT_T_0()}
+	if err := p.ApplyStrategy("StrategyTestDoubleRoot", []SymbolicExpression{X1}, []SymbolicExpression{Add},
+		1); err != nil {
+		t.Fatal(err)
+	}
+	if p.T.root.value != "*" || p.T.root.right.value != "2" {
+		t.Errorf("Program.ApplyStrategy() did not apply the registered strategy")
+	}
+}
+
+func TestProgram_ApplyStrategyUnknown(t *testing.T) {
+	p := &Program{T: TreeT_NT_T_0()}
+	if err := p.ApplyStrategy("StrategyUnknown", []SymbolicExpression{X1}, []SymbolicExpression{Add},
+		1); err == nil {
+		t.Errorf("Program.ApplyStrategy() should return an error for an unknown strategy")
+	}
+}
+
+func Test_validateAvailableStrategies(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategies Strategies
+		wantErr    bool
+	}{
+		{"empty", Strategies{}, false},
+		{"known", Strategies{AntagonistAvailableStrategies: []Strategy{StrategyFellTree, StrategySkip},
+			ProtagonistAvailableStrategies: []Strategy{StrategyMultXD}}, false},
+		{"unknown antagonist", Strategies{AntagonistAvailableStrategies: []Strategy{StrategyFellTree, "FellTree"}},
+			true},
+		{"unknown protagonist", Strategies{ProtagonistAvailableStrategies: []Strategy{"SkipD "}}, true},
+		{"attach-terminal", Strategies{AntagonistAvailableStrategies: []Strategy{"Attach(*,x,root)",
+			"Attach(-,-1.5,leftmost)"}}, false},
+		{"attach-unknown-variable", Strategies{ProtagonistAvailableStrategies: []Strategy{"Attach(*,z,root)"}},
+			true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAvailableStrategies(tt.strategies, []SymbolicExpression{X1, Const4})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateAvailableStrategies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
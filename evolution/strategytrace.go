@@ -0,0 +1,71 @@
+package evolution
+
+// StrategyTraceStep records how a single strategy transformed a tree.
+type StrategyTraceStep struct {
+	Strategy Strategy `json:"strategy"`
+	// Before and After are the tree as a mathematical expression before and after the strategy was applied.
+	Before string `json:"before"`
+	After  string `json:"after"`
+	// NoOp is set if the strategy left the tree unchanged.
+	NoOp  bool   `json:"noOp"`
+	Error string `json:"error,omitempty"`
+}
+
+// StrategyTrace records every strategy of an individual's Strategy as it was applied in a single epoch.
+// It only holds the strategies up to and including the first that failed.
+type StrategyTrace []StrategyTraceStep
+
+// newStrategyTraceStep records the tree after the strategy was applied against the tree before it.
+func newStrategyTraceStep(strategy Strategy, before string, tree *DualTree, err error) StrategyTraceStep {
+	step := StrategyTraceStep{Strategy: strategy, Before: before}
+	if tree != nil {
+		step.After, _ = tree.ToMathematicalString()
+	}
+	step.NoOp = step.Before == step.After
+	if err != nil {
+		step.Error = err.Error()
+	}
+	return step
+}
+
+// applyStrategies applies the individual's Strategy to its program in order. If Strategies.EnableTrace is set each
+// application is recorded as a StrategyTrace.
+func (individual *Individual) applyStrategies(params EvolutionParams) error {
+	var trace StrategyTrace
+	if params.Strategies.EnableTrace {
+		trace = make(StrategyTrace, 0, len(individual.Strategy))
+		defer func() {
+			individual.recordStrategyTrace(trace)
+		}()
+	}
+
+	for _, strategy := range individual.Strategy {
+		var before string
+		if trace != nil {
+			before, _ = individual.Program.T.ToMathematicalString()
+		}
+		err := individual.Program.ApplyStrategy(strategy,
+			params.SpecParam.AvailableSymbolicExpressions.Terminals,
+			params.SpecParam.AvailableSymbolicExpressions.NonTerminals,
+			params.Strategies.DepthOfRandomNewTrees)
+		if trace != nil {
+			trace = append(trace, newStrategyTraceStep(strategy, before, individual.Program.T, err))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordStrategyTrace appends the trace to the individual's StrategyTraces. Like NoOfCompetitions it is recorded
+// on the Parent if the individual is a clone made for a single epoch.
+func (individual *Individual) recordStrategyTrace(trace StrategyTrace) {
+	target := individual
+	if individual.Parent != nil {
+		target = individual.Parent
+	}
+	target.Mutex.Lock()
+	target.StrategyTraces = append(target.StrategyTraces, trace)
+	target.Mutex.Unlock()
+}
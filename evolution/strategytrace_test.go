@@ -0,0 +1,113 @@
+package evolution
+
+import (
+	"testing"
+)
+
+func traceParams(enableTrace bool) EvolutionParams {
+	return EvolutionParams{
+		StartIndividual: Program{T: TreeT_NT_T_0()},
+		SpecParam: SpecParam{
+			AvailableSymbolicExpressions: AvailableSymbolicExpressions{
+				Terminals:    []SymbolicExpression{X1, Const4},
+				NonTerminals: []SymbolicExpression{Add, Mult},
+			},
+		},
+		Strategies: Strategies{DepthOfRandomNewTrees: 1, EnableTrace: enableTrace},
+	}
+}
+
+func TestIndividual_ApplyAntagonistStrategyTrace(t *testing.T) {
+	tests := []struct {
+		name        string
+		strategy    []Strategy
+		enableTrace bool
+		want        StrategyTrace
+		wantErr     bool
+	}{
+		{"disabled", []Strategy{StrategySkip, "Attach(*,2,root)"}, false, nil, false},
+		{"skip", []Strategy{StrategySkip}, true, StrategyTrace{
+			{Strategy: StrategySkip, Before: "((x)*(4))", After: "((x)*(4))", NoOp: true},
+		}, false},
+		{"attach", []Strategy{StrategySkip, "Attach(*,2,root)", "Attach(-,1,leftmost)"}, true, StrategyTrace{
+			{Strategy: StrategySkip, Before: "((x)*(4))", After: "((x)*(4))", NoOp: true},
+			{Strategy: "Attach(*,2,root)", Before: "((x)*(4))", After: "(((x)*(4))*(2))"},
+			{Strategy: "Attach(-,1,leftmost)", Before: "(((x)*(4))*(2))", After: "((((x)-(1))*(4))*(2))"},
+		}, false},
+		{"error", []Strategy{StrategyFellTree, "StrategyUnknown", StrategySkip}, true, StrategyTrace{
+			{Strategy: StrategyFellTree, Before: "((x)*(4))", After: "(0)"},
+			{Strategy: "StrategyUnknown", Before: "(0)", After: "(0)", NoOp: true,
+				Error: `StrategyByName | unknown strategy "StrategyUnknown"`},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := &Individual{Kind: IndividualAntagonist, Strategy: tt.strategy}
+			individual, err := parent.Clone()
+			if err != nil {
+				t.Fatal(err)
+			}
+			individual.Parent = parent
+
+			err = individual.ApplyAntagonistStrategy(traceParams(tt.enableTrace))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Individual.ApplyAntagonistStrategy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(individual.StrategyTraces) != 0 {
+				t.Errorf("the trace of an epoch clone should be recorded on its parent")
+			}
+			if !tt.enableTrace {
+				if len(parent.StrategyTraces) != 0 {
+					t.Errorf("no trace should be recorded if EnableTrace is not set. got: %v", parent.StrategyTraces)
+				}
+				return
+			}
+			if len(parent.StrategyTraces) != 1 {
+				t.Fatalf("a single trace should be recorded. got: %d", len(parent.StrategyTraces))
+			}
+			got := parent.StrategyTraces[0]
+			if len(got) != len(tt.want) {
+				t.Fatalf("Individual.ApplyAntagonistStrategy() trace = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Individual.ApplyAntagonistStrategy() step %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIndividual_ApplyProtagonistStrategyTrace(t *testing.T) {
+	params := traceParams(true)
+	protagonist := &Individual{Kind: IndividualProtagonist, Strategy: []Strategy{"Attach(+,x,rightmost)"},
+		Program: &Program{}}
+
+	for _, antagonist := range []*DualTree{TreeT_NT_T_0(), TreeT_X()} {
+		if err := protagonist.ApplyProtagonistStrategy(*antagonist, params); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []StrategyTraceStep{
+		{Strategy: "Attach(+,x,rightmost)", Before: "((x)*(4))", After: "((x)*((4)+(x)))"},
+		{Strategy: "Attach(+,x,rightmost)", Before: "(x)", After: "((x)+(x))"},
+	}
+	if len(protagonist.StrategyTraces) != len(want) {
+		t.Fatalf("a trace should be recorded for every epoch. got: %d", len(protagonist.StrategyTraces))
+	}
+	for i := range want {
+		if len(protagonist.StrategyTraces[i]) != 1 || protagonist.StrategyTraces[i][0] != want[i] {
+			t.Errorf("Individual.ApplyProtagonistStrategy() epoch %d = %+v, want %+v", i,
+				protagonist.StrategyTraces[i], want[i])
+		}
+	}
+
+	childA, childB, err := SinglePointCrossover(protagonist, protagonist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if childA.StrategyTraces != nil || childB.StrategyTraces != nil {
+		t.Errorf("children should not inherit the strategy traces of their parents")
+	}
+}
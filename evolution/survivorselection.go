@@ -1,8 +1,17 @@
 package evolution
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 const (
 	SurvivorSelectionFitnessBased = "SurvivorSelectionFitnessBased"
 	SurvivorSelectionRandom       = "SurvivorSelectionRandom"
+	SurvivorSelectionGenerational = "SurvivorSelectionGenerational" // ID for full generational replacement
+	SurvivorSelectionSteadyState  = "SurvivorSelectionSteadyState"  // ID for steady state replacement of the worst k
+	SurvivorSelectionAgeBased     = "SurvivorSelectionAgeBased"     // ID for replacement of individuals past MaxAge
 )
 
 // FitnessBasedSurvivorSelection returns a set of survivors proportionate to the survivor percentage.
@@ -63,13 +72,125 @@ func RandomSurvivorSelection(selectedParents, selectedChildren []*Individual,
 // GenerationalSurvivorSelection is a process where the entire input population gets replaced by their offspring.
 // The returned individuals do not exist with their parents as they have been totally annihilated.
 // These new individuals will go on into the next Generation
-func GenerationalSurvivorSelection(population *Generation) ([]*Individual, error) {
-	return nil, nil
+func GenerationalSurvivorSelection(selectedParents, selectedChildren []*Individual,
+	params EvolutionParams) ([]*Individual, error) {
+	if len(selectedChildren) < params.EachPopulationSize {
+		return nil, fmt.Errorf("GenerationalSurvivorSelection | %d children cannot replace a population of %d",
+			len(selectedChildren), params.EachPopulationSize)
+	}
+
+	survivors := make([]*Individual, params.EachPopulationSize)
+	copy(survivors, selectedChildren)
+	return survivors, nil
 }
 
 // SteadyStateSurvivorSelection is a process where a select amount of individuals make it through.
-// Some parents may make it through based on their Fitness or other compounding parameters.
+// The worst ReplacementCount parents are replaced by the first ReplacementCount children, the remaining parents
+// survive unchanged. Children have not competed yet so they are taken in the order reproduction created them.
 // These new individuals will go on into the next Generation
-func SteadyStateSurvivorSelection(population *Generation) ([]*Individual, error) {
-	return nil, nil
+func SteadyStateSurvivorSelection(selectedParents, selectedChildren []*Individual,
+	params EvolutionParams) ([]*Individual, error) {
+	replacementCount := params.Selection.Survivor.ReplacementCount
+	if replacementCount < 1 || replacementCount > params.EachPopulationSize {
+		return nil, fmt.Errorf("SteadyStateSurvivorSelection | ReplacementCount must be between 1 and %d",
+			params.EachPopulationSize)
+	}
+	parentCount := params.EachPopulationSize - replacementCount
+	if len(selectedParents) < parentCount || len(selectedChildren) < replacementCount {
+		return nil, fmt.Errorf("SteadyStateSurvivorSelection | not enough parents or children to fill the population")
+	}
+
+	sortedParents := sortedByFitness(selectedParents)
+
+	survivors := make([]*Individual, 0, params.EachPopulationSize)
+	survivors = append(survivors, sortedParents[:parentCount]...)
+	survivors = append(survivors, selectedChildren[:replacementCount]...)
+	return survivors, nil
+}
+
+// AgeBasedSurvivorSelection replaces every parent whose Age has reached MaxAge with children regardless of how fit
+// the parent is. Parents keep their position in the population. Children have not competed yet so they are taken in
+// the order reproduction created them. Age counts the generations an individual has survived, see ageSurvivors.
+func AgeBasedSurvivorSelection(selectedParents, selectedChildren []*Individual,
+	params EvolutionParams) ([]*Individual, error) {
+	maxAge := params.Selection.Survivor.MaxAge
+	if maxAge < 1 {
+		return nil, fmt.Errorf("AgeBasedSurvivorSelection | MaxAge must be at least 1")
+	}
+
+	survivors := make([]*Individual, 0, params.EachPopulationSize)
+	for _, parent := range selectedParents {
+		if len(survivors) == params.EachPopulationSize {
+			break
+		}
+		if parent.Age < maxAge {
+			survivors = append(survivors, parent)
+		}
+	}
+
+	replacementCount := params.EachPopulationSize - len(survivors)
+	if len(selectedChildren) < replacementCount {
+		return nil, fmt.Errorf("AgeBasedSurvivorSelection | %d children cannot replace %d expired parents",
+			len(selectedChildren), replacementCount)
+	}
+	survivors = append(survivors, selectedChildren[:replacementCount]...)
+	return survivors, nil
+}
+
+// sortedByFitness returns a copy of the individuals ordered from the highest to the lowest average fitness.
+func sortedByFitness(individuals []*Individual) []*Individual {
+	sorted := make([]*Individual, len(individuals))
+	copy(sorted, individuals)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].AverageFitness > sorted[j].AverageFitness
+	})
+	return sorted
+}
+
+// ageSurvivors increments the Age of every parent that survived into the next generation. Children are born with an
+// Age of 0. A parent selected more than once shares a single Individual and is only aged once.
+func ageSurvivors(parents, survivors []*Individual) {
+	isParent := make(map[*Individual]bool, len(parents))
+	for _, parent := range parents {
+		isParent[parent] = true
+	}
+	aged := make(map[*Individual]bool, len(survivors))
+	for _, survivor := range survivors {
+		if isParent[survivor] && !aged[survivor] {
+			survivor.Age++
+			aged[survivor] = true
+		}
+	}
+}
+
+// validate checks that the parameters of the selected survivor selection are usable with the population size.
+func (s SurvivorSelection) validate(eachPopulationSize int) error {
+	switch s.Type {
+	case SurvivorSelectionSteadyState:
+		if s.ReplacementCount < 1 || s.ReplacementCount > eachPopulationSize {
+			return fmt.Errorf("ReplacementCount must be between 1 and the population size when using steady state" +
+				" survivor selection")
+		}
+	case SurvivorSelectionAgeBased:
+		if s.MaxAge < 1 {
+			return fmt.Errorf("MaxAge must be at least 1 when using age based survivor selection")
+		}
+	}
+	return nil
+}
+
+// toString names the survivor selection type and the parameters it uses for EvolutionParams.ToString, so runs that
+// differ in any of them are written to different folders. Fitness based selection is described by
+// SurvivorPercentage alone and returns an empty string.
+func (s SurvivorSelection) toString() string {
+	name := strings.TrimPrefix(s.Type, "SurvivorSelection")
+	switch s.Type {
+	case SurvivorSelectionFitnessBased:
+		return ""
+	case SurvivorSelectionSteadyState:
+		name += fmt.Sprintf("Rc%d", s.ReplacementCount)
+	case SurvivorSelectionAgeBased:
+		name += fmt.Sprintf("Ma%d", s.MaxAge)
+	}
+	return name
 }
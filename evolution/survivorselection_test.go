@@ -0,0 +1,201 @@
+package evolution
+
+import (
+	"reflect"
+	"testing"
+)
+
+// survivorTestPopulation returns individuals named from first with the given fitness values.
+func survivorTestPopulation(first rune, fitness ...float64) []*Individual {
+	population := make([]*Individual, len(fitness))
+	for i := range fitness {
+		population[i] = &Individual{Id: string(first + rune(i)), AverageFitness: fitness[i]}
+	}
+	return population
+}
+
+func survivorIds(survivors []*Individual) []string {
+	ids := make([]string, len(survivors))
+	for i := range survivors {
+		ids[i] = survivors[i].Id
+	}
+	return ids
+}
+
+func TestGenerationalSurvivorSelection(t *testing.T) {
+	parents := survivorTestPopulation('a', 0.9, 0.8, 0.7, 0.6)
+	tests := []struct {
+		name     string
+		children []*Individual
+		want     []string
+		wantErr  bool
+	}{
+		{"replace-all", survivorTestPopulation('w', -1, 0, 0.1, 0.2), []string{"w", "x", "y", "z"}, false},
+		{"extra-children", survivorTestPopulation('u', 0, 0, 0, 0, 0, 0), []string{"u", "v", "w", "x"}, false},
+		{"too-few-children", survivorTestPopulation('w', 0, 0), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerationalSurvivorSelection(parents, tt.children, EvolutionParams{EachPopulationSize: 4})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerationalSurvivorSelection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(survivorIds(got), tt.want) {
+				t.Errorf("GenerationalSurvivorSelection() = %v, want %v", survivorIds(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSteadyStateSurvivorSelection(t *testing.T) {
+	tests := []struct {
+		name             string
+		replacementCount int
+		want             []string
+		wantErr          bool
+	}{
+		{"replace-0", 0, nil, true},
+		{"replace-1", 1, []string{"b", "d", "a", "w"}, false},
+		{"replace-2", 2, []string{"b", "d", "w", "x"}, false},
+		{"replace-all", 4, []string{"w", "x", "y", "z"}, false},
+		{"replace-too-many", 5, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parents := survivorTestPopulation('a', 0.5, 0.9, -0.2, 0.6)
+			// children have not competed yet, their fitness must not change the order they are taken in
+			children := survivorTestPopulation('w', 0.1, 0.8, 0.3, -1)
+			params := EvolutionParams{EachPopulationSize: 4}
+			params.Selection.Survivor.ReplacementCount = tt.replacementCount
+
+			got, err := SteadyStateSurvivorSelection(parents, children, params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SteadyStateSurvivorSelection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(survivorIds(got), tt.want) {
+				t.Errorf("SteadyStateSurvivorSelection() = %v, want %v", survivorIds(got), tt.want)
+			}
+			if ids := survivorIds(parents); !reflect.DeepEqual(ids, []string{"a", "b", "c", "d"}) {
+				t.Errorf("SteadyStateSurvivorSelection() reordered the parents to %v", ids)
+			}
+		})
+	}
+}
+
+func TestAgeBasedSurvivorSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		ages    []int
+		maxAge  int
+		want    []string
+		wantErr bool
+	}{
+		{"max-age-0", []int{0, 0, 0, 0}, 0, nil, true},
+		{"all-young", []int{0, 1, 2, 0}, 3, []string{"a", "b", "c", "d"}, false},
+		{"fittest-parent-expires", []int{3, 1, 2, 0}, 3, []string{"b", "c", "d", "w"}, false},
+		{"two-expire", []int{0, 4, 2, 3}, 3, []string{"a", "c", "w", "x"}, false},
+		{"all-expire", []int{1, 1, 1, 1}, 1, []string{"w", "x", "y", "z"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parents := survivorTestPopulation('a', 0.9, 0.5, -0.2, 0.6)
+			for i := range parents {
+				parents[i].Age = tt.ages[i]
+			}
+			children := survivorTestPopulation('w', 0.1, 0.8, 0.3, -1)
+			params := EvolutionParams{EachPopulationSize: 4}
+			params.Selection.Survivor.MaxAge = tt.maxAge
+
+			got, err := AgeBasedSurvivorSelection(parents, children, params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AgeBasedSurvivorSelection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(survivorIds(got), tt.want) {
+				t.Errorf("AgeBasedSurvivorSelection() = %v, want %v", survivorIds(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_ageSurvivors(t *testing.T) {
+	parents := survivorTestPopulation('a', 0, 0, 0)
+	parents[1].Age = 2
+	children := survivorTestPopulation('x', 0, 0)
+	// a was selected twice and shares its Individual, c did not survive
+	survivors := []*Individual{parents[0], parents[0], parents[1], children[0], children[1]}
+
+	ageSurvivors(append(parents, parents[0]), survivors)
+
+	for _, want := range []struct {
+		individual *Individual
+		age        int
+	}{
+		{parents[0], 1},
+		{parents[1], 3},
+		{parents[2], 0},
+		{children[0], 0},
+		{children[1], 0},
+	} {
+		if want.individual.Age != want.age {
+			t.Errorf("%s has Age %d, want %d", want.individual.Id, want.individual.Age, want.age)
+		}
+	}
+}
+
+func TestSurvivorSelection_validate(t *testing.T) {
+	tests := []struct {
+		name              string
+		survivorSelection SurvivorSelection
+		wantErr           bool
+	}{
+		{"fitness-based", SurvivorSelection{Type: SurvivorSelectionFitnessBased}, false},
+		{"generational", SurvivorSelection{Type: SurvivorSelectionGenerational}, false},
+		{"steady-state", SurvivorSelection{Type: SurvivorSelectionSteadyState, ReplacementCount: 2}, false},
+		{"steady-state-no-replacement", SurvivorSelection{Type: SurvivorSelectionSteadyState}, true},
+		{"steady-state-too-many", SurvivorSelection{Type: SurvivorSelectionSteadyState, ReplacementCount: 9}, true},
+		{"age-based", SurvivorSelection{Type: SurvivorSelectionAgeBased, MaxAge: 3}, false},
+		{"age-based-no-max-age", SurvivorSelection{Type: SurvivorSelectionAgeBased}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.survivorSelection.validate(8); (err != nil) != tt.wantErr {
+				t.Errorf("SurvivorSelection.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSurvivorSelection_toString(t *testing.T) {
+	tests := []struct {
+		name              string
+		survivorSelection SurvivorSelection
+		want              string
+	}{
+		{"fitness-based", SurvivorSelection{Type: SurvivorSelectionFitnessBased, SurvivorPercentage: 0.5}, ""},
+		{"generational", SurvivorSelection{Type: SurvivorSelectionGenerational}, "Generational"},
+		{"steady-state", SurvivorSelection{Type: SurvivorSelectionSteadyState, ReplacementCount: 2},
+			"SteadyStateRc2"},
+		{"age-based", SurvivorSelection{Type: SurvivorSelectionAgeBased, MaxAge: 5}, "AgeBasedMa5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.survivorSelection.toString(); got != tt.want {
+				t.Errorf("SurvivorSelection.toString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	params := EvolutionParams{
+		FitnessStrategy: FitnessStrategy{Type: FitnessDualThresholdedRatio},
+		Selection: Selection{
+			Parent:   ParentSelection{Type: ParentSelectionTournament},
+			Survivor: SurvivorSelection{Type: SurvivorSelectionSteadyState, ReplacementCount: 2},
+		},
+	}
+	other := params
+	other.Selection.Survivor.ReplacementCount = 4
+	if params.ToString() == other.ToString() {
+		t.Errorf("EvolutionParams.ToString() should differ for different survivor selection parameters. got: %s",
+			params.ToString())
+	}
+}
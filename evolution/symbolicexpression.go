@@ -2,6 +2,7 @@ package evolution
 
 import (
 	"fmt"
+	"github.com/martinomburajr/masters-go/eval"
 	"math"
 	"strings"
 )
@@ -101,6 +102,8 @@ func GenerateTerminals(count int, symbolList []string) ([]SymbolicExpression, er
 	return se, nil
 }
 
+// GenerateNonTerminals returns a set of count non-terminals from the symbol list.
+// Unary functions such as sin take a single child, every other operator is treated as binary.
 func GenerateNonTerminals(count int, symbolList []string) ([]SymbolicExpression, error) {
 	if count > len(symbolList) {
 		count = len(symbolList)
@@ -120,7 +123,7 @@ func GenerateNonTerminals(count int, symbolList []string) ([]SymbolicExpression,
 		str := symbolList[i]
 		sExp := SymbolicExpression{
 			value: str,
-			arity: 1,
+			arity: NonTerminalArity(str),
 			kind:  1,
 		}
 		se[i] = sExp
@@ -129,6 +132,15 @@ func GenerateNonTerminals(count int, symbolList []string) ([]SymbolicExpression,
 	return se, nil
 }
 
+// NonTerminalArity returns the number of children a non-terminal takes. Named functions use their own arity
+// e.g. sin is 1 and pow is 2, all other operators are binary.
+func NonTerminalArity(value string) int {
+	if arity, ok := eval.FunctionArity(value); ok {
+		return arity
+	}
+	return 2
+}
+
 // ParseString parses a given mathematical expression into a set of terminals and nonTerminals within the string.
 // It assumes mathematical expressions in particular non-terminals have an arity of two and take in two arguments e.
 // g. * / - + are some examples
@@ -2,6 +2,8 @@ package evolution
 
 //TERMINALS
 var X1 = SymbolicExpression{kind: 0, value: "x", arity: 0}
+var Y1 = SymbolicExpression{kind: 0, value: "y", arity: 0}
+var Z1 = SymbolicExpression{kind: 0, value: "z", arity: 0}
 var Const0 = SymbolicExpression{kind: 0, value: "0", arity: 0}
 var Const1 = SymbolicExpression{kind: 0, value: "1", arity: 0}
 var Const2 = SymbolicExpression{kind: 0, value: "2", arity: 0}
@@ -18,6 +20,9 @@ var Sub = SymbolicExpression{kind: 1, value: "-", arity: 2}
 var Add = SymbolicExpression{kind: 1, value: "+", arity: 2}
 var Mult = SymbolicExpression{kind: 1, value: "*", arity: 2}
 var Sin = SymbolicExpression{kind: 1, value: "sin", arity: 1}
+var Cos = SymbolicExpression{kind: 1, value: "cos", arity: 1}
+var Log = SymbolicExpression{kind: 1, value: "log", arity: 1}
+var Pow = SymbolicExpression{kind: 1, value: "pow", arity: 2}
 
 // SAMPLE TREES
 
@@ -417,6 +422,43 @@ var TreeVine_D3 = func() *DualTree {
 	return &t
 }
 
+// TreeSinX = sin(x)
+var TreeSinX = func() *DualTree {
+	t := DualTree{}
+	t.root = Sin.ToDualTreeNode(RandString(5))
+	t.root.left = X1.ToDualTreeNode(RandString(5))
+	return &t
+}
+
+// TreeSinXMult4 = sin(x)*4
+var TreeSinXMult4 = func() *DualTree {
+	t := DualTree{}
+	t.root = Mult.ToDualTreeNode(RandString(5))
+	t.root.left = Sin.ToDualTreeNode(RandString(5))
+	t.root.left.left = X1.ToDualTreeNode(RandString(5))
+	t.root.right = Const4.ToDualTreeNode(RandString(5))
+	return &t
+}
+
+// TreePowXAdd1_2 = pow(x+1, 2)
+var TreePowXAdd1_2 = func() *DualTree {
+	t := DualTree{}
+	t.root = Pow.ToDualTreeNode(RandString(5))
+	t.root.left = Add.ToDualTreeNode(RandString(5))
+	t.root.left.left = X1.ToDualTreeNode(RandString(5))
+	t.root.left.right = Const1.ToDualTreeNode(RandString(5))
+	t.root.right = Const2.ToDualTreeNode(RandString(5))
+	return &t
+}
+
+// TreeLogX = log(x)
+var TreeLogX = func() *DualTree {
+	t := DualTree{}
+	t.root = Log.ToDualTreeNode(RandString(5))
+	t.root.left = X1.ToDualTreeNode(RandString(5))
+	return &t
+}
+
 // TreeVine_D4 = sin(sin(sin(sin(x)))))
 var TreeVine_D4 = func() *DualTree {
 	t := DualTree{}
@@ -0,0 +1,54 @@
+package evolution
+
+import (
+	"fmt"
+)
+
+// TopologyFactory creates the ITopology that runs a single evolution of the engine. A new topology is created for
+// every call to EvolutionEngine.Evolve so topologies may keep state across generations e.g. the archive of
+// HallOfFame.
+type TopologyFactory func(engine *EvolutionEngine) ITopology
+
+var topologies = newRegistry[string, TopologyFactory]("topology", "RegisterTopology", "TopologyByName")
+
+func init() {
+	topologies.registerBuiltIn(map[string]TopologyFactory{
+		TopologyRoundRobin: func(engine *EvolutionEngine) ITopology {
+			return &RoundRobin{Engine: engine}
+		},
+		TopologyKRandom: func(engine *EvolutionEngine) ITopology {
+			return &KRandom{Engine: engine}
+		},
+		TopologyHallOfFame: func(engine *EvolutionEngine) ITopology {
+			return &HallOfFame{Engine: engine}
+		},
+		TopologySingleEliminationTournament: func(engine *EvolutionEngine) ITopology {
+			return &SingleEliminationTournamentTopology{Engine: engine}
+		},
+	})
+}
+
+// RegisterTopology makes a topology available to Topology.Type under the given name.
+// It returns an error if the name is empty or already taken.
+func RegisterTopology(name string, factory TopologyFactory) error {
+	return topologies.register(name, factory)
+}
+
+// TopologyByName creates the topology registered under the given name for the engine.
+func TopologyByName(name string, engine *EvolutionEngine) (ITopology, error) {
+	factory, err := topologies.byName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	topology := factory(engine)
+	if topology == nil {
+		return nil, fmt.Errorf("TopologyByName | topology %q created a nil topology", name)
+	}
+	return topology, nil
+}
+
+// RegisteredTopologies returns the sorted names of all registered topologies.
+func RegisteredTopologies() []string {
+	return topologies.names()
+}
@@ -0,0 +1,89 @@
+package evolution
+
+import (
+	"reflect"
+	"testing"
+)
+
+// topologyTestSkip is a topology that promotes the current generation unchanged.
+type topologyTestSkip struct{}
+
+func (topologyTestSkip) Topology(currentGeneration *Generation, params EvolutionParams) (*Generation, error) {
+	return currentGeneration.NextGeneration("TopologyTestSkip", currentGeneration.Antagonists,
+		currentGeneration.Protagonists), nil
+}
+
+func TestRegisterTopology(t *testing.T) {
+	engine := &EvolutionEngine{}
+	for name, want := range map[string]ITopology{
+		TopologyRoundRobin:                  &RoundRobin{Engine: engine},
+		TopologyKRandom:                     &KRandom{Engine: engine},
+		TopologyHallOfFame:                  &HallOfFame{Engine: engine},
+		TopologySingleEliminationTournament: &SingleEliminationTournamentTopology{Engine: engine},
+	} {
+		got, err := TopologyByName(name, engine)
+		if err != nil {
+			t.Errorf("TopologyByName(%s) error = %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("TopologyByName(%s) got = %#v, want %#v", name, got, want)
+		}
+	}
+	if _, err := TopologyByName("TopologyUnknown", engine); err == nil {
+		t.Errorf("TopologyByName(TopologyUnknown) should return an error")
+	}
+
+	hallOfFame, _ := TopologyByName(TopologyHallOfFame, engine)
+	hallOfFame.(*HallOfFame).AntagonistArchive = []Individual{{Id: "a"}}
+	if other, _ := TopologyByName(TopologyHallOfFame, engine); len(other.(*HallOfFame).AntagonistArchive) != 0 {
+		t.Errorf("TopologyByName() should create a new topology for every evolution")
+	}
+
+	skip := func(engine *EvolutionEngine) ITopology {
+		return topologyTestSkip{}
+	}
+	tests := []struct {
+		name    string
+		factory TopologyFactory
+		wantErr bool
+	}{
+		{"", skip, true},
+		{"TopologyTestSkip", nil, true},
+		{TopologyRoundRobin, skip, true},
+		{"TopologyTestSkip", skip, false},
+		{"TopologyTestSkip", skip, true},
+		{"TopologyTestNil", func(engine *EvolutionEngine) ITopology { return nil }, false},
+	}
+	for _, tt := range tests {
+		if err := RegisterTopology(tt.name, tt.factory); (err != nil) != tt.wantErr {
+			t.Errorf("RegisterTopology(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if _, err := TopologyByName("TopologyTestNil", engine); err == nil {
+		t.Errorf("TopologyByName() should return an error if the factory creates a nil topology")
+	}
+
+	found := false
+	for _, name := range RegisteredTopologies() {
+		found = found || name == "TopologyTestSkip"
+	}
+	if !found {
+		t.Errorf("RegisteredTopologies() does not contain TopologyTestSkip")
+	}
+
+	topology, err := TopologyByName("TopologyTestSkip", engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := &Generation{engine: engine, count: 3, Antagonists: []*Individual{{Id: "a"}},
+		Protagonists: []*Individual{{Id: "p"}}}
+	next, err := topology.Topology(current, EvolutionParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.count != 4 || next.GenerationID != GenerateGenerationID(4, "TopologyTestSkip") || next.engine != engine ||
+		next.Antagonists[0].Id != "a" || next.Protagonists[0].Id != "p" {
+		t.Errorf("Generation.NextGeneration() got = %+v", next)
+	}
+}
@@ -69,6 +69,54 @@ var AllSelectionParentType = []evolution.ParentSelection{
 	},
 }
 
+// AllParentSelectionSweepType lists parent selection types that are swept over every value of their parameters below,
+// in addition to AllSelectionParentType. e.g. adding evolution.ParentSelectionBoltzmann sweeps all combinations of
+// AllBoltzmannTemperature and AllBoltzmannTemperatureDecay.
+var AllParentSelectionSweepType = []string{}
+var AllSelectionPressure = []float64{1.5, 2}
+var AllBoltzmannTemperature = []float64{1}
+var AllBoltzmannTemperatureDecay = []float64{0, 0.05}
+var AllTruncationRatio = []float64{0.25, 0.5}
+
+// ParentSelectionSweep returns AllSelectionParentType followed by a ParentSelection for every combination of the
+// parameters used by each type in AllParentSelectionSweepType.
+func ParentSelectionSweep() []evolution.ParentSelection {
+	parentSelections := make([]evolution.ParentSelection, len(AllSelectionParentType))
+	copy(parentSelections, AllSelectionParentType)
+
+	for _, parentType := range AllParentSelectionSweepType {
+		switch parentType {
+		case evolution.ParentSelectionLinearRank, evolution.ParentSelectionExponentialRank:
+			for _, selectionPressure := range AllSelectionPressure {
+				parentSelections = append(parentSelections, evolution.ParentSelection{
+					Type:              parentType,
+					SelectionPressure: selectionPressure,
+				})
+			}
+		case evolution.ParentSelectionBoltzmann:
+			for _, temperature := range AllBoltzmannTemperature {
+				for _, temperatureDecay := range AllBoltzmannTemperatureDecay {
+					parentSelections = append(parentSelections, evolution.ParentSelection{
+						Type:             parentType,
+						Temperature:      temperature,
+						TemperatureDecay: temperatureDecay,
+					})
+				}
+			}
+		case evolution.ParentSelectionTruncation:
+			for _, truncationRatio := range AllTruncationRatio {
+				parentSelections = append(parentSelections, evolution.ParentSelection{
+					Type:            parentType,
+					TruncationRatio: truncationRatio,
+				})
+			}
+		default:
+			parentSelections = append(parentSelections, evolution.ParentSelection{Type: parentType})
+		}
+	}
+	return parentSelections
+}
+
 var AllDivByZeroStrategy = []string{
 	evolution.DivByZeroSteadyPenalize,
 }
@@ -76,8 +124,17 @@ var AllDivByZeroPenalty = []float64{-1}
 
 var AllPossibleStrategies = [][]evolution.Strategy{
 	AllStrategies,
+	append(append([]evolution.Strategy{}, AllStrategies...), AllAttachStrategies...),
 }
 
+// AllAttachStrategies attaches the independent variable or a constant at either end of the tree. Every expression in
+// AllExpressions is in x, so x is always one of the available terminals.
+var AllAttachStrategies = evolution.AttachStrategies(
+	[]string{"*", "+", "-"},
+	[]string{"x", "2"},
+	[]string{evolution.AttachLeftmostLeaf, evolution.AttachRightmostLeaf},
+)
+
 var AllStrategies = []evolution.Strategy{
 	evolution.StrategyDeleteNonTerminal,
 	evolution.StrategyDeleteTerminal,
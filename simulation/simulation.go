@@ -147,6 +147,16 @@ func (s *Simulation) Begin(params evolution.EvolutionParams) (evolution.Evolutio
 		if err != nil {
 			params.ErrorChan <- err
 		}
+		if params.StatisticsOutput.EnableStrategyCredits {
+			simulationStrategyCredit, err := s.SimulationStrategyCredit(params)
+			if err != nil {
+				params.ErrorChan <- err
+			}
+			err = simulationStrategyCredit.ToCSV(s.generateSimulationPathCSV("strategycredit"))
+			if err != nil {
+				params.ErrorChan <- err
+			}
+		}
 
 		s.ProgressBar.Incr()
 	}(&wg, s, params)
@@ -212,6 +222,7 @@ type SimulationRunStats struct {
 	Generational             evolution.Generational
 	MeanCorrelation          float64
 	MeanCovariance           float64
+	StrategyCredits          []evolution.StrategyCredit
 }
 
 func (s *Simulation) RunRScript(RPath, dirPath string, RFiles []string, logChan chan evolog.Logger,
@@ -264,6 +275,7 @@ func (s *Simulation) StartEngine(engine *evolution.EvolutionEngine) error {
 		Generational:             evolutionResult.Generational,
 		MeanCorrelation:          evolutionResult.Correlation,
 		MeanCovariance:           evolutionResult.Covariance,
+		StrategyCredits:          evolutionResult.StrategyCredits,
 	}
 
 	os.Mkdir(fmt.Sprintf("%s%d", s.OutputDir, s.CurrentEvolutionState.InternalCount), 0755)
@@ -319,6 +331,16 @@ func (s *Simulation) StartEngine(engine *evolution.EvolutionEngine) error {
 		if err != nil {
 			engine.Parameters.ErrorChan <- err
 		}
+		if engine.Parameters.StatisticsOutput.EnableStrategyCredits {
+			runStrategyCredit, err := s.StrategyCreditInRun(engine.Parameters)
+			if err != nil {
+				engine.Parameters.ErrorChan <- err
+			}
+			err = runStrategyCredit.ToCSV(s.generateRunPathCSV("strategycredit", engine.Parameters.InternalCount))
+			if err != nil {
+				engine.Parameters.ErrorChan <- err
+			}
+		}
 		engine.ProgressBar.Incr()
 		mut.Unlock()
 	}(s, engine, &wg)
@@ -335,6 +357,16 @@ func (s *Simulation) StartEngine(engine *evolution.EvolutionEngine) error {
 		if err != nil {
 			engine.Parameters.ErrorChan <- err
 		}
+		if engine.Parameters.Strategies.EnableTrace {
+			runBestIndividualTrace, err := s.BestIndividualTraceInRun(engine.Parameters)
+			if err != nil {
+				engine.Parameters.ErrorChan <- err
+			}
+			err = runBestIndividualTrace.ToJSON(s.generateRunPathJSON("best-trace", engine.Parameters.InternalCount))
+			if err != nil {
+				engine.Parameters.ErrorChan <- err
+			}
+		}
 		engine.ProgressBar.Incr()
 		mut.Unlock()
 	}(s, engine, &wg)
@@ -349,6 +381,12 @@ func (s *Simulation) generateRunPathCSV(fileName string, run int) string {
 	return path
 }
 
+func (s *Simulation) generateRunPathJSON(fileName string, run int) string {
+	path := fmt.Sprintf("%s/%s-%d.json", s.DataPath, fileName, run)
+
+	return path
+}
+
 func (s *Simulation) generateSimulationPathCSV(fileName string) string {
 	path := fmt.Sprintf("%s/%s.csv", s.DataPath, fileName)
 
@@ -361,6 +399,22 @@ func PrepareSimulation(params evolution.EvolutionParams, count int) *evolution.E
 	if params.SpecParam.Seed < 0 {
 		params.FitnessCalculatorType = 1
 	}
+	var datasetSpec evolution.SpecMulti
+	if params.SpecParam.DatasetPath != "" {
+		spec, err := evolution.LoadSpecDataset(params.SpecParam, params.FitnessStrategy)
+		if err != nil {
+			log.Fatalf("MAIN | failed to load the spec dataset | %s", err.Error())
+		}
+		datasetSpec = spec
+		variables := spec.Variables()
+		if len(params.SpecParam.AvailableVariablesAndOperators.Variables) < 1 {
+			params.SpecParam.AvailableVariablesAndOperators.Variables = variables
+		}
+		// Without a known formula the StartIndividual is seeded from the given expression or the first variable
+		if params.SpecParam.Expression == "" {
+			params.SpecParam.Expression = variables[0]
+		}
+	}
 	params.SpecParam.Expression = strings.ReplaceAll(params.SpecParam.Expression, " ", "")
 
 	constantTerminals, err := evolution.GenerateTerminals(10, params.SpecParam.AvailableVariablesAndOperators.Constants)
@@ -371,21 +425,16 @@ func PrepareSimulation(params evolution.EvolutionParams, count int) *evolution.E
 	if err != nil {
 		log.Fatal(err)
 	}
-	nonTerminals, err := evolution.GenerateNonTerminals(3, params.SpecParam.AvailableVariablesAndOperators.Operators)
+	nonTerminals, err := evolution.GenerateNonTerminals(len(params.SpecParam.AvailableVariablesAndOperators.Operators),
+		params.SpecParam.AvailableVariablesAndOperators.Operators)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	_, _, mathematicalExpression, err := evolution.ParseString(params.SpecParam.Expression, params.SpecParam.AvailableVariablesAndOperators.Operators, params.SpecParam.AvailableVariablesAndOperators.Variables)
+	starterTree, err := evolution.ParseExpression(params.SpecParam.Expression)
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	starterTree := evolution.DualTree{}
-	err = starterTree.FromSymbolicExpressionSet2(mathematicalExpression)
-	if err != nil {
-		log.Fatal("main | cannot parse symbolic expression tree to convert starter tree to a mathematical expression")
-	}
 	starterTreeAsMathematicalExpression, err := starterTree.ToMathematicalString()
 	if err != nil {
 		log.Fatal("main | failed to convert starter tree to a mathematical expression")
@@ -393,17 +442,19 @@ func PrepareSimulation(params evolution.EvolutionParams, count int) *evolution.E
 
 	params.SpecParam.ExpressionParsed = starterTreeAsMathematicalExpression
 	startProgram := evolution.Program{
-		T: &starterTree,
+		T: starterTree,
 	}
 	var spec evolution.SpecMulti
 
-	switch params.FitnessStrategy.Type {
-	case evolution.FitnessMonoThresholdedRatio:
+	switch {
+	case datasetSpec != nil:
+		spec = datasetSpec
+	case params.FitnessStrategy.Type == evolution.FitnessMonoThresholdedRatio:
 		spec, err = evolution.GenerateSpecSimple(params.SpecParam, params.FitnessStrategy)
 		if err != nil {
 			log.Fatalf("MAIN | failed to create a valid spec | %s", err.Error())
 		}
-	case evolution.FitnessDualThresholdedRatio:
+	case params.FitnessStrategy.Type == evolution.FitnessDualThresholdedRatio:
 		spec, err = evolution.GenerateSpecSimple(params.SpecParam, params.FitnessStrategy)
 		if err != nil {
 			log.Fatalf("MAIN | failed to create a valid spec | %s", err.Error())
@@ -421,8 +472,14 @@ func PrepareSimulation(params evolution.EvolutionParams, count int) *evolution.E
 		starterTreeAsMathematicalExpression,
 	)
 
+	trainSpec, testSpec, err := evolution.SplitSpec(params.SpecParam, params.FitnessStrategy, spec)
+	if err != nil {
+		log.Fatalf("MAIN | failed to create the test spec | %s", err.Error())
+	}
+
 	// Set extra params
-	params.Spec = spec
+	params.Spec = trainSpec
+	params.TestSpec = testSpec
 	params.StartIndividual = startProgram
 	params.SpecParam.AvailableSymbolicExpressions.Terminals = append(variableTerminals, constantTerminals...)
 	params.SpecParam.AvailableSymbolicExpressions.NonTerminals = nonTerminals
@@ -454,27 +511,10 @@ func PrepareSimulation(params evolution.EvolutionParams, count int) *evolution.E
 		fmt.Printf("Fitness Calculation Method: %s\n", "Unknown")
 	}
 
-	switch engine.Parameters.FitnessStrategy.Type {
-	case evolution.FitnessAbsolute:
-		fmt.Printf("Fitness Strategy: %s\n", "FitnessAbsolute")
-		break
-	case evolution.FitnessRatio:
-		fmt.Printf("Fitness Strategy: %s\n", "FitnessRatio")
-		break
-	case evolution.FitnessProtagonistThresholdTally:
-		fmt.Printf("Fitness Strategy: %s\n", "FitnessProtagonistThresholdTally")
-		break
-	case evolution.FitnessThresholdedAntagonistRatio:
-		fmt.Printf("Fitness Strategy: %s\n", "FitnessThresholdedAntagonistRatio")
-		break
-	case evolution.FitnessMonoThresholdedRatio:
-		fmt.Printf("Fitness Strategy: %s\n", "FitnessMonoThresholdedRatio")
-		break
-	case evolution.FitnessDualThresholdedRatio:
-		fmt.Printf("Fitness Strategy: %s\n", "FitnessDualThresholdedRatio")
-		break
-	default:
+	if _, err := evolution.FitnessFunctionByName(engine.Parameters.FitnessStrategy.Type); err != nil {
 		log.Printf("Fitness Strategy: %s\n", "Unknown")
+	} else {
+		fmt.Printf("Fitness Strategy: %s\n", engine.Parameters.FitnessStrategy.Type)
 	}
 	fmt.Println()
 
@@ -499,6 +539,7 @@ func (s *Simulation) SpewJSONNoSplit(projectAbsolutePath, paramsDir string) erro
 	//os.Mkdir(baseRelDir, 0775)
 
 	counter := 0
+	allSelectionParent := ParentSelectionSweep()
 
 	for expressionIndex := 0; expressionIndex < len(AllExpressions); expressionIndex++ {
 		for allMaxGenIndex := 0; allMaxGenIndex < len(AllMaxGenerations); allMaxGenIndex++ {
@@ -525,7 +566,7 @@ func (s *Simulation) SpewJSONNoSplit(projectAbsolutePath, paramsDir string) erro
 																			for divByZeroPenaltyIndex := 0; divByZeroPenaltyIndex < len(AllDivByZeroPenalty); divByZeroPenaltyIndex++ {
 																				for divByZeroStrategyIndex := 0; divByZeroStrategyIndex < len(AllDivByZeroStrategy); divByZeroStrategyIndex++ {
 																					for survivorIndex := 0; survivorIndex < len(AllSurvivorSelection); survivorIndex++ {
-																						for parentIndex := 0; parentIndex < len(AllSelectionParentType); parentIndex++ {
+																						for parentIndex := 0; parentIndex < len(allSelectionParent); parentIndex++ {
 																							for ProtagonistMinGenAvgFitIndex := 0; ProtagonistMinGenAvgFitIndex < len(AllProtagonistMinGenAvgFit); ProtagonistMinGenAvgFitIndex++ {
 																								// TODO Add Parallelism
 																								params := evolution.EvolutionParams{
@@ -564,7 +605,7 @@ func (s *Simulation) SpewJSONNoSplit(projectAbsolutePath, paramsDir string) erro
 																									Reproduction: AllReproduction[reproductionIndex],
 																									Selection: evolution.Selection{
 																										Survivor: AllSurvivorSelection[survivorIndex],
-																										Parent:   AllSelectionParentType[parentIndex],
+																										Parent:   allSelectionParent[parentIndex],
 																									},
 																									FolderPercentages: []float64{0.01, 0.25, 0.5, 0.75, 0.95},
 																								}
@@ -1,14 +1,17 @@
 package simulation
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/gocarina/gocsv"
 	"github.com/martinomburajr/masters-go/evolution"
+	"gonum.org/v1/gonum/stat"
 	"math"
 	"os"
+	"sort"
 )
 
-//########################################### BEST INDIVIDUAL ##############
+// ########################################### BEST INDIVIDUAL ##############
 type SimulationBestActualIndividual struct {
 	Antagonist            evolution.Individual
 	Protagonist           evolution.Individual
@@ -81,63 +84,74 @@ func (s *Simulation) BestIndividualsInRun(params evolution.EvolutionParams) (run
 	topProtagonistEq, err := run.TopProtagonist.Program.T.ToMathematicalString()
 	finalAntagonistEq, err := run.FinalAntagonist.Program.T.ToMathematicalString()
 	finalProtagonistEq, err := run.FinalProtagonist.Program.T.ToMathematicalString()
+	protagonistTestFitness, protagonistTestDelta, err := run.TopProtagonist.GeneralisationScore(params)
+	if err != nil {
+		return nil, err
+	}
 
 	runBest[0] = RunBestIndividualStatistic{
 		SpecEquation: params.SpecParam.ExpressionParsed,
 		SpecRange:    params.SpecParam.Range,
 		SpecSeed:     params.SpecParam.Seed,
-
-		Antagonist:                  run.TopAntagonist.AverageFitness,
-		Protagonist:                 run.TopProtagonist.AverageFitness,
-		AntagonistBestFitness:       run.TopAntagonist.BestFitness,
-		ProtagonistBestFitness:      run.TopProtagonist.BestFitness,
-		AntagonistStdDev:            run.TopAntagonist.FitnessStdDev,
-		ProtagonistStdDev:           run.TopProtagonist.FitnessStdDev,
-		AntagonistAverageDelta:      run.TopAntagonist.AverageDelta,
-		ProtagonistAverageDelta:     run.TopProtagonist.AverageDelta,
-		AntagonistBestDelta:         run.TopAntagonist.BestDelta,
-		ProtagonistBestDelta:        run.TopProtagonist.BestDelta,
-		AntagonistEquation:          topAntagonistEq,
-		ProtagonistEquation:         topProtagonistEq,
-		AntagonistStrategy:          evolution.StrategiesToString(run.TopAntagonist),
-		ProtagonistStrategy:         evolution.StrategiesToString(run.TopProtagonist),
-		AntagonistDominantStrategy:  evolution.DominantStrategy(run.TopAntagonist),
-		ProtagonistDominantStrategy: evolution.DominantStrategy(run.TopProtagonist),
-		AntagonistBirthGen:          run.TopAntagonist.BirthGen,
-		ProtagonistBirthGen:         run.TopProtagonist.BirthGen,
-		AntagonistAge:               run.TopAntagonist.Age,
-		ProtagonistAge:              run.TopProtagonist.Age,
-		AntagonistGeneration:        run.TopAntagonistGeneration,
-		ProtagonistGeneration:       run.TopProtagonistGeneration,
-		AntagonistNoOfCompetitions: run.TopAntagonist.NoOfCompetitions,
-		ProtagonistNoOfCompetitions: run.TopProtagonist.NoOfCompetitions,
+		ErrorMetric:  params.FitnessStrategy.ResolvedErrorMetric(),
+
+		Antagonist:                    run.TopAntagonist.AverageFitness,
+		Protagonist:                   run.TopProtagonist.AverageFitness,
+		AntagonistBestFitness:         run.TopAntagonist.BestFitness,
+		ProtagonistBestFitness:        run.TopProtagonist.BestFitness,
+		AntagonistStdDev:              run.TopAntagonist.FitnessStdDev,
+		ProtagonistStdDev:             run.TopProtagonist.FitnessStdDev,
+		AntagonistAverageDelta:        run.TopAntagonist.AverageDelta,
+		ProtagonistAverageDelta:       run.TopProtagonist.AverageDelta,
+		AntagonistBestDelta:           run.TopAntagonist.BestDelta,
+		ProtagonistBestDelta:          run.TopProtagonist.BestDelta,
+		AntagonistEquation:            topAntagonistEq,
+		ProtagonistEquation:           topProtagonistEq,
+		AntagonistSimplifiedEquation:  simplifiedEquation(params, &run.TopAntagonist),
+		ProtagonistSimplifiedEquation: simplifiedEquation(params, &run.TopProtagonist),
+		AntagonistStrategy:            evolution.StrategiesToString(run.TopAntagonist),
+		ProtagonistStrategy:           evolution.StrategiesToString(run.TopProtagonist),
+		AntagonistDominantStrategy:    evolution.DominantStrategy(run.TopAntagonist),
+		ProtagonistDominantStrategy:   evolution.DominantStrategy(run.TopProtagonist),
+		AntagonistBirthGen:            run.TopAntagonist.BirthGen,
+		ProtagonistBirthGen:           run.TopProtagonist.BirthGen,
+		AntagonistAge:                 run.TopAntagonist.Age,
+		ProtagonistAge:                run.TopProtagonist.Age,
+		AntagonistGeneration:          run.TopAntagonistGeneration,
+		ProtagonistGeneration:         run.TopProtagonistGeneration,
+		AntagonistNoOfCompetitions:    run.TopAntagonist.NoOfCompetitions,
+		ProtagonistNoOfCompetitions:   run.TopProtagonist.NoOfCompetitions,
 
 		MeanCorrelation: run.MeanCorrelation,
 		MeanCovariance:  run.MeanCovariance,
 
-		FinalAntagonist:                  run.FinalAntagonist.AverageFitness,
-		FinalProtagonist:                 run.FinalProtagonist.AverageFitness,
-		FinalAntagonistBestFitness:       run.FinalAntagonist.BestFitness,
-		FinalProtagonistBestFitness:      run.FinalProtagonist.BestFitness,
-		FinalAntagonistStdDev:            run.FinalAntagonist.FitnessStdDev,
-		FinalProtagonistStdDev:           run.FinalProtagonist.FitnessStdDev,
-		FinalAntagonistAverageDelta:      run.FinalAntagonist.AverageDelta,
-		FinalProtagonistAverageDelta:     run.FinalProtagonist.AverageDelta,
-		FinalAntagonistBestDelta:         run.FinalAntagonist.BestDelta,
-		FinalProtagonistBestDelta:        run.FinalProtagonist.BestDelta,
-		FinalAntagonistEquation:          finalAntagonistEq,
-		FinalProtagonistEquation:         finalProtagonistEq,
-		FinalAntagonistStrategy:          evolution.StrategiesToString(run.FinalAntagonist),
-		FinalProtagonistStrategy:         evolution.StrategiesToString(run.FinalProtagonist),
-		FinalAntagonistDominantStrategy:  evolution.DominantStrategy(run.FinalAntagonist),
-		FinalProtagonistDominantStrategy: evolution.DominantStrategy(run.FinalProtagonist),
-		FinalAntagonistBirthGen:          run.FinalAntagonist.BirthGen,
-		FinalProtagonistBirthGen:         run.FinalProtagonist.BirthGen,
-		FinalAntagonistAge:               run.FinalAntagonist.Age,
-		FinalProtagonistAge:              run.FinalProtagonist.Age,
-		FinalAntagonistNoOfCompetitions: run.FinalAntagonist.NoOfCompetitions,
-		FinalProtagonistNoOfCompetitions: run.FinalProtagonist.NoOfCompetitions,
-		Run:                              runIndex,
+		FinalAntagonist:                    run.FinalAntagonist.AverageFitness,
+		FinalProtagonist:                   run.FinalProtagonist.AverageFitness,
+		FinalAntagonistBestFitness:         run.FinalAntagonist.BestFitness,
+		FinalProtagonistBestFitness:        run.FinalProtagonist.BestFitness,
+		FinalAntagonistStdDev:              run.FinalAntagonist.FitnessStdDev,
+		FinalProtagonistStdDev:             run.FinalProtagonist.FitnessStdDev,
+		FinalAntagonistAverageDelta:        run.FinalAntagonist.AverageDelta,
+		FinalProtagonistAverageDelta:       run.FinalProtagonist.AverageDelta,
+		FinalAntagonistBestDelta:           run.FinalAntagonist.BestDelta,
+		FinalProtagonistBestDelta:          run.FinalProtagonist.BestDelta,
+		FinalAntagonistEquation:            finalAntagonistEq,
+		FinalProtagonistEquation:           finalProtagonistEq,
+		FinalAntagonistSimplifiedEquation:  simplifiedEquation(params, &run.FinalAntagonist),
+		FinalProtagonistSimplifiedEquation: simplifiedEquation(params, &run.FinalProtagonist),
+		FinalAntagonistStrategy:            evolution.StrategiesToString(run.FinalAntagonist),
+		FinalProtagonistStrategy:           evolution.StrategiesToString(run.FinalProtagonist),
+		FinalAntagonistDominantStrategy:    evolution.DominantStrategy(run.FinalAntagonist),
+		FinalProtagonistDominantStrategy:   evolution.DominantStrategy(run.FinalProtagonist),
+		FinalAntagonistBirthGen:            run.FinalAntagonist.BirthGen,
+		FinalProtagonistBirthGen:           run.FinalProtagonist.BirthGen,
+		FinalAntagonistAge:                 run.FinalAntagonist.Age,
+		FinalProtagonistAge:                run.FinalProtagonist.Age,
+		FinalAntagonistNoOfCompetitions:    run.FinalAntagonist.NoOfCompetitions,
+		FinalProtagonistNoOfCompetitions:   run.FinalProtagonist.NoOfCompetitions,
+		ProtagonistTestFitness:             protagonistTestFitness,
+		ProtagonistTestDelta:               protagonistTestDelta,
+		Run:                                runIndex,
 	}
 
 	return runBest, err
@@ -168,39 +182,42 @@ func (s *Simulation) SimulationBestIndividuals(params evolution.EvolutionParams)
 		topAntagonistEq, _ := topAntagonist.Program.T.ToMathematicalString()
 		topProtagonistEq, _ := topProtagonist.Program.T.ToMathematicalString()
 		simulationBestIndividuals[i] = SimulationBestIndividual{
-			SpecEquation:                params.SpecParam.ExpressionParsed,
-			SpecRange:                   params.SpecParam.Range,
-			SpecSeed:                    params.SpecParam.Seed,
-			Correlation:                 run.MeanCorrelation,
-			Covariance:                  run.MeanCovariance,
-			AntagonistID:                topAntagonist.Id,
-			ProtagonistID:               topProtagonist.Id,
-			Antagonist:                  topAntagonist.AverageFitness,
-			Protagonist:                 topProtagonist.AverageFitness,
-			AntagonistBestFitness:       topAntagonist.BestFitness,
-			ProtagonistBestFitness:      topProtagonist.BestFitness,
-			AntagonistStdDev:            topAntagonist.FitnessStdDev,
-			ProtagonistStdDev:           topProtagonist.FitnessStdDev,
-			AntagonistAverageDelta:      topAntagonist.AverageDelta,
-			ProtagonistAverageDelta:     topProtagonist.AverageDelta,
-			AntagonistBestDelta:         topAntagonist.BestDelta,
-			ProtagonistBestDelta:        topProtagonist.BestDelta,
-			AntagonistEquation:          topAntagonistEq,
-			ProtagonistEquation:         topProtagonistEq,
-			AntagonistStrategy:          evolution.StrategiesToString(topAntagonist),
-			ProtagonistStrategy:         evolution.StrategiesToString(topProtagonist),
-			AntagonistDominantStrategy:  evolution.DominantStrategy(topAntagonist),
-			ProtagonistDominantStrategy: evolution.DominantStrategy(topProtagonist),
-			AntagonistGeneration:        topAntGen,
-			ProtagonistGeneration:       topProGen,
-			AntagonistBirthGen:          topAntagonist.BirthGen,
-			ProtagonistBirthGen:         topProtagonist.BirthGen,
-			AntagonistAge:               topAntagonist.Age,
-			ProtagonistAge:              topProtagonist.Age,
-			AntagonistRun:               topAntRun,
-			ProtagonistRun:              topProRun,
-			AntagonistNoOfCompetitions:  topAntagonist.NoOfCompetitions,
-			ProtagonistNoOfCompetitions: topProtagonist.NoOfCompetitions,
+			SpecEquation:                  params.SpecParam.ExpressionParsed,
+			SpecRange:                     params.SpecParam.Range,
+			SpecSeed:                      params.SpecParam.Seed,
+			ErrorMetric:                   params.FitnessStrategy.ResolvedErrorMetric(),
+			Correlation:                   run.MeanCorrelation,
+			Covariance:                    run.MeanCovariance,
+			AntagonistID:                  topAntagonist.Id,
+			ProtagonistID:                 topProtagonist.Id,
+			Antagonist:                    topAntagonist.AverageFitness,
+			Protagonist:                   topProtagonist.AverageFitness,
+			AntagonistBestFitness:         topAntagonist.BestFitness,
+			ProtagonistBestFitness:        topProtagonist.BestFitness,
+			AntagonistStdDev:              topAntagonist.FitnessStdDev,
+			ProtagonistStdDev:             topProtagonist.FitnessStdDev,
+			AntagonistAverageDelta:        topAntagonist.AverageDelta,
+			ProtagonistAverageDelta:       topProtagonist.AverageDelta,
+			AntagonistBestDelta:           topAntagonist.BestDelta,
+			ProtagonistBestDelta:          topProtagonist.BestDelta,
+			AntagonistEquation:            topAntagonistEq,
+			ProtagonistEquation:           topProtagonistEq,
+			AntagonistSimplifiedEquation:  simplifiedEquation(params, &topAntagonist),
+			ProtagonistSimplifiedEquation: simplifiedEquation(params, &topProtagonist),
+			AntagonistStrategy:            evolution.StrategiesToString(topAntagonist),
+			ProtagonistStrategy:           evolution.StrategiesToString(topProtagonist),
+			AntagonistDominantStrategy:    evolution.DominantStrategy(topAntagonist),
+			ProtagonistDominantStrategy:   evolution.DominantStrategy(topProtagonist),
+			AntagonistGeneration:          topAntGen,
+			ProtagonistGeneration:         topProGen,
+			AntagonistBirthGen:            topAntagonist.BirthGen,
+			ProtagonistBirthGen:           topProtagonist.BirthGen,
+			AntagonistAge:                 topAntagonist.Age,
+			ProtagonistAge:                topProtagonist.Age,
+			AntagonistRun:                 topAntRun,
+			ProtagonistRun:                topProRun,
+			AntagonistNoOfCompetitions:    topAntagonist.NoOfCompetitions,
+			ProtagonistNoOfCompetitions:   topProtagonist.NoOfCompetitions,
 		}
 	}
 
@@ -243,39 +260,42 @@ func (s *Simulation) SimulationBestIndividual(params evolution.EvolutionParams)
 	topProtagonistEq, _ := topProtagonist.Program.T.ToMathematicalString()
 
 	simulationBestIndividuals[0] = SimulationBestIndividual{
-		SpecEquation:                params.SpecParam.ExpressionParsed,
-		SpecRange:                   params.SpecParam.Range,
-		SpecSeed:                    params.SpecParam.Seed,
-		Correlation:                 0,
-		Covariance:                  0,
-		AntagonistID:                topAntagonist.Id,
-		ProtagonistID:               topProtagonist.Id,
-		Antagonist:                  topAntagonist.AverageFitness,
-		Protagonist:                 topProtagonist.AverageFitness,
-		AntagonistBestFitness:       topAntagonist.BestFitness,
-		ProtagonistBestFitness:      topProtagonist.BestFitness,
-		AntagonistStdDev:            topAntagonist.FitnessStdDev,
-		ProtagonistStdDev:           topProtagonist.FitnessStdDev,
-		AntagonistAverageDelta:      topAntagonist.AverageDelta,
-		ProtagonistAverageDelta:     topProtagonist.AverageDelta,
-		AntagonistBestDelta:         topAntagonist.BestDelta,
-		ProtagonistBestDelta:        topProtagonist.BestDelta,
-		AntagonistEquation:          topAntagonistEq,
-		ProtagonistEquation:         topProtagonistEq,
-		AntagonistStrategy:          evolution.StrategiesToString(topAntagonist),
-		ProtagonistStrategy:         evolution.StrategiesToString(topProtagonist),
-		AntagonistDominantStrategy:  evolution.DominantStrategy(topAntagonist),
-		ProtagonistDominantStrategy: evolution.DominantStrategy(topProtagonist),
-		AntagonistGeneration:        topAntGen,
-		ProtagonistGeneration:       topProGen,
-		AntagonistBirthGen:          topAntagonist.BirthGen,
-		ProtagonistBirthGen:         topProtagonist.BirthGen,
-		AntagonistAge:               topAntagonist.Age,
-		ProtagonistAge:              topProtagonist.Age,
-		AntagonistRun:               topAntRun,
-		ProtagonistRun:              topProRun,
-		AntagonistNoOfCompetitions:  topAntagonist.NoOfCompetitions,
-		ProtagonistNoOfCompetitions: topProtagonist.NoOfCompetitions,
+		SpecEquation:                  params.SpecParam.ExpressionParsed,
+		SpecRange:                     params.SpecParam.Range,
+		SpecSeed:                      params.SpecParam.Seed,
+		ErrorMetric:                   params.FitnessStrategy.ResolvedErrorMetric(),
+		Correlation:                   0,
+		Covariance:                    0,
+		AntagonistID:                  topAntagonist.Id,
+		ProtagonistID:                 topProtagonist.Id,
+		Antagonist:                    topAntagonist.AverageFitness,
+		Protagonist:                   topProtagonist.AverageFitness,
+		AntagonistBestFitness:         topAntagonist.BestFitness,
+		ProtagonistBestFitness:        topProtagonist.BestFitness,
+		AntagonistStdDev:              topAntagonist.FitnessStdDev,
+		ProtagonistStdDev:             topProtagonist.FitnessStdDev,
+		AntagonistAverageDelta:        topAntagonist.AverageDelta,
+		ProtagonistAverageDelta:       topProtagonist.AverageDelta,
+		AntagonistBestDelta:           topAntagonist.BestDelta,
+		ProtagonistBestDelta:          topProtagonist.BestDelta,
+		AntagonistEquation:            topAntagonistEq,
+		ProtagonistEquation:           topProtagonistEq,
+		AntagonistSimplifiedEquation:  simplifiedEquation(params, &topAntagonist),
+		ProtagonistSimplifiedEquation: simplifiedEquation(params, &topProtagonist),
+		AntagonistStrategy:            evolution.StrategiesToString(topAntagonist),
+		ProtagonistStrategy:           evolution.StrategiesToString(topProtagonist),
+		AntagonistDominantStrategy:    evolution.DominantStrategy(topAntagonist),
+		ProtagonistDominantStrategy:   evolution.DominantStrategy(topProtagonist),
+		AntagonistGeneration:          topAntGen,
+		ProtagonistGeneration:         topProGen,
+		AntagonistBirthGen:            topAntagonist.BirthGen,
+		ProtagonistBirthGen:           topProtagonist.BirthGen,
+		AntagonistAge:                 topAntagonist.Age,
+		ProtagonistAge:                topProtagonist.Age,
+		AntagonistRun:                 topAntRun,
+		ProtagonistRun:                topProRun,
+		AntagonistNoOfCompetitions:    topAntagonist.NoOfCompetitions,
+		ProtagonistNoOfCompetitions:   topProtagonist.NoOfCompetitions,
 	}
 
 	return simulationBestIndividuals, err
@@ -311,14 +331,22 @@ func (s *Simulation) GenerationalInRun(params evolution.EvolutionParams) (runGen
 		topProtagonistInGenerationByAvgFitness := run.Generational.BestProtagonistInEachGenerationByAvgFitness[i]
 		AntagonistEq, _ := topAntagonistInGenerationByAvgFitness.Program.T.ToMathematicalString()
 		ProtagonistEq, _ := topProtagonistInGenerationByAvgFitness.Program.T.ToMathematicalString()
+		protagonistTestFitness, protagonistTestDelta, err := topProtagonistInGenerationByAvgFitness.
+			GeneralisationScore(params)
+		if err != nil {
+			return nil, err
+		}
 
 		runGen[i] = RunGenerationalStatistic{
-			Generation:          i,
-			SpecEquation:        params.SpecParam.ExpressionParsed,
-			SpecRange:           params.SpecParam.Range,
-			SpecSeed:            params.SpecParam.Seed,
-			AntagonistEquation:  AntagonistEq,
-			ProtagonistEquation: ProtagonistEq,
+			Generation:                    i,
+			SpecEquation:                  params.SpecParam.ExpressionParsed,
+			SpecRange:                     params.SpecParam.Range,
+			SpecSeed:                      params.SpecParam.Seed,
+			ErrorMetric:                   params.FitnessStrategy.ResolvedErrorMetric(),
+			AntagonistEquation:            AntagonistEq,
+			ProtagonistEquation:           ProtagonistEq,
+			AntagonistSimplifiedEquation:  simplifiedEquation(params, &topAntagonistInGenerationByAvgFitness),
+			ProtagonistSimplifiedEquation: simplifiedEquation(params, &topProtagonistInGenerationByAvgFitness),
 
 			// Generational Stats
 			Correlation:           run.Generational.CorrelationInEachGeneration[i],
@@ -332,6 +360,11 @@ func (s *Simulation) GenerationalInRun(params evolution.EvolutionParams) (runGen
 			AntagonistExKurtosis:  run.Generational.AntagonistExKurtosisInEachGeneration[i],
 			ProtagonistExKurtosis: run.Generational.ProtagonistExKurtosisInEachGeneration[i],
 
+			AntagonistUniquePhenotypes:  run.Generational.AntagonistUniquePhenotypesInEachGeneration[i],
+			ProtagonistUniquePhenotypes: run.Generational.ProtagonistUniquePhenotypesInEachGeneration[i],
+			AntagonistMutationRate:      run.Generational.AntagonistMutationRateInEachGeneration[i],
+			ProtagonistMutationRate:     run.Generational.ProtagonistMutationRateInEachGeneration[i],
+
 			// Best Individual in Generation Stats
 			TopAntagonistMeanFitness:  topAntagonistInGenerationByAvgFitness.AverageFitness,
 			TopProtagonistMeanFitness: topProtagonistInGenerationByAvgFitness.AverageFitness,
@@ -352,6 +385,8 @@ func (s *Simulation) GenerationalInRun(params evolution.EvolutionParams) (runGen
 			ProtagonistBirthGen:         topProtagonistInGenerationByAvgFitness.BirthGen,
 			AntagonistAge:               topAntagonistInGenerationByAvgFitness.Age,
 			ProtagonistAge:              topProtagonistInGenerationByAvgFitness.Age,
+			ProtagonistTestFitness:      protagonistTestFitness,
+			ProtagonistTestDelta:        protagonistTestDelta,
 
 			Run: runIndex,
 		}
@@ -373,6 +408,10 @@ func (s *Simulation) EpochalInRun(params evolution.EvolutionParams) (runEpochal
 	topProtagonistEq, err := protagonist.Program.T.ToMathematicalString()
 	finalAntagonistEq, err := finalAntagonist.Program.T.ToMathematicalString()
 	finalProtagonistEq, err := finalProtagonist.Program.T.ToMathematicalString()
+	topAntagonistSimplifiedEq := simplifiedEquation(params, &antagonist)
+	topProtagonistSimplifiedEq := simplifiedEquation(params, &protagonist)
+	finalAntagonistSimplifiedEq := simplifiedEquation(params, &finalAntagonist)
+	finalProtagonistSimplifiedEq := simplifiedEquation(params, &finalProtagonist)
 
 	epochLength := params.EachPopulationSize
 	runEpochal = make([]RunEpochalStatistic, epochLength)
@@ -383,42 +422,47 @@ func (s *Simulation) EpochalInRun(params evolution.EvolutionParams) (runEpochal
 			SpecEquation: params.SpecParam.ExpressionParsed,
 			SpecRange:    params.SpecParam.Range,
 			SpecSeed:     params.SpecParam.Seed,
-
-			AntagonistID:                antagonist.Id,
-			ProtagonistID:               protagonist.Id,
-			Antagonist:                  antagonist.Fitness[i],
-			Protagonist:                 protagonist.Fitness[i],
-			AntagonistDelta:             antagonist.Deltas[i],
-			ProtagonistDelta:            protagonist.Deltas[i],
-			AntagonistStdDev:            antagonist.FitnessStdDev,
-			ProtagonistStdDev:           protagonist.FitnessStdDev,
-			AntagonistAverageDelta:      antagonist.AverageDelta,
-			ProtagonistAverageDelta:     protagonist.AverageDelta,
-			AntagonistBestDelta:         antagonist.BestDelta,
-			ProtagonistBestDelta:        protagonist.BestDelta,
-			AntagonistEquation:          topAntagonistEq,
-			ProtagonistEquation:         topProtagonistEq,
-			AntagonistStrategy:          evolution.StrategiesToString(antagonist),
-			ProtagonistStrategy:         evolution.StrategiesToString(protagonist),
-			AntagonistDominantStrategy:  evolution.DominantStrategy(antagonist),
-			ProtagonistDominantStrategy: evolution.DominantStrategy(protagonist),
-			AntagonistGeneration:        s.SimulationStats[runIndex].TopAntagonistGeneration,
-			ProtagonistGeneration:       s.SimulationStats[runIndex].TopProtagonistGeneration,
-
-			FinalAntagonist:                  finalAntagonist.Fitness[i],
-			FinalProtagonist:                 finalProtagonist.Fitness[i],
-			FinalAntagonistStdDev:            finalAntagonist.FitnessStdDev,
-			FinalProtagonistStdDev:           finalProtagonist.FitnessStdDev,
-			FinalAntagonistDelta:             finalAntagonist.Deltas[i],
-			FinalProtagonistDelta:            finalProtagonist.Deltas[i],
-			FinalAntagonistBestDelta:         finalAntagonist.BestDelta,
-			FinalProtagonistBestDelta:        finalProtagonist.BestDelta,
-			FinalAntagonistEquation:          finalAntagonistEq,
-			FinalProtagonistEquation:         finalProtagonistEq,
-			FinalAntagonistStrategy:          evolution.StrategiesToString(finalAntagonist),
-			FinalProtagonistStrategy:         evolution.StrategiesToString(finalProtagonist),
-			FinalAntagonistDominantStrategy:  evolution.DominantStrategy(finalAntagonist),
-			FinalProtagonistDominantStrategy: evolution.DominantStrategy(finalProtagonist),
+			ErrorMetric:  params.FitnessStrategy.ResolvedErrorMetric(),
+
+			AntagonistID:                  antagonist.Id,
+			ProtagonistID:                 protagonist.Id,
+			Antagonist:                    antagonist.Fitness[i],
+			Protagonist:                   protagonist.Fitness[i],
+			AntagonistDelta:               antagonist.Deltas[i],
+			ProtagonistDelta:              protagonist.Deltas[i],
+			AntagonistStdDev:              antagonist.FitnessStdDev,
+			ProtagonistStdDev:             protagonist.FitnessStdDev,
+			AntagonistAverageDelta:        antagonist.AverageDelta,
+			ProtagonistAverageDelta:       protagonist.AverageDelta,
+			AntagonistBestDelta:           antagonist.BestDelta,
+			ProtagonistBestDelta:          protagonist.BestDelta,
+			AntagonistEquation:            topAntagonistEq,
+			ProtagonistEquation:           topProtagonistEq,
+			AntagonistSimplifiedEquation:  topAntagonistSimplifiedEq,
+			ProtagonistSimplifiedEquation: topProtagonistSimplifiedEq,
+			AntagonistStrategy:            evolution.StrategiesToString(antagonist),
+			ProtagonistStrategy:           evolution.StrategiesToString(protagonist),
+			AntagonistDominantStrategy:    evolution.DominantStrategy(antagonist),
+			ProtagonistDominantStrategy:   evolution.DominantStrategy(protagonist),
+			AntagonistGeneration:          s.SimulationStats[runIndex].TopAntagonistGeneration,
+			ProtagonistGeneration:         s.SimulationStats[runIndex].TopProtagonistGeneration,
+
+			FinalAntagonist:                    finalAntagonist.Fitness[i],
+			FinalProtagonist:                   finalProtagonist.Fitness[i],
+			FinalAntagonistStdDev:              finalAntagonist.FitnessStdDev,
+			FinalProtagonistStdDev:             finalProtagonist.FitnessStdDev,
+			FinalAntagonistDelta:               finalAntagonist.Deltas[i],
+			FinalProtagonistDelta:              finalProtagonist.Deltas[i],
+			FinalAntagonistBestDelta:           finalAntagonist.BestDelta,
+			FinalProtagonistBestDelta:          finalProtagonist.BestDelta,
+			FinalAntagonistEquation:            finalAntagonistEq,
+			FinalProtagonistEquation:           finalProtagonistEq,
+			FinalAntagonistSimplifiedEquation:  finalAntagonistSimplifiedEq,
+			FinalProtagonistSimplifiedEquation: finalProtagonistSimplifiedEq,
+			FinalAntagonistStrategy:            evolution.StrategiesToString(finalAntagonist),
+			FinalProtagonistStrategy:           evolution.StrategiesToString(finalProtagonist),
+			FinalAntagonistDominantStrategy:    evolution.DominantStrategy(finalAntagonist),
+			FinalProtagonistDominantStrategy:   evolution.DominantStrategy(finalProtagonist),
 		}
 	}
 
@@ -455,6 +499,7 @@ func (s *Simulation) SimulationBestEpochal(params evolution.EvolutionParams) (be
 	//			SpecEquation: params.SpecParam.ParsedExpression,
 	//			SpecRange:    params.SpecParam.Range,
 	//			SpecSeed:     params.SpecParam.Seed,
+	//			ErrorMetric:  params.FitnessStrategy.ResolvedErrorMetric(),
 	//
 	//			Antagonist:                  antagonist.AverageFitness,
 	//			Protagonist:                 protagonist.AverageFitness,
@@ -541,6 +586,7 @@ func (s *Simulation) StrategyInRun(params evolution.EvolutionParams) (runStrateg
 			ProtagonistGeneration: run.TopProtagonistGeneration,
 			StrategyCount:         j,
 			Run:                   runIndex,
+			ErrorMetric:           params.FitnessStrategy.ResolvedErrorMetric(),
 		}
 	}
 
@@ -582,21 +628,38 @@ func (s *Simulation) SimulationBestStrategy(params evolution.EvolutionParams) (s
 		antEq, _ := antagonist.Program.T.ToMathematicalString()
 		proEq, _ := protagonist.Program.T.ToMathematicalString()
 		simulationStrategy[j] = SimulationStrategyStatistic{
-			Antagonist:            antStrat,
-			Protagonist:           proStrat,
-			AntagonistEquation:    antEq,
-			ProtagonistEquation:   proEq,
-			ProtagonistRun:        bestActualIndividuals.ProtagonistRun,
-			AntagonistRun:         bestActualIndividuals.AntagonistRun,
-			ProtagonistGeneration: bestActualIndividuals.ProtagonistGeneration,
-			AntagonistGeneration:  bestActualIndividuals.AntagonistGeneration,
-			StrategyCount:         j,
+			Antagonist:                    antStrat,
+			Protagonist:                   proStrat,
+			AntagonistEquation:            antEq,
+			ProtagonistEquation:           proEq,
+			AntagonistSimplifiedEquation:  simplifiedEquation(params, &antagonist),
+			ProtagonistSimplifiedEquation: simplifiedEquation(params, &protagonist),
+			ProtagonistRun:                bestActualIndividuals.ProtagonistRun,
+			AntagonistRun:                 bestActualIndividuals.AntagonistRun,
+			ProtagonistGeneration:         bestActualIndividuals.ProtagonistGeneration,
+			AntagonistGeneration:          bestActualIndividuals.AntagonistGeneration,
+			StrategyCount:                 j,
+			ErrorMetric:                   params.FitnessStrategy.ResolvedErrorMetric(),
 		}
 	}
 
 	return simulationStrategy, err
 }
 
+// simplifiedEquation returns the simplified equation of the individual if StatisticsOutput.SimplifyEquations is set,
+// otherwise it returns an empty string so the column stays blank.
+func simplifiedEquation(params evolution.EvolutionParams, individual *evolution.Individual) string {
+	if !params.StatisticsOutput.SimplifyEquations || individual == nil || individual.Program == nil ||
+		individual.Program.T == nil {
+		return ""
+	}
+	equation, err := individual.Program.T.SimplifiedMathematicalString()
+	if err != nil {
+		return ""
+	}
+	return equation
+}
+
 type RunBasedStatistics struct {
 	TopAntagonist          float64 `csv:"runTopA"`
 	TopProtagonist         float64 `csv:"runTopP"`
@@ -634,9 +697,14 @@ type RunGenerationalStatistic struct {
 	SpecEquation string `csv:"specEquation"`
 	SpecRange    int    `csv:"range"`
 	SpecSeed     int    `csv:"seed"`
+	ErrorMetric  string `csv:"errorMetric"`
 
 	AntagonistEquation  string `csv:"topAEquation"`
 	ProtagonistEquation string `csv:"topPEquation"`
+	// AntagonistSimplifiedEquation and ProtagonistSimplifiedEquation are only set if
+	// StatisticsOutput.SimplifyEquations is enabled.
+	AntagonistSimplifiedEquation  string `csv:"topASimpEquation"`
+	ProtagonistSimplifiedEquation string `csv:"topPSimpEquation"`
 
 	// Generation Stats
 	Correlation float64 `csv:"correlation"`
@@ -653,6 +721,13 @@ type RunGenerationalStatistic struct {
 	AntagonistExKurtosis  float64 `csv:"AExKur"`
 	ProtagonistExKurtosis float64 `csv:"PExKur"`
 
+	AntagonistUniquePhenotypes  int `csv:"AUnique"`
+	ProtagonistUniquePhenotypes int `csv:"PUnique"`
+
+	// AntagonistMutationRate and ProtagonistMutationRate are the effective mutation rates of the generation.
+	AntagonistMutationRate  float64 `csv:"AMutRate"`
+	ProtagonistMutationRate float64 `csv:"PMutRate"`
+
 	// Top Individual In Generation Stats
 	TopAntagonistMeanFitness    float64 `csv:"topAMean"`
 	TopProtagonistMeanFitness   float64 `csv:"topPMean"`
@@ -671,6 +746,11 @@ type RunGenerationalStatistic struct {
 	AntagonistAge               int     `csv:"topAAge"`
 	ProtagonistAge              int     `csv:"topPAge"`
 
+	// ProtagonistTestFitness and ProtagonistTestDelta score the top protagonist on the held out test spec.
+	// They are NaN if SpecParam.TestSplit is not set.
+	ProtagonistTestFitness float64 `csv:"topPTestFit"`
+	ProtagonistTestDelta   float64 `csv:"topPTestDelta"`
+
 	Run int `csv:"run"`
 }
 type RunGenerationalStatistics []RunGenerationalStatistic
@@ -697,6 +777,7 @@ type RunEpochalStatistic struct {
 	SpecEquation string `csv:"specEquation"`
 	SpecRange    int    `csv:"range"`
 	SpecSeed     int    `csv:"seed"`
+	ErrorMetric  string `csv:"errorMetric"`
 
 	AntagonistID      string  `csv:"AID"`
 	ProtagonistID     string  `csv:"PID"`
@@ -707,33 +788,37 @@ type RunEpochalStatistic struct {
 	AntagonistStdDev  float64 `csv:"AStdDev"`
 	ProtagonistStdDev float64 `csv:"PStdDev"`
 
-	AntagonistAverageDelta      float64 `csv:"AAvgDelta"`
-	ProtagonistAverageDelta     float64 `csv:"PAvgDelta"`
-	AntagonistBestDelta         float64 `csv:"ABestDelta"`
-	ProtagonistBestDelta        float64 `csv:"PBestDelta"`
-	AntagonistEquation          string  `csv:"AEquation"`
-	ProtagonistEquation         string  `csv:"PEquation"`
-	AntagonistStrategy          string  `csv:"AStrat"`
-	ProtagonistStrategy         string  `csv:"PStrat"`
-	AntagonistDominantStrategy  string  `csv:"ADomStrat"`
-	ProtagonistDominantStrategy string  `csv:"PDomStrat"`
-	AntagonistGeneration        int     `csv:"AGen"`
-	ProtagonistGeneration       int     `csv:"PGen"`
-
-	FinalAntagonist                  float64 `csv:"finA"`
-	FinalProtagonist                 float64 `csv:"finP"`
-	FinalAntagonistStdDev            float64 `csv:"finAStdDev"`
-	FinalProtagonistStdDev           float64 `csv:"finPStdDev"`
-	FinalAntagonistDelta             float64 `csv:"finADelta"`
-	FinalProtagonistDelta            float64 `csv:"finPDelta"`
-	FinalAntagonistBestDelta         float64 `csv:"finABestDelta"`
-	FinalProtagonistBestDelta        float64 `csv:"finPBestDelta"`
-	FinalAntagonistEquation          string  `csv:"finAEquation"`
-	FinalProtagonistEquation         string  `csv:"finPEquation"`
-	FinalAntagonistStrategy          string  `csv:"finAStrat"`
-	FinalProtagonistStrategy         string  `csv:"finPStrat"`
-	FinalAntagonistDominantStrategy  string  `csv:"finADomStrat"`
-	FinalProtagonistDominantStrategy string  `csv:"finPDomStrat"`
+	AntagonistAverageDelta        float64 `csv:"AAvgDelta"`
+	ProtagonistAverageDelta       float64 `csv:"PAvgDelta"`
+	AntagonistBestDelta           float64 `csv:"ABestDelta"`
+	ProtagonistBestDelta          float64 `csv:"PBestDelta"`
+	AntagonistEquation            string  `csv:"AEquation"`
+	ProtagonistEquation           string  `csv:"PEquation"`
+	AntagonistSimplifiedEquation  string  `csv:"ASimpEquation"`
+	ProtagonistSimplifiedEquation string  `csv:"PSimpEquation"`
+	AntagonistStrategy            string  `csv:"AStrat"`
+	ProtagonistStrategy           string  `csv:"PStrat"`
+	AntagonistDominantStrategy    string  `csv:"ADomStrat"`
+	ProtagonistDominantStrategy   string  `csv:"PDomStrat"`
+	AntagonistGeneration          int     `csv:"AGen"`
+	ProtagonistGeneration         int     `csv:"PGen"`
+
+	FinalAntagonist                    float64 `csv:"finA"`
+	FinalProtagonist                   float64 `csv:"finP"`
+	FinalAntagonistStdDev              float64 `csv:"finAStdDev"`
+	FinalProtagonistStdDev             float64 `csv:"finPStdDev"`
+	FinalAntagonistDelta               float64 `csv:"finADelta"`
+	FinalProtagonistDelta              float64 `csv:"finPDelta"`
+	FinalAntagonistBestDelta           float64 `csv:"finABestDelta"`
+	FinalProtagonistBestDelta          float64 `csv:"finPBestDelta"`
+	FinalAntagonistEquation            string  `csv:"finAEquation"`
+	FinalProtagonistEquation           string  `csv:"finPEquation"`
+	FinalAntagonistSimplifiedEquation  string  `csv:"finASimpEquation"`
+	FinalProtagonistSimplifiedEquation string  `csv:"finPSimpEquation"`
+	FinalAntagonistStrategy            string  `csv:"finAStrat"`
+	FinalProtagonistStrategy           string  `csv:"finPStrat"`
+	FinalAntagonistDominantStrategy    string  `csv:"finADomStrat"`
+	FinalProtagonistDominantStrategy   string  `csv:"finPDomStrat"`
 
 	Epoch int `csv:"epoch"`
 	Run   int `csv:"run"`
@@ -768,6 +853,7 @@ type RunStrategyStatistic struct {
 	ProtagonistGeneration int    `csv:"PGen"`
 	StrategyCount         int    `csv:"count"`
 	Run                   int    `csv:"run"`
+	ErrorMetric           string `csv:"errorMetric"`
 }
 
 type RunStrategyStatistics []RunStrategyStatistic
@@ -791,16 +877,19 @@ func (e *RunStrategyStatistics) ToCSV(outputPath string) error {
 }
 
 type SimulationStrategyStatistic struct {
-	Antagonist            string `csv:"A"`
-	Protagonist           string `csv:"P"`
-	AntagonistEquation    string `csv:"AEquation"`
-	ProtagonistEquation   string `csv:"PEquation"`
-	AntagonistGeneration  int    `csv:"AGen"`
-	ProtagonistGeneration int    `csv:"PGen"`
-	AntagonistRun         int    `csv:"ARun"`
-	ProtagonistRun        int    `csv:"PRun"`
-	StrategyCount         int    `csv:"count"`
-	Run                   int    `csv:"run"`
+	Antagonist                    string `csv:"A"`
+	Protagonist                   string `csv:"P"`
+	AntagonistEquation            string `csv:"AEquation"`
+	ProtagonistEquation           string `csv:"PEquation"`
+	AntagonistSimplifiedEquation  string `csv:"ASimpEquation"`
+	ProtagonistSimplifiedEquation string `csv:"PSimpEquation"`
+	AntagonistGeneration          int    `csv:"AGen"`
+	ProtagonistGeneration         int    `csv:"PGen"`
+	AntagonistRun                 int    `csv:"ARun"`
+	ProtagonistRun                int    `csv:"PRun"`
+	StrategyCount                 int    `csv:"count"`
+	Run                           int    `csv:"run"`
+	ErrorMetric                   string `csv:"errorMetric"`
 }
 
 type SimulationStrategyStatistics []SimulationStrategyStatistic
@@ -827,60 +916,70 @@ type RunBestIndividualStatistic struct {
 	SpecEquation string `csv:"specEquation"`
 	SpecRange    int    `csv:"range"`
 	SpecSeed     int    `csv:"seed"`
+	ErrorMetric  string `csv:"errorMetric"`
+
+	AntagonistID                  string  `csv:"AID"`
+	ProtagonistID                 string  `csv:"PID"`
+	Antagonist                    float64 `csv:"AAvg"`
+	Protagonist                   float64 `csv:"PAvg"`
+	MeanCorrelation               float64 `csv:"meanCorrInRun"`
+	MeanCovariance                float64 `csv:"meanCovInRun"`
+	AntagonistBestFitness         float64 `csv:"ABestFit"`
+	ProtagonistBestFitness        float64 `csv:"PBestFit"`
+	AntagonistStdDev              float64 `csv:"AStdDev"`
+	ProtagonistStdDev             float64 `csv:"PStdDev"`
+	AntagonistAverageDelta        float64 `csv:"AAvgDelta"`
+	ProtagonistAverageDelta       float64 `csv:"PAvgDelta"`
+	AntagonistBestDelta           float64 `csv:"ABestDelta"`
+	ProtagonistBestDelta          float64 `csv:"PBestDelta"`
+	AntagonistEquation            string  `csv:"AEquation"`
+	ProtagonistEquation           string  `csv:"PEquation"`
+	AntagonistSimplifiedEquation  string  `csv:"ASimpEquation"`
+	ProtagonistSimplifiedEquation string  `csv:"PSimpEquation"`
+	AntagonistStrategy            string  `csv:"AStrat"`
+	ProtagonistStrategy           string  `csv:"PStrat"`
+	AntagonistDominantStrategy    string  `csv:"ADomStrat"`
+	ProtagonistDominantStrategy   string  `csv:"PDomStrat"`
+	AntagonistGeneration          int     `csv:"AGen"`
+	ProtagonistGeneration         int     `csv:"PGen"`
+	AntagonistBirthGen            int     `csv:"ABirthGen"`
+	ProtagonistBirthGen           int     `csv:"PBirthGen"`
+	AntagonistAge                 int     `csv:"AAge"`
+	ProtagonistAge                int     `csv:"PAge"`
+	AntagonistNoOfCompetitions    int     `csv:"ANoC"`
+	ProtagonistNoOfCompetitions   int     `csv:"PNoC"`
+
+	FinalAntagonist                    float64 `csv:"finAAvg"`
+	FinalProtagonist                   float64 `csv:"finPAvg"`
+	FinalAntagonistBestFitness         float64 `csv:"finABestFit"`
+	FinalProtagonistBestFitness        float64 `csv:"finPBestFit"`
+	FinalAntagonistStdDev              float64 `csv:"finAStdDev"`
+	FinalProtagonistStdDev             float64 `csv:"finPStdDev"`
+	FinalAntagonistAverageDelta        float64 `csv:"finAAvgDelta"`
+	FinalProtagonistAverageDelta       float64 `csv:"finPAvgDelta"`
+	FinalAntagonistBestDelta           float64 `csv:"finABestDelta"`
+	FinalProtagonistBestDelta          float64 `csv:"finPBestDelta"`
+	FinalAntagonistEquation            string  `csv:"finAEquation"`
+	FinalProtagonistEquation           string  `csv:"finPEquation"`
+	FinalAntagonistSimplifiedEquation  string  `csv:"finASimpEquation"`
+	FinalProtagonistSimplifiedEquation string  `csv:"finPSimpEquation"`
+	FinalAntagonistStrategy            string  `csv:"finAStrat"`
+	FinalProtagonistStrategy           string  `csv:"finPStrat"`
+	FinalAntagonistDominantStrategy    string  `csv:"finADomStrat"`
+	FinalProtagonistDominantStrategy   string  `csv:"finPDomStrat"`
+	FinalAntagonistBirthGen            int     `csv:"finABirthGen"`
+	FinalProtagonistBirthGen           int     `csv:"finPBirthGen"`
+	FinalAntagonistAge                 int     `csv:"finAAge"`
+	FinalProtagonistAge                int     `csv:"finPAge"`
+	FinalAntagonistNoOfCompetitions    int     `csv:"finANoC"`
+	FinalProtagonistNoOfCompetitions   int     `csv:"finPNoC"`
+
+	// ProtagonistTestFitness and ProtagonistTestDelta score the top protagonist in the run on the held out test spec.
+	// They are NaN if SpecParam.TestSplit is not set.
+	ProtagonistTestFitness float64 `csv:"PTestFit"`
+	ProtagonistTestDelta   float64 `csv:"PTestDelta"`
 
-	AntagonistID                string  `csv:"AID"`
-	ProtagonistID               string  `csv:"PID"`
-	Antagonist                  float64 `csv:"AAvg"`
-	Protagonist                 float64 `csv:"PAvg"`
-	MeanCorrelation             float64 `csv:"meanCorrInRun"`
-	MeanCovariance              float64 `csv:"meanCovInRun"`
-	AntagonistBestFitness       float64 `csv:"ABestFit"`
-	ProtagonistBestFitness      float64 `csv:"PBestFit"`
-	AntagonistStdDev            float64 `csv:"AStdDev"`
-	ProtagonistStdDev           float64 `csv:"PStdDev"`
-	AntagonistAverageDelta      float64 `csv:"AAvgDelta"`
-	ProtagonistAverageDelta     float64 `csv:"PAvgDelta"`
-	AntagonistBestDelta         float64 `csv:"ABestDelta"`
-	ProtagonistBestDelta        float64 `csv:"PBestDelta"`
-	AntagonistEquation          string  `csv:"AEquation"`
-	ProtagonistEquation         string  `csv:"PEquation"`
-	AntagonistStrategy          string  `csv:"AStrat"`
-	ProtagonistStrategy         string  `csv:"PStrat"`
-	AntagonistDominantStrategy  string  `csv:"ADomStrat"`
-	ProtagonistDominantStrategy string  `csv:"PDomStrat"`
-	AntagonistGeneration        int     `csv:"AGen"`
-	ProtagonistGeneration       int     `csv:"PGen"`
-	AntagonistBirthGen          int     `csv:"ABirthGen"`
-	ProtagonistBirthGen         int     `csv:"PBirthGen"`
-	AntagonistAge               int     `csv:"AAge"`
-	ProtagonistAge              int     `csv:"PAge"`
-	AntagonistNoOfCompetitions       int `csv:"ANoC"`
-	ProtagonistNoOfCompetitions      int `csv:"PNoC"`
-
-	FinalAntagonist                  float64 `csv:"finAAvg"`
-	FinalProtagonist                 float64 `csv:"finPAvg"`
-	FinalAntagonistBestFitness       float64 `csv:"finABestFit"`
-	FinalProtagonistBestFitness      float64 `csv:"finPBestFit"`
-	FinalAntagonistStdDev            float64 `csv:"finAStdDev"`
-	FinalProtagonistStdDev           float64 `csv:"finPStdDev"`
-	FinalAntagonistAverageDelta      float64 `csv:"finAAvgDelta"`
-	FinalProtagonistAverageDelta     float64 `csv:"finPAvgDelta"`
-	FinalAntagonistBestDelta         float64 `csv:"finABestDelta"`
-	FinalProtagonistBestDelta        float64 `csv:"finPBestDelta"`
-	FinalAntagonistEquation          string  `csv:"finAEquation"`
-	FinalProtagonistEquation         string  `csv:"finPEquation"`
-	FinalAntagonistStrategy          string  `csv:"finAStrat"`
-	FinalProtagonistStrategy         string  `csv:"finPStrat"`
-	FinalAntagonistDominantStrategy  string  `csv:"finADomStrat"`
-	FinalProtagonistDominantStrategy string  `csv:"finPDomStrat"`
-	FinalAntagonistBirthGen          int     `csv:"finABirthGen"`
-	FinalProtagonistBirthGen         int     `csv:"finPBirthGen"`
-	FinalAntagonistAge               int     `csv:"finAAge"`
-	FinalProtagonistAge              int     `csv:"finPAge"`
-	FinalAntagonistNoOfCompetitions  int `csv:"finANoC"`
-	FinalProtagonistNoOfCompetitions int `csv:"finPNoC"`
-
-	Run                              int `csv:"run"`
+	Run int `csv:"run"`
 }
 
 type RunBestIndividualStatistics []RunBestIndividualStatistic
@@ -903,42 +1002,252 @@ func (e *RunBestIndividualStatistics) ToCSV(outputPath string) error {
 	return nil
 }
 
+// IndividualStrategyTrace holds the StrategyTrace of every epoch an individual competed in.
+type IndividualStrategyTrace struct {
+	ID       string                    `json:"id"`
+	Equation string                    `json:"equation"`
+	Strategy []evolution.Strategy      `json:"strategy"`
+	Traces   []evolution.StrategyTrace `json:"traces"`
+}
+
+func newIndividualStrategyTrace(individual *evolution.Individual) IndividualStrategyTrace {
+	trace := IndividualStrategyTrace{
+		ID:       individual.Id,
+		Strategy: individual.Strategy,
+		Traces:   individual.StrategyTraces,
+	}
+	if individual.Program != nil && individual.Program.T != nil {
+		trace.Equation, _ = individual.Program.T.ToMathematicalString()
+	}
+	return trace
+}
+
+// RunBestIndividualTrace shows how the strategies of the best individuals in a run transformed their trees. It is
+// only recorded if Strategies.EnableTrace is set.
+type RunBestIndividualTrace struct {
+	TopAntagonist    IndividualStrategyTrace `json:"topAntagonist"`
+	TopProtagonist   IndividualStrategyTrace `json:"topProtagonist"`
+	FinalAntagonist  IndividualStrategyTrace `json:"finalAntagonist"`
+	FinalProtagonist IndividualStrategyTrace `json:"finalProtagonist"`
+	Run              int                     `json:"run"`
+}
+
+// BestIndividualTraceInRun returns the strategy traces of the best individuals in the run.
+func (s *Simulation) BestIndividualTraceInRun(params evolution.EvolutionParams) (runTrace RunBestIndividualTrace,
+	err error) {
+	if s.SimulationStats == nil {
+		return RunBestIndividualTrace{}, fmt.Errorf("BestIndividualTraceInRun | simulationStats is nil")
+	}
+
+	run := &s.SimulationStats[params.InternalCount]
+	return RunBestIndividualTrace{
+		TopAntagonist:    newIndividualStrategyTrace(&run.TopAntagonist),
+		TopProtagonist:   newIndividualStrategyTrace(&run.TopProtagonist),
+		FinalAntagonist:  newIndividualStrategyTrace(&run.FinalAntagonist),
+		FinalProtagonist: newIndividualStrategyTrace(&run.FinalProtagonist),
+		Run:              params.InternalCount,
+	}, nil
+}
+
+func (t *RunBestIndividualTrace) ToJSON(outputPath string) error {
+	outputFileJSON, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer outputFileJSON.Close()
+
+	encoder := json.NewEncoder(outputFileJSON)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(t)
+}
+
+// RunStrategyCreditStatistic is the credit a strategy was given for the fitness of individuals of a kind in a run,
+// see evolution.StrategyCredit.
+type RunStrategyCreditStatistic struct {
+	Kind     string `csv:"kind"`
+	Strategy string `csv:"strategy"`
+	Genes    int    `csv:"genes"`
+	// AblationEffect is measured against the top individual of the other kind, which the top individual may never
+	// have competed against, see evolution.StrategyCredits.
+	AblationEffect float64 `csv:"ablationEffectVsTopOpponent"`
+	Presence       float64 `csv:"presence"`
+	Correlation    float64 `csv:"corr"`
+	Run            int     `csv:"run"`
+	ErrorMetric    string  `csv:"errorMetric"`
+}
+
+type RunStrategyCreditStatistics []RunStrategyCreditStatistic
+
+// StrategyCreditInRun returns the credit every strategy was given in the run.
+func (s *Simulation) StrategyCreditInRun(params evolution.EvolutionParams) (runCredit RunStrategyCreditStatistics,
+	err error) {
+	if s.SimulationStats == nil {
+		return nil, fmt.Errorf("StrategyCreditInRun | simulationStats is nil")
+	}
+
+	strategyCredits := s.SimulationStats[params.InternalCount].StrategyCredits
+	runCredit = make(RunStrategyCreditStatistics, len(strategyCredits))
+	for i, credit := range strategyCredits {
+		runCredit[i] = RunStrategyCreditStatistic{
+			Kind:           evolution.KindToString(credit.Kind),
+			Strategy:       string(credit.Strategy),
+			Genes:          credit.Genes,
+			AblationEffect: credit.AblationEffect,
+			Presence:       credit.Presence,
+			Correlation:    credit.Correlation,
+			Run:            params.InternalCount,
+			ErrorMetric:    params.FitnessStrategy.ResolvedErrorMetric(),
+		}
+	}
+	return runCredit, nil
+}
+
+func (e *RunStrategyCreditStatistics) ToCSV(outputPath string) error {
+	outputFileCSV, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer outputFileCSV.Close()
+
+	writer := gocsv.DefaultCSVWriter(outputFileCSV)
+	if writer.Error() != nil {
+		return writer.Error()
+	}
+	return gocsv.Marshal(e, outputFileCSV)
+}
+
+// SimulationStrategyCreditStatistic is the credit a strategy was given for the fitness of individuals of a kind
+// averaged over the runs of the simulation. Runs where the credit is NaN are left out of the respective mean.
+type SimulationStrategyCreditStatistic struct {
+	Kind     string `csv:"kind"`
+	Strategy string `csv:"strategy"`
+	// Runs is the number of runs the strategy was credited in and AblatedRuns the number of those where the top
+	// individual held it.
+	Runs        int     `csv:"runs"`
+	AblatedRuns int     `csv:"ablatedRuns"`
+	MeanGenes   float64 `csv:"meanGenes"`
+	// MeanAblationEffect is measured against the top individual of the other kind in each run, see
+	// RunStrategyCreditStatistic.AblationEffect.
+	MeanAblationEffect float64 `csv:"meanAblationEffectVsTopOpponent"`
+	MeanPresence       float64 `csv:"meanPresence"`
+	MeanCorrelation    float64 `csv:"meanCorr"`
+	ErrorMetric        string  `csv:"errorMetric"`
+}
+
+type SimulationStrategyCreditStatistics []SimulationStrategyCreditStatistic
+
+// SimulationStrategyCredit averages the credit every strategy was given over the runs of the simulation. Antagonist
+// credits are followed by protagonist credits, each sorted by strategy.
+func (s *Simulation) SimulationStrategyCredit(params evolution.EvolutionParams) (
+	simulationCredit SimulationStrategyCreditStatistics, err error) {
+	if s.SimulationStats == nil {
+		return nil, fmt.Errorf("SimulationStrategyCredit | simulationStats is nil")
+	}
+
+	type creditKey struct {
+		kind     int
+		strategy evolution.Strategy
+	}
+	credits := map[creditKey][]evolution.StrategyCredit{}
+	keys := make([]creditKey, 0)
+	for i := range s.SimulationStats {
+		for _, credit := range s.SimulationStats[i].StrategyCredits {
+			key := creditKey{credit.Kind, credit.Strategy}
+			if _, ok := credits[key]; !ok {
+				keys = append(keys, key)
+			}
+			credits[key] = append(credits[key], credit)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind == evolution.IndividualAntagonist
+		}
+		return keys[i].strategy < keys[j].strategy
+	})
+
+	simulationCredit = make(SimulationStrategyCreditStatistics, len(keys))
+	for i, key := range keys {
+		genes, ablationEffects, presences, correlations := make([]float64, 0), make([]float64, 0),
+			make([]float64, 0), make([]float64, 0)
+		for _, credit := range credits[key] {
+			genes = append(genes, float64(credit.Genes))
+			presences = append(presences, credit.Presence)
+			if !math.IsNaN(credit.AblationEffect) {
+				ablationEffects = append(ablationEffects, credit.AblationEffect)
+			}
+			if !math.IsNaN(credit.Correlation) {
+				correlations = append(correlations, credit.Correlation)
+			}
+		}
+		simulationCredit[i] = SimulationStrategyCreditStatistic{
+			Kind:               evolution.KindToString(key.kind),
+			Strategy:           string(key.strategy),
+			Runs:               len(credits[key]),
+			AblatedRuns:        len(ablationEffects),
+			MeanGenes:          stat.Mean(genes, nil),
+			MeanAblationEffect: stat.Mean(ablationEffects, nil),
+			MeanPresence:       stat.Mean(presences, nil),
+			MeanCorrelation:    stat.Mean(correlations, nil),
+			ErrorMetric:        params.FitnessStrategy.ResolvedErrorMetric(),
+		}
+	}
+	return simulationCredit, nil
+}
+
+func (e *SimulationStrategyCreditStatistics) ToCSV(outputPath string) error {
+	outputFileCSV, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer outputFileCSV.Close()
+
+	writer := gocsv.DefaultCSVWriter(outputFileCSV)
+	if writer.Error() != nil {
+		return writer.Error()
+	}
+	return gocsv.Marshal(e, outputFileCSV)
+}
+
 type SimulationBestIndividual struct {
 	SpecEquation string `csv:"specEquation"`
 	SpecRange    int    `csv:"range"`
 	SpecSeed     int    `csv:"seed"`
+	ErrorMetric  string `csv:"errorMetric"`
 
 	Correlation float64 `csv:"corr"`
 	Covariance  float64 `csv:"cov"`
 
-	AntagonistID                string  `csv:"AID"`
-	ProtagonistID               string  `csv:"PID"`
-	Antagonist                  float64 `csv:"AAvg"`
-	Protagonist                 float64 `csv:"PAvg"`
-	AntagonistBestFitness       float64 `csv:"ABestFit"`
-	ProtagonistBestFitness      float64 `csv:"PBestFit"`
-	AntagonistStdDev            float64 `csv:"AStdDev"`
-	ProtagonistStdDev           float64 `csv:"PStdDev"`
-	AntagonistAverageDelta      float64 `csv:"AAverageDelta"`
-	ProtagonistAverageDelta     float64 `csv:"PAverageDelta"`
-	AntagonistBestDelta         float64 `csv:"ABestDelta"`
-	ProtagonistBestDelta        float64 `csv:"PBestDelta"`
-	AntagonistEquation          string  `csv:"AEquation"`
-	ProtagonistEquation         string  `csv:"PEquation"`
-	AntagonistStrategy          string  `csv:"AStrat"`
-	ProtagonistStrategy         string  `csv:"PStrat"`
-	AntagonistDominantStrategy  string  `csv:"ADomStrat"`
-	ProtagonistDominantStrategy string  `csv:"PDomStrat"`
-	AntagonistGeneration        int     `csv:"AGen"`
-	ProtagonistGeneration       int     `csv:"PGen"`
-	AntagonistBirthGen          int     `csv:"ABirthGen"`
-	ProtagonistBirthGen         int     `csv:"PBirthGen"`
-	AntagonistAge               int     `csv:"AAge"`
-	ProtagonistAge              int     `csv:"PAge"`
-	AntagonistRun               int     `csv:"ARun"`
-	ProtagonistRun              int     `csv:"PRun"`
-	AntagonistNoOfCompetitions       int `csv:"ANoC"`
-	ProtagonistNoOfCompetitions      int `csv:"PNoC"`
+	AntagonistID                  string  `csv:"AID"`
+	ProtagonistID                 string  `csv:"PID"`
+	Antagonist                    float64 `csv:"AAvg"`
+	Protagonist                   float64 `csv:"PAvg"`
+	AntagonistBestFitness         float64 `csv:"ABestFit"`
+	ProtagonistBestFitness        float64 `csv:"PBestFit"`
+	AntagonistStdDev              float64 `csv:"AStdDev"`
+	ProtagonistStdDev             float64 `csv:"PStdDev"`
+	AntagonistAverageDelta        float64 `csv:"AAverageDelta"`
+	ProtagonistAverageDelta       float64 `csv:"PAverageDelta"`
+	AntagonistBestDelta           float64 `csv:"ABestDelta"`
+	ProtagonistBestDelta          float64 `csv:"PBestDelta"`
+	AntagonistEquation            string  `csv:"AEquation"`
+	ProtagonistEquation           string  `csv:"PEquation"`
+	AntagonistSimplifiedEquation  string  `csv:"ASimpEquation"`
+	ProtagonistSimplifiedEquation string  `csv:"PSimpEquation"`
+	AntagonistStrategy            string  `csv:"AStrat"`
+	ProtagonistStrategy           string  `csv:"PStrat"`
+	AntagonistDominantStrategy    string  `csv:"ADomStrat"`
+	ProtagonistDominantStrategy   string  `csv:"PDomStrat"`
+	AntagonistGeneration          int     `csv:"AGen"`
+	ProtagonistGeneration         int     `csv:"PGen"`
+	AntagonistBirthGen            int     `csv:"ABirthGen"`
+	ProtagonistBirthGen           int     `csv:"PBirthGen"`
+	AntagonistAge                 int     `csv:"AAge"`
+	ProtagonistAge                int     `csv:"PAge"`
+	AntagonistRun                 int     `csv:"ARun"`
+	ProtagonistRun                int     `csv:"PRun"`
+	AntagonistNoOfCompetitions    int     `csv:"ANoC"`
+	ProtagonistNoOfCompetitions   int     `csv:"PNoC"`
 }
 
 type SimulationBestIndividuals []SimulationBestIndividual
@@ -966,32 +1275,35 @@ type SimulationBestEpoch struct {
 	SpecEquation string `csv:"specEquation"`
 	SpecRange    int    `csv:"range"`
 	SpecSeed     int    `csv:"seed"`
-
-	Epoch                       int     `csv:"epoch"`
-	Antagonist                  float64 `csv:"AAvg"`
-	Protagonist                 float64 `csv:"PAvg"`
-	AntagonistBestDelta         float64 `csv:"ABestDelta"`
-	ProtagonistBestDelta        float64 `csv:"PBestDelta"`
-	AntagonistAverageDelta      float64 `csv:"PAverageDelta"`
-	ProtagonistAverageDelta     float64 `csv:"PAverageDelta"`
-	AntagonistBestFitness       float64 `csv:"ABestFit"`
-	ProtagonistBestFitness      float64 `csv:"PBestFit"`
-	AntagonistStdDev            float64 `csv:"AStdDev"`
-	ProtagonistStdDev           float64 `csv:"PStdDev"`
-	AntagonistEquation          string  `csv:"AEquation"`
-	ProtagonistEquation         string  `csv:"PEquation"`
-	AntagonistStrategy          string  `csv:"AStrat"`
-	ProtagonistStrategy         string  `csv:"PStrat"`
-	AntagonistDominantStrategy  string  `csv:"ADomStrat"`
-	ProtagonistDominantStrategy string  `csv:"PDomStrat"`
-	AntagonistGeneration        int     `csv:"AGen"`
-	ProtagonistGeneration       int     `csv:"PGen"`
-	AntagonistBirthGen          int     `csv:"ABirthGen"`
-	ProtagonistBirthGen         int     `csv:"PBirthGen"`
-	AntagonistAge               int     `csv:"AAge"`
-	ProtagonistAge              int     `csv:"PAge"`
-	AntagonistRun               int     `csv:"ARun"`
-	ProtagonistRun              int     `csv:"PRun"`
+	ErrorMetric  string `csv:"errorMetric"`
+
+	Epoch                         int     `csv:"epoch"`
+	Antagonist                    float64 `csv:"AAvg"`
+	Protagonist                   float64 `csv:"PAvg"`
+	AntagonistBestDelta           float64 `csv:"ABestDelta"`
+	ProtagonistBestDelta          float64 `csv:"PBestDelta"`
+	AntagonistAverageDelta        float64 `csv:"PAverageDelta"`
+	ProtagonistAverageDelta       float64 `csv:"PAverageDelta"`
+	AntagonistBestFitness         float64 `csv:"ABestFit"`
+	ProtagonistBestFitness        float64 `csv:"PBestFit"`
+	AntagonistStdDev              float64 `csv:"AStdDev"`
+	ProtagonistStdDev             float64 `csv:"PStdDev"`
+	AntagonistEquation            string  `csv:"AEquation"`
+	ProtagonistEquation           string  `csv:"PEquation"`
+	AntagonistSimplifiedEquation  string  `csv:"ASimpEquation"`
+	ProtagonistSimplifiedEquation string  `csv:"PSimpEquation"`
+	AntagonistStrategy            string  `csv:"AStrat"`
+	ProtagonistStrategy           string  `csv:"PStrat"`
+	AntagonistDominantStrategy    string  `csv:"ADomStrat"`
+	ProtagonistDominantStrategy   string  `csv:"PDomStrat"`
+	AntagonistGeneration          int     `csv:"AGen"`
+	ProtagonistGeneration         int     `csv:"PGen"`
+	AntagonistBirthGen            int     `csv:"ABirthGen"`
+	ProtagonistBirthGen           int     `csv:"PBirthGen"`
+	AntagonistAge                 int     `csv:"AAge"`
+	ProtagonistAge                int     `csv:"PAge"`
+	AntagonistRun                 int     `csv:"ARun"`
+	ProtagonistRun                int     `csv:"PRun"`
 }
 
 type SimulationBestEpochs []SimulationBestEpoch